@@ -0,0 +1,493 @@
+// Command savetool inspects and repairs save files.
+//
+//	savetool info     [flags] save.gz
+//	savetool dump     [flags] [-format json|table] [-component name] save.gz
+//	savetool validate [flags] save.gz
+//	savetool migrate  [flags] [-o out.gz] save.gz
+//	savetool reencode [flags] [-o out.gz] [-out-password pw] [-out-compress=false] save.gz
+//	savetool import   [flags] -o save.gz edited.json
+//
+// Common flags: -password decrypts an encrypted save, -compress=false reads an
+// uncompressed one. Errors go to stderr and exit non-zero.
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	"harvester/pkg/ecs"
+)
+
+const usage = `usage: savetool <command> [flags] <file>
+
+commands:
+  info      print snapshot metadata and component counts
+  dump      print the snapshot as pretty JSON or per-component tables
+  validate  check entity ids and that payloads decode; exits 1 when issues are found
+  migrate   run pending snapshot migrations and write the result
+  reencode  rewrite a save with different compression/password
+  import    encode an edited JSON snapshot back into a save
+`
+
+type commonFlags struct {
+	password string
+	compress bool
+}
+
+func (c *commonFlags) register(fs *flag.FlagSet) {
+	fs.StringVar(&c.password, "password", "", "password the save was encrypted with")
+	fs.BoolVar(&c.compress, "compress", true, "input is gzip-compressed")
+}
+
+func (c commonFlags) options() ecs.SaveOptions {
+	return ecs.SaveOptions{Password: c.password, Compress: c.compress}
+}
+
+func main() {
+	if err := run(os.Args[1:], os.Stdout); err != nil {
+		fmt.Fprintln(os.Stderr, "savetool:", err)
+		os.Exit(1)
+	}
+}
+
+func run(args []string, out io.Writer) error {
+	if len(args) == 0 {
+		return errors.New(usage)
+	}
+	cmd, rest := args[0], args[1:]
+	switch cmd {
+	case "info":
+		return cmdInfo(rest, out)
+	case "dump":
+		return cmdDump(rest, out)
+	case "validate":
+		return cmdValidate(rest, out)
+	case "migrate":
+		return cmdMigrate(rest, out)
+	case "reencode":
+		return cmdReencode(rest, out)
+	case "import":
+		return cmdImport(rest, out)
+	case "help", "-h", "--help":
+		fmt.Fprint(out, usage)
+		return nil
+	}
+	return fmt.Errorf("unknown command %q\n%s", cmd, usage)
+}
+
+func parse(fs *flag.FlagSet, args []string) (string, error) {
+	if err := fs.Parse(args); err != nil {
+		return "", err
+	}
+	if fs.NArg() != 1 {
+		return "", fmt.Errorf("%s: expected exactly one file argument", fs.Name())
+	}
+	return fs.Arg(0), nil
+}
+
+func readSnapshot(path string, opt ecs.SaveOptions) (*ecs.Snapshot, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("read %s: %w", path, err)
+	}
+	defer f.Close()
+	s, err := decode(f, opt)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return s, nil
+}
+
+// decode reads an encoded save from in.
+func decode(in io.Reader, opt ecs.SaveOptions) (*ecs.Snapshot, error) {
+	b, err := io.ReadAll(in)
+	if err != nil {
+		return nil, fmt.Errorf("read: %w", err)
+	}
+	s, err := ecs.DecodeSnapshot(b, opt)
+	if err != nil {
+		return nil, fmt.Errorf("decode (wrong password or -compress?): %w", err)
+	}
+	return s, nil
+}
+
+// encode writes s to dst as a save.
+func encode(dst io.Writer, s *ecs.Snapshot, opt ecs.SaveOptions) error {
+	b, err := ecs.EncodeSnapshot(s, opt)
+	if err != nil {
+		return fmt.Errorf("encode: %w", err)
+	}
+	_, err = dst.Write(b)
+	return err
+}
+
+func writeSnapshot(path string, s *ecs.Snapshot, opt ecs.SaveOptions) error {
+	var buf bytes.Buffer
+	if err := encode(&buf, s, opt); err != nil {
+		return err
+	}
+	return writeFile(path, buf.Bytes())
+}
+
+// writeFile replaces path with b through a temporary file, so a failed write
+// never leaves a half-written save behind.
+func writeFile(path string, b []byte) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".savetool-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(b); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+func cmdInfo(args []string, out io.Writer) error {
+	fs := flag.NewFlagSet("info", flag.ContinueOnError)
+	var cf commonFlags
+	cf.register(fs)
+	path, err := parse(fs, args)
+	if err != nil {
+		return err
+	}
+	s, err := readSnapshot(path, cf.options())
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(out, "file:      %s\n", path)
+	fmt.Fprintf(out, "version:   %d (current %d)\n", s.Version, ecs.CurrentSnapshotVersion())
+	fmt.Fprintf(out, "seed:      %d\n", s.Seed)
+	fmt.Fprintf(out, "entities:  %d allocated, %d free\n", s.Next, len(s.Free))
+	if h, err := ecs.SnapshotHash(s); err == nil {
+		fmt.Fprintf(out, "hash:      %s\n", h)
+	}
+	for _, k := range sortedKeys(s.Meta) {
+		fmt.Fprintf(out, "meta:      %s=%s\n", k, s.Meta[k])
+	}
+	if ctx, ok := worldContext(s); ok {
+		fmt.Fprintf(out, "layer:     %s\n", layerName(ctx.CurrentLayer))
+		fmt.Fprintf(out, "planet:    %d depth %d biome %d\n", ctx.PlanetID, ctx.Depth, ctx.BiomeType)
+	}
+	fmt.Fprintln(out, "components:")
+	tw := tabwriter.NewWriter(out, 0, 2, 2, ' ', 0)
+	for _, name := range sortedKeys(s.Components) {
+		fmt.Fprintf(tw, "  %s\t%d\n", name, len(s.Components[name]))
+	}
+	return tw.Flush()
+}
+
+func cmdDump(args []string, out io.Writer) error {
+	fs := flag.NewFlagSet("dump", flag.ContinueOnError)
+	var cf commonFlags
+	cf.register(fs)
+	format := fs.String("format", "json", "output format: json or table")
+	component := fs.String("component", "", "only dump components whose type name contains this string")
+	path, err := parse(fs, args)
+	if err != nil {
+		return err
+	}
+	s, err := readSnapshot(path, cf.options())
+	if err != nil {
+		return err
+	}
+	if *component != "" {
+		for name := range s.Components {
+			if !strings.Contains(name, *component) {
+				delete(s.Components, name)
+			}
+		}
+	}
+	switch *format {
+	case "json":
+		b, err := json.MarshalIndent(s, "", "  ")
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Fprintln(out, string(b))
+		return err
+	case "table":
+		for _, name := range sortedKeys(s.Components) {
+			if err := writeTable(out, name, s.Components[name]); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	return fmt.Errorf("unknown format %q (want json or table)", *format)
+}
+
+func cmdValidate(args []string, out io.Writer) error {
+	fs := flag.NewFlagSet("validate", flag.ContinueOnError)
+	var cf commonFlags
+	cf.register(fs)
+	path, err := parse(fs, args)
+	if err != nil {
+		return err
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("read %s: %w", path, err)
+	}
+	defer f.Close()
+	if err := validate(f, cf.options(), out); err != nil {
+		return fmt.Errorf("%s: %w", path, err)
+	}
+	return nil
+}
+
+// validate reports the problems with the save read from in, one per line,
+// and fails if there are any.
+func validate(in io.Reader, opt ecs.SaveOptions, out io.Writer) error {
+	s, err := decode(in, opt)
+	if err != nil {
+		return err
+	}
+	issues := ecs.ValidateSnapshot(s)
+	for _, is := range issues {
+		fmt.Fprintln(out, is)
+	}
+	// A trial load into a scratch world catches payloads that do not decode.
+	report, err := ecs.Load(ecs.NewWorld(nil), s, nil)
+	if err != nil {
+		return fmt.Errorf("load: %w", err)
+	}
+	var failed int
+	for _, c := range report.Components {
+		for _, le := range c.Errors {
+			fmt.Fprintf(out, "%s[%d]: does not decode: %v\n", c.Component, le.Entity, le.Err)
+		}
+		if extra := c.Failed - len(c.Errors); extra > 0 {
+			fmt.Fprintf(out, "%s: %d more row(s) do not decode\n", c.Component, extra)
+		}
+		failed += c.Failed
+	}
+	if n := len(issues) + failed; n > 0 {
+		return fmt.Errorf("%d issue(s) found", n)
+	}
+	fmt.Fprintln(out, "ok")
+	return nil
+}
+
+func cmdMigrate(args []string, out io.Writer) error {
+	fs := flag.NewFlagSet("migrate", flag.ContinueOnError)
+	var cf commonFlags
+	cf.register(fs)
+	dest := fs.String("o", "", "output file (default: overwrite input)")
+	path, err := parse(fs, args)
+	if err != nil {
+		return err
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("read %s: %w", path, err)
+	}
+	defer f.Close()
+	var buf bytes.Buffer
+	if err := migrate(f, cf.options(), &buf, out); err != nil {
+		return fmt.Errorf("%s: %w", path, err)
+	}
+	if buf.Len() == 0 {
+		return nil
+	}
+	if *dest == "" {
+		*dest = path
+	}
+	if err := writeFile(*dest, buf.Bytes()); err != nil {
+		return err
+	}
+	fmt.Fprintf(out, "wrote %s\n", *dest)
+	return nil
+}
+
+// migrate runs the pending migrations on the save read from in and writes
+// the result to dst. Nothing is written when the save is already current.
+func migrate(in io.Reader, opt ecs.SaveOptions, dst, out io.Writer) error {
+	s, err := decode(in, opt)
+	if err != nil {
+		return err
+	}
+	from := s.Version
+	if err := ecs.MigrateSnapshot(s); err != nil {
+		return fmt.Errorf("migrate from version %d: %w", from, err)
+	}
+	if s.Version == from {
+		fmt.Fprintf(out, "no migrations pending (version %d)\n", from)
+		return nil
+	}
+	if err := encode(dst, s, opt); err != nil {
+		return err
+	}
+	fmt.Fprintf(out, "migrated version %d -> %d\n", from, s.Version)
+	return nil
+}
+
+func cmdReencode(args []string, out io.Writer) error {
+	fs := flag.NewFlagSet("reencode", flag.ContinueOnError)
+	var cf commonFlags
+	cf.register(fs)
+	dest := fs.String("o", "", "output file (default: overwrite input)")
+	outPassword := fs.String("out-password", "", "password for the output (empty: unencrypted)")
+	outCompress := fs.Bool("out-compress", true, "gzip-compress the output")
+	path, err := parse(fs, args)
+	if err != nil {
+		return err
+	}
+	s, err := readSnapshot(path, cf.options())
+	if err != nil {
+		return err
+	}
+	if *dest == "" {
+		*dest = path
+	}
+	if err := writeSnapshot(*dest, s, ecs.SaveOptions{Password: *outPassword, Compress: *outCompress}); err != nil {
+		return err
+	}
+	fmt.Fprintf(out, "wrote %s\n", *dest)
+	return nil
+}
+
+func cmdImport(args []string, out io.Writer) error {
+	fs := flag.NewFlagSet("import", flag.ContinueOnError)
+	var cf commonFlags
+	cf.register(fs)
+	dest := fs.String("o", "", "save file to write (required)")
+	force := fs.Bool("force", false, "write even if validation finds issues")
+	path, err := parse(fs, args)
+	if err != nil {
+		return err
+	}
+	if *dest == "" {
+		return errors.New("import: -o is required")
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("read %s: %w", path, err)
+	}
+	defer f.Close()
+	var buf bytes.Buffer
+	if err := importJSON(f, cf.options(), *force, &buf, out); err != nil {
+		return fmt.Errorf("%s: %w", path, err)
+	}
+	if err := writeFile(*dest, buf.Bytes()); err != nil {
+		return err
+	}
+	fmt.Fprintf(out, "wrote %s\n", *dest)
+	return nil
+}
+
+// importJSON encodes the JSON snapshot read from in as a save written to
+// dst. A snapshot that does not validate is refused unless force is set.
+func importJSON(in io.Reader, opt ecs.SaveOptions, force bool, dst, out io.Writer) error {
+	var s ecs.Snapshot
+	if err := json.NewDecoder(in).Decode(&s); err != nil {
+		return fmt.Errorf("parse: %w", err)
+	}
+	if issues := ecs.ValidateSnapshot(&s); len(issues) > 0 {
+		for _, is := range issues {
+			fmt.Fprintln(out, is)
+		}
+		if !force {
+			return fmt.Errorf("%d issue(s) found; rerun with -force to write anyway", len(issues))
+		}
+	}
+	return encode(dst, &s, opt)
+}
+
+// writeTable prints one component type as a table with one row per entity
+// and one column per top-level JSON field.
+func writeTable(out io.Writer, name string, data map[ecs.Entity]json.RawMessage) error {
+	entities := make([]ecs.Entity, 0, len(data))
+	rows := make(map[ecs.Entity]map[string]any, len(data))
+	cols := map[string]bool{}
+	for e, raw := range data {
+		entities = append(entities, e)
+		var fields map[string]any
+		if err := json.Unmarshal(raw, &fields); err != nil {
+			// scalar or malformed payloads get a single column
+			fields = map[string]any{"value": string(raw)}
+		}
+		rows[e] = fields
+		for k := range fields {
+			cols[k] = true
+		}
+	}
+	sort.Slice(entities, func(i, j int) bool { return entities[i] < entities[j] })
+	header := sortedKeys(cols)
+
+	fmt.Fprintf(out, "== %s (%d)\n", name, len(entities))
+	tw := tabwriter.NewWriter(out, 0, 2, 2, ' ', 0)
+	fmt.Fprintf(tw, "entity\t%s\n", strings.Join(header, "\t"))
+	for _, e := range entities {
+		cells := make([]string, len(header))
+		for i, k := range header {
+			cells[i] = formatCell(rows[e][k])
+		}
+		fmt.Fprintf(tw, "%d\t%s\n", e, strings.Join(cells, "\t"))
+	}
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+	_, err := fmt.Fprintln(out)
+	return err
+}
+
+func formatCell(v any) string {
+	switch x := v.(type) {
+	case nil:
+		return "-"
+	case string:
+		return x
+	case float64, bool:
+		return fmt.Sprint(x)
+	}
+	b, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprint(v)
+	}
+	return string(b)
+}
+
+func worldContext(s *ecs.Snapshot) (ecs.WorldContext, bool) {
+	for _, raw := range s.Components["ecs.WorldContext"] {
+		var ctx ecs.WorldContext
+		if json.Unmarshal(raw, &ctx) == nil {
+			return ctx, true
+		}
+	}
+	return ecs.WorldContext{}, false
+}
+
+func layerName(l ecs.GameLayer) string {
+	switch l {
+	case ecs.LayerSpace:
+		return "space"
+	case ecs.LayerPlanetSurface:
+		return "planet surface"
+	case ecs.LayerPlanetDeep:
+		return "planet deep"
+	}
+	return fmt.Sprintf("unknown (%d)", int(l))
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
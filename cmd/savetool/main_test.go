@@ -0,0 +1,196 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"reflect"
+	"strings"
+	"testing"
+
+	"harvester/pkg/components"
+	"harvester/pkg/ecs"
+)
+
+var testOpt = ecs.SaveOptions{Compress: true}
+
+// snapshot saves a world holding one positioned entity.
+func snapshot(t *testing.T) *ecs.Snapshot {
+	t.Helper()
+	w := ecs.NewWorld(nil)
+	ecs.Add(w, w.Create(), components.Position{X: 3, Y: 4})
+	s, err := ecs.Save(w, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return s
+}
+
+// v1 is a version 1 snapshot with an inventory in the old counts-map form.
+func v1(t *testing.T) *ecs.Snapshot {
+	s := snapshot(t)
+	s.Version = 1
+	s.Components["components.Inventory"] = map[ecs.Entity]json.RawMessage{
+		1: json.RawMessage(`{"Items":{"wood":2,"ore":3,"gone":0}}`),
+	}
+	return s
+}
+
+// broken has a component on an entity the allocator never handed out.
+func broken(t *testing.T) *ecs.Snapshot {
+	s := snapshot(t)
+	s.Components["components.Position"][99] = json.RawMessage(`{"X":1,"Y":1}`)
+	return s
+}
+
+func encoded(t *testing.T, s *ecs.Snapshot) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	if err := encode(&buf, s, testOpt); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func asJSON(t *testing.T, s *ecs.Snapshot) []byte {
+	t.Helper()
+	b, err := json.Marshal(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return b
+}
+
+func decoded(t *testing.T, b []byte) *ecs.Snapshot {
+	t.Helper()
+	s, err := decode(bytes.NewReader(b), testOpt)
+	if err != nil {
+		t.Fatalf("output does not decode: %v", err)
+	}
+	return s
+}
+
+func TestCommands(t *testing.T) {
+	validateCmd := func(in io.Reader, _, out io.Writer) error { return validate(in, testOpt, out) }
+	migrateCmd := func(in io.Reader, dst, out io.Writer) error { return migrate(in, testOpt, dst, out) }
+	importCmd := func(force bool) func(io.Reader, io.Writer, io.Writer) error {
+		return func(in io.Reader, dst, out io.Writer) error { return importJSON(in, testOpt, force, dst, out) }
+	}
+	tests := []struct {
+		name    string
+		run     func(in io.Reader, dst, out io.Writer) error
+		in      func(t *testing.T) []byte
+		wantErr string
+		wantOut string
+		check   func(t *testing.T, dst []byte)
+	}{
+		{
+			name:    "validate a good save",
+			run:     validateCmd,
+			in:      func(t *testing.T) []byte { return encoded(t, snapshot(t)) },
+			wantOut: "ok",
+		},
+		{
+			name:    "validate a save with a stray entity",
+			run:     validateCmd,
+			in:      func(t *testing.T) []byte { return encoded(t, broken(t)) },
+			wantErr: "1 issue(s) found",
+			wantOut: "components.Position[99]: entity outside allocated range",
+		},
+		{
+			name:    "validate something that is not a save",
+			run:     validateCmd,
+			in:      func(*testing.T) []byte { return []byte("not a save") },
+			wantErr: "wrong password or -compress?",
+		},
+		{
+			name:    "migrate a version 1 save",
+			run:     migrateCmd,
+			in:      func(t *testing.T) []byte { return encoded(t, v1(t)) },
+			wantOut: "migrated version 1 -> 2",
+			check: func(t *testing.T, dst []byte) {
+				s := decoded(t, dst)
+				if s.Version != 2 {
+					t.Fatalf("expected version 2, got %d", s.Version)
+				}
+				var inv components.Inventory
+				if err := json.Unmarshal(s.Components["components.Inventory"][1], &inv); err != nil {
+					t.Fatal(err)
+				}
+				want := []components.ItemStack{{Item: "ore", Count: 3}, {Item: "wood", Count: 2}}
+				if !reflect.DeepEqual(inv.Stacks, want) {
+					t.Fatalf("expected stacks %+v, got %+v", want, inv.Stacks)
+				}
+			},
+		},
+		{
+			name:    "migrate a current save",
+			run:     migrateCmd,
+			in:      func(t *testing.T) []byte { return encoded(t, snapshot(t)) },
+			wantOut: "no migrations pending (version 2)",
+			check: func(t *testing.T, dst []byte) {
+				if len(dst) != 0 {
+					t.Fatal("a current save should not be rewritten")
+				}
+			},
+		},
+		{
+			name: "import an edited snapshot",
+			run:  importCmd(false),
+			in:   func(t *testing.T) []byte { return asJSON(t, snapshot(t)) },
+			check: func(t *testing.T, dst []byte) {
+				s := decoded(t, dst)
+				if got := string(s.Components["components.Position"][1]); got != `{"X":3,"Y":4}` {
+					t.Fatalf("the position should survive the import, got %s", got)
+				}
+			},
+		},
+		{
+			name:    "import refuses a broken snapshot",
+			run:     importCmd(false),
+			in:      func(t *testing.T) []byte { return asJSON(t, broken(t)) },
+			wantErr: "rerun with -force",
+			wantOut: "components.Position[99]",
+			check: func(t *testing.T, dst []byte) {
+				if len(dst) != 0 {
+					t.Fatal("nothing should be written")
+				}
+			},
+		},
+		{
+			name:    "import a broken snapshot by force",
+			run:     importCmd(true),
+			in:      func(t *testing.T) []byte { return asJSON(t, broken(t)) },
+			wantOut: "components.Position[99]",
+			check: func(t *testing.T, dst []byte) {
+				if s := decoded(t, dst); len(s.Components["components.Position"]) != 2 {
+					t.Fatal("a forced import should keep every row")
+				}
+			},
+		},
+		{
+			name:    "import malformed JSON",
+			run:     importCmd(false),
+			in:      func(*testing.T) []byte { return []byte("{") },
+			wantErr: "parse",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var dst, out bytes.Buffer
+			err := tt.run(bytes.NewReader(tt.in(t)), &dst, &out)
+			switch {
+			case tt.wantErr == "" && err != nil:
+				t.Fatalf("unexpected error: %v", err)
+			case tt.wantErr != "" && (err == nil || !strings.Contains(err.Error(), tt.wantErr)):
+				t.Fatalf("expected an error containing %q, got %v", tt.wantErr, err)
+			}
+			if !strings.Contains(out.String(), tt.wantOut) {
+				t.Fatalf("expected output containing %q, got %q", tt.wantOut, out.String())
+			}
+			if tt.check != nil {
+				tt.check(t, dst.Bytes())
+			}
+		})
+	}
+}
@@ -0,0 +1,119 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss/v2"
+	"harvester/pkg/components"
+	"harvester/pkg/data"
+	"harvester/pkg/ecs"
+	"harvester/pkg/rendering"
+	"harvester/pkg/systems"
+)
+
+// craftingMenu is the planet-side crafting overlay opened with 'c'. It lists
+// every recipe with what is missing, and the player's pack underneath.
+type craftingMenu struct {
+	model    *Model
+	recipes  []data.Recipe
+	selected int
+	w, h     int
+}
+
+func newCraftingMenu(model *Model) *craftingMenu {
+	return &craftingMenu{model: model, recipes: data.Recipes()}
+}
+
+// HandleInput moves the selection or crafts, and reports whether the menu
+// stays open.
+func (c *craftingMenu) HandleInput(a InputAction) bool {
+	switch a.Kind {
+	case InputMenuBack, InputCraft:
+		return false
+	case InputMenuUp, InputMoveUp:
+		if c.selected > 0 {
+			c.selected--
+		}
+	case InputMenuDown, InputMoveDown:
+		if c.selected < len(c.recipes)-1 {
+			c.selected++
+		}
+	case InputMenuSelect, InputEnter:
+		if len(c.recipes) > 0 {
+			c.model.Craft(c.recipes[c.selected].ID)
+		}
+	}
+	return true
+}
+
+func (c *craftingMenu) GetLayer() rendering.Layer { return rendering.LayerMenu }
+func (c *craftingMenu) GetZ() int                 { return rendering.ZMenu }
+
+func (c *craftingMenu) ToLipglossLayer() *lipgloss.Layer {
+	width := min(max(40, c.w-10), 72)
+	panel := ThemedPanel("Crafting", c.content(width-4), ecs.GetWorldContext(c.model.world).CurrentLayer, width, 0)
+	x := max(0, (c.w-width)/2)
+	return lipgloss.NewLayer(panel).X(x).Y(2).Z(c.GetZ()).ID("crafting")
+}
+
+func (c *craftingMenu) content(width int) string {
+	w, player := c.model.world, c.model.player
+	var b strings.Builder
+	for i, r := range c.recipes {
+		cursor := "  "
+		if i == c.selected {
+			cursor = "> "
+		}
+		line := cursor + r.Name + " — " + recipeNeeds(r)
+		if err := systems.CanCraft(w, player, r); err != nil {
+			b.WriteString(Muted(truncate(line, width)) + "\n")
+			if i == c.selected {
+				b.WriteString(Muted("    "+truncate(err.Error(), width-4)) + "\n")
+			}
+			continue
+		}
+		if i == c.selected {
+			line = Highlight(truncate(line, width))
+		}
+		b.WriteString(line + "\n")
+	}
+
+	inv, _ := ecs.Get[components.Inventory](w, player)
+	weight, volume := systems.InventoryLoad(inv)
+	b.WriteString("\n" + Header("Pack"))
+	if hold, limited := systems.CarryCapacity(w, player); limited {
+		b.WriteString(fmt.Sprintf("  %.1f/%.0f kg  %.1f/%.0f L", weight, hold.Weight, volume, hold.Volume))
+	}
+	b.WriteString("\n")
+	if len(inv.Stacks) == 0 {
+		b.WriteString(Muted("  (empty)") + "\n")
+	}
+	for _, s := range inv.Stacks {
+		def, _ := data.Item(s.Item)
+		line := fmt.Sprintf("  %3d %s", s.Count, def.Name)
+		if def.Slot != data.SlotNone && inv.Equipped[def.Slot.String()] == s.Item {
+			line += " (" + def.Slot.String() + ")"
+		}
+		b.WriteString(line + "\n")
+	}
+	b.WriteString("\n" + Muted("↑/↓ or j/k select · enter craft · esc close"))
+	return b.String()
+}
+
+// recipeNeeds summarises a recipe's inputs and requirements.
+func recipeNeeds(r data.Recipe) string {
+	parts := make([]string, 0, len(r.Inputs)+2)
+	for _, in := range r.Inputs {
+		def, _ := data.Item(in.Item)
+		parts = append(parts, fmt.Sprintf("%d %s", in.Count, def.Name))
+	}
+	for _, t := range r.Tools {
+		def, _ := data.Item(t)
+		parts = append(parts, "["+def.Name+"]")
+	}
+	if r.Station != "" {
+		parts = append(parts, "@"+r.Station)
+	}
+	return strings.Join(parts, ", ")
+}
@@ -9,6 +9,9 @@ import (
 type PlanetScreen struct {
 	model         *Model
 	width, height int
+	inventory     *inventoryMenu
+	crafting      *craftingMenu
+	upgrades      *upgradeMenu
 }
 
 func (p *PlanetScreen) RegisterContent(renderer *rendering.ViewRenderer) {
@@ -25,6 +28,21 @@ func (p *PlanetScreen) RegisterContent(renderer *rendering.ViewRenderer) {
 	}
 	renderer.RegisterContent(newHUDContent(p.model))
 	renderer.RegisterContent(newUIRightPanel(p.model))
+	if len(p.model.log) > 0 {
+		renderer.RegisterContent(newMessageLogContent(p.model, p.width, p.height))
+	}
+	if p.inventory != nil {
+		p.inventory.w, p.inventory.h = p.width, p.height
+		renderer.RegisterContent(p.inventory)
+	}
+	if p.crafting != nil {
+		p.crafting.w, p.crafting.h = p.width, p.height
+		renderer.RegisterContent(p.crafting)
+	}
+	if p.upgrades != nil {
+		p.upgrades.w, p.upgrades.h = p.width, p.height
+		renderer.RegisterContent(p.upgrades)
+	}
 }
 
 func NewPlanetScreen(model *Model) *PlanetScreen {
@@ -50,6 +68,36 @@ func (p *PlanetScreen) View() string {
 }
 
 func (p *PlanetScreen) HandleInput(a InputAction) tea.Cmd {
+	// an open overlay takes all input
+	if p.inventory != nil {
+		if !p.inventory.HandleInput(a) {
+			p.inventory = nil
+		}
+		return nil
+	}
+	if p.crafting != nil {
+		if !p.crafting.HandleInput(a) {
+			p.crafting = nil
+		}
+		return nil
+	}
+	if p.upgrades != nil {
+		if !p.upgrades.HandleInput(a) {
+			p.upgrades = nil
+		}
+		return nil
+	}
+	switch a.Kind {
+	case InputInventory:
+		p.inventory = newInventoryMenu(p.model)
+		return nil
+	case InputCraft:
+		p.crafting = newCraftingMenu(p.model)
+		return nil
+	case InputUpgrade:
+		p.upgrades = newUpgradeMenu(p.model)
+		return nil
+	}
 	p.model.ApplyAction(a)
 	return nil
 }
@@ -7,6 +7,7 @@ import (
 	"harvester/pkg/components"
 	"harvester/pkg/ecs"
 	"harvester/pkg/rendering"
+	"harvester/pkg/systems"
 )
 
 func buildGameGlyphs(m *Model, w, h int) [][]rendering.Glyph {
@@ -17,11 +18,19 @@ func buildGameGlyphs(m *Model, w, h int) [][]rendering.Glyph {
 	m.render.Update(0, m.world)
 	cam, _ := ecs.Get[components.Camera](m.world, m.player)
 	mx0, my0 := cam.X, cam.Y
+	vis := systems.VisibilityOf(m.world)
 	glyphs := make([][]rendering.Glyph, h)
 	for y := 0; y < h; y++ {
 		row := make([]rendering.Glyph, w)
 		for x := 0; x < w; x++ {
-			row[x] = rendering.Glyph{Char: '.'}
+			switch vis(mx0+x, my0+y) {
+			case systems.VisUnknown:
+				row[x] = rendering.Glyph{Char: ' '}
+			case systems.VisRemembered:
+				row[x] = rendering.Glyph{Char: '.', Style: rendering.StyleDim}
+			default:
+				row[x] = rendering.Glyph{Char: '.'}
+			}
 		}
 		glyphs[y] = row
 	}
@@ -29,7 +38,11 @@ func buildGameGlyphs(m *Model, w, h int) [][]rendering.Glyph {
 		x := d.X - mx0
 		y := d.Y - my0
 		if x >= 0 && y >= 0 && x < w && y < h {
-			glyphs[y][x] = rendering.Glyph{Char: rune(d.Glyph)}
+			g := rendering.Glyph{Char: rune(d.Glyph)}
+			if d.Dim {
+				g.Style = rendering.StyleDim
+			}
+			glyphs[y][x] = g
 		}
 	}
 	return glyphs
@@ -0,0 +1,37 @@
+package ui
+
+import (
+	"github.com/charmbracelet/lipgloss/v2"
+	"harvester/pkg/rendering"
+)
+
+// messageLogContent draws the most recent player messages along the bottom of
+// the map area.
+type messageLogContent struct {
+	model *Model
+	w, h  int
+}
+
+func newMessageLogContent(model *Model, w, h int) *messageLogContent {
+	return &messageLogContent{model: model, w: w, h: h}
+}
+
+func (l *messageLogContent) GetLayer() rendering.Layer { return rendering.LayerHUD }
+func (l *messageLogContent) GetZ() int                 { return rendering.ZHUD }
+
+func (l *messageLogContent) ToLipglossLayer() *lipgloss.Layer {
+	if l.model == nil || len(l.model.log) == 0 {
+		return lipgloss.NewLayer("").X(0).Y(0).Z(l.GetZ()).ID("message-log")
+	}
+	msgs := l.model.log
+	y := l.h - 3 - len(msgs)
+	if y < 0 {
+		msgs = msgs[-y:]
+		y = 0
+	}
+	return lipgloss.NewLayer(LogPanel(msgs, max(0, l.w-2))).
+		X(1).
+		Y(y).
+		Z(l.GetZ()).
+		ID("message-log")
+}
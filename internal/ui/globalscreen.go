@@ -1,10 +1,13 @@
 package ui
 
 import (
+	"errors"
+	"fmt"
 	"math/rand"
 	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
+	"harvester/pkg/debug"
 	"harvester/pkg/ecs"
 	"harvester/pkg/rendering"
 	"harvester/pkg/timing"
@@ -70,6 +73,8 @@ type GlobalScreen struct {
 
 	// Save game manager
 	saveManager *SaveGameManager
+	// gameOverSeen is set once the end of the current run has been handled
+	gameOverSeen bool
 }
 
 func NewGlobalScreen() *GlobalScreen {
@@ -154,6 +159,7 @@ func (g *GlobalScreen) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	if keyMsg, ok := msg.(tea.KeyMsg); ok {
 		if keyMsg.String() == "ctrl+c" {
 			// Immediate quit on Ctrl+C
+			g.saveOnQuit()
 			return g, tea.Quit
 		}
 
@@ -192,10 +198,19 @@ func (g *GlobalScreen) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		if space, ok := g.subScreen.(*SpaceScreen); ok {
 			ctx := ecs.GetWorldContext(space.model.World())
 			if ctx.CurrentLayer == ecs.LayerPlanetSurface {
-				return g.transitionToPlanet(&StartResult{Action: ActionContinue})
+				return g.transitionToPlanet(space.model)
 			}
 		}
 	}
+	if g.currentScreen == ScreenPlanet {
+		if planet, ok := g.subScreen.(*PlanetScreen); ok {
+			ctx := ecs.GetWorldContext(planet.model.World())
+			if ctx.CurrentLayer == ecs.LayerSpace {
+				return g.returnToSpace(planet.model)
+			}
+		}
+	}
+	g.checkGameOver()
 
 	return g, cmd
 }
@@ -259,6 +274,7 @@ func (g *GlobalScreen) SetDimensions(width, height int) {
 
 func (g *GlobalScreen) HandleInput(a InputAction) tea.Cmd {
 	if a.Kind == InputQuit {
+		g.saveOnQuit()
 		g.startShutdownAnimation()
 		return nil
 	}
@@ -284,7 +300,7 @@ func (g *GlobalScreen) handleStartScreenResult(result *StartResult) (tea.Model,
 		g.startShutdownAnimation()
 		return g, nil
 
-	case ActionContinue, ActionLoadSlot, ActionNewGame:
+	case ActionContinue, ActionLoadSlot, ActionNewGame, ActionContinueIronman:
 		// Transition to space screen (games start in space)
 		return g.transitionToSpace(result)
 
@@ -306,9 +322,9 @@ func (g *GlobalScreen) transitionToSpace(startResult *StartResult) (tea.Model, t
 	return g, g.subScreen.Init()
 }
 
-func (g *GlobalScreen) transitionToPlanet(startResult *StartResult) (tea.Model, tea.Cmd) {
-	// Create new planet screen based on start result
-	planetScreen := g.createPlanetScreen(startResult)
+func (g *GlobalScreen) transitionToPlanet(model *Model) (tea.Model, tea.Cmd) {
+	// The planet screen continues the same run, so it shares the space model
+	planetScreen := g.createPlanetScreen(model)
 
 	g.nextScreen = ScreenPlanet
 	g.nextSubScreen = planetScreen
@@ -319,6 +335,20 @@ func (g *GlobalScreen) transitionToPlanet(startResult *StartResult) (tea.Model,
 	return g, g.subScreen.Init()
 }
 
+// returnToSpace swaps the planet screen for space after a launch. The run
+// continues on the same model.
+func (g *GlobalScreen) returnToSpace(model *Model) (tea.Model, tea.Cmd) {
+	spaceScreen := NewSpaceScreen(model)
+	if g.width > 0 && g.height > 0 {
+		spaceScreen.SetDimensions(g.width, g.height)
+	}
+	g.nextScreen = ScreenSpace
+	g.nextSubScreen = spaceScreen
+	g.transitioning = true
+	g.completeTransition()
+	return g, nil
+}
+
 func (g *GlobalScreen) createSpaceScreen(result *StartResult) SubScreen {
 	// Create model with appropriate save data loaded
 	model := g.createModelWithSaveData(result)
@@ -333,9 +363,7 @@ func (g *GlobalScreen) createSpaceScreen(result *StartResult) SubScreen {
 	return spaceScreen
 }
 
-func (g *GlobalScreen) createPlanetScreen(result *StartResult) SubScreen {
-	// Create model with appropriate save data loaded
-	model := g.createModelWithSaveData(result)
+func (g *GlobalScreen) createPlanetScreen(model *Model) SubScreen {
 	// Create planet exploration screen
 	planetScreen := NewPlanetScreen(model)
 
@@ -352,29 +380,112 @@ func (g *GlobalScreen) createModelWithSaveData(result *StartResult) *Model {
 	r := rand.New(rand.NewSource(time.Now().UnixNano()))
 	model := NewModelWithRNG(r)
 
+	// A new model starts a new run; only an ironman game rebinds one
+	g.saveManager.run = nil
+	g.gameOverSeen = false
+
 	// Load save data based on start result
 	switch result.Action {
+	case ActionContinueIronman:
+		report, err := g.saveManager.LoadIronman(model.World())
+		if errors.Is(err, ErrIronmanTampered) || errors.Is(err, ErrIronmanRunOver) {
+			model.Notify(LogError, "Ironman save rejected: "+err.Error())
+		}
+		reportLoad(&model, report, err)
+
 	case ActionContinue:
 		// Load autosave
-		err := g.saveManager.LoadAutosave(model.World())
-		if err != nil {
-			// Log error but continue with new game
-		}
+		report, err := g.saveManager.LoadAutosave(model.World())
+		reportLoad(&model, report, err)
 
 	case ActionLoadSlot:
 		// Load specific slot
-		err := g.saveManager.LoadSlot(result.SlotNum, model.World())
-		if err != nil {
-			// Log error but continue with new game
-		}
+		report, err := g.saveManager.LoadSlot(result.SlotName, model.World())
+		reportLoad(&model, report, err)
 
 	case ActionNewGame:
 		// Start fresh - no loading needed, starts in LayerSpace by default
+		if result.Ironman {
+			if err := g.saveManager.StartIronman(model.World()); err != nil {
+				debug.Warnf("save", "Failed to start ironman run: %v", err)
+				model.Notify(LogError, "Ironman save failed; this run is not protected")
+			} else {
+				model.Notify(LogWarning, "Ironman run started: death is permanent")
+			}
+		}
 	}
 
 	return &model
 }
 
+// reportLoad tells the player when a save only partly loaded. A failed load
+// continues with a new game, as before, but now says so.
+func reportLoad(model *Model, report *ecs.LoadReport, err error) {
+	if err != nil {
+		debug.Warnf("save", "Load failed: %v", err)
+		model.Notify(LogError, "Save could not be loaded; starting a new game")
+		return
+	}
+	if report == nil || report.Clean() {
+		return
+	}
+	debug.Warnf("save", "Partial load: %d component(s) dropped", report.Failed())
+	model.Notify(LogWarning, fmt.Sprintf("Warning: save loaded with %d error(s)", report.Failed()+len(report.Unknown)))
+	for _, w := range report.Warnings() {
+		debug.Warn("save", w)
+		model.Notify(LogWarning, "  "+w)
+	}
+}
+
+// gameModel returns the model behind the current game screen, if any.
+func (g *GlobalScreen) gameModel() *Model {
+	switch sc := g.subScreen.(type) {
+	case *SpaceScreen:
+		return sc.model
+	case *PlanetScreen:
+		return sc.model
+	}
+	return nil
+}
+
+// saveOnQuit writes the ironman save when the player leaves mid-run, so
+// quitting is never a way to roll back.
+func (g *GlobalScreen) saveOnQuit() {
+	model := g.gameModel()
+	if model == nil || g.gameOverSeen {
+		return
+	}
+	if _, ok := g.saveManager.IronmanRun(); !ok {
+		return
+	}
+	if err := g.saveManager.SaveIronman(model.World()); err != nil {
+		debug.Errorf("save", "Ironman save on quit failed: %v", err)
+	}
+}
+
+// checkGameOver handles the end of a run once: ironman saves are deleted and
+// the player is told why the run ended.
+func (g *GlobalScreen) checkGameOver() {
+	model := g.gameModel()
+	if model == nil || g.gameOverSeen {
+		return
+	}
+	ctx := ecs.GetWorldContext(model.World())
+	if !ctx.GameOver {
+		return
+	}
+	g.gameOverSeen = true
+	model.Notify(LogError, "Game over: "+ctx.GameOverReason)
+	if _, ok := g.saveManager.IronmanRun(); ok {
+		if err := g.saveManager.EndIronman(ctx.GameOverReason); err != nil {
+			debug.Errorf("save", "Failed to end ironman run: %v", err)
+			model.Notify(LogError, "Ironman save could not be deleted: "+err.Error())
+			return
+		}
+		model.Notify(LogError, "Ironman save deleted")
+	}
+}
+
 func (g *GlobalScreen) completeTransition() {
 	g.currentScreen = g.nextScreen
 	g.subScreen = g.nextSubScreen
@@ -24,6 +24,17 @@ const (
 	InputMenuSelect
 	InputMenuBack
 	InputDebugToggle
+	InputScan
+	InputWait
+	InputHarvest
+	InputCraft
+	InputUpgrade
+	InputWarp
+	InputTravel
+	InputInventory
+	InputEquip
+	InputDrop
+	InputThrow
 )
 
 type InputAction struct{ Kind InputKind }
@@ -0,0 +1,140 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss/v2"
+	"harvester/pkg/components"
+	"harvester/pkg/data"
+	"harvester/pkg/ecs"
+	"harvester/pkg/rendering"
+	"harvester/pkg/systems"
+)
+
+// inventoryMenu is the planet-side pack overlay opened with 'i'. It lists
+// what the player carries and uses, equips, drops or throws the selected
+// stack. Throwing asks for a direction first.
+type inventoryMenu struct {
+	model    *Model
+	selected int
+	aiming   bool
+	w, h     int
+}
+
+func newInventoryMenu(model *Model) *inventoryMenu {
+	return &inventoryMenu{model: model}
+}
+
+// HandleInput moves the selection or acts on the selected stack, and
+// reports whether the menu stays open.
+func (v *inventoryMenu) HandleInput(a InputAction) bool {
+	stacks := v.stacks()
+	v.selected = max(0, min(v.selected, len(stacks)-1))
+	if v.aiming {
+		v.aiming = false
+		dx, dy := aimOf(a.Kind)
+		if (dx != 0 || dy != 0) && len(stacks) > 0 {
+			v.model.ThrowItem(stacks[v.selected].Item, dx, dy)
+		}
+		return true
+	}
+	switch a.Kind {
+	case InputMenuBack, InputInventory:
+		return false
+	case InputMenuUp, InputMoveUp:
+		if v.selected > 0 {
+			v.selected--
+		}
+	case InputMenuDown, InputMoveDown:
+		if v.selected < len(stacks)-1 {
+			v.selected++
+		}
+	}
+	if len(stacks) == 0 {
+		return true
+	}
+	item := stacks[v.selected].Item
+	switch a.Kind {
+	case InputMenuSelect, InputEnter:
+		v.model.UseItem(item)
+	case InputEquip:
+		v.model.EquipItem(item)
+	case InputDrop:
+		v.model.DropItem(item, 1)
+	case InputThrow:
+		v.aiming = true
+	}
+	return true
+}
+
+// aimOf turns a movement key into a throwing direction.
+func aimOf(k InputKind) (int, int) {
+	switch k {
+	case InputMoveLeft, InputMenuLeft:
+		return -1, 0
+	case InputMoveRight, InputMenuRight:
+		return 1, 0
+	case InputMoveUp, InputMenuUp:
+		return 0, -1
+	case InputMoveDown, InputMenuDown, InputScan:
+		return 0, 1
+	}
+	return 0, 0
+}
+
+func (v *inventoryMenu) stacks() []components.ItemStack {
+	inv, _ := ecs.Get[components.Inventory](v.model.world, v.model.player)
+	return inv.Stacks
+}
+
+func (v *inventoryMenu) GetLayer() rendering.Layer { return rendering.LayerMenu }
+func (v *inventoryMenu) GetZ() int                 { return rendering.ZMenu }
+
+func (v *inventoryMenu) ToLipglossLayer() *lipgloss.Layer {
+	width := min(max(40, v.w-10), 72)
+	panel := ThemedPanel("Pack", v.content(width-4), ecs.GetWorldContext(v.model.world).CurrentLayer, width, 0)
+	x := max(0, (v.w-width)/2)
+	return lipgloss.NewLayer(panel).X(x).Y(2).Z(v.GetZ()).ID("inventory")
+}
+
+func (v *inventoryMenu) content(width int) string {
+	w, player := v.model.world, v.model.player
+	inv, _ := ecs.Get[components.Inventory](w, player)
+	var b strings.Builder
+	if hold, limited := systems.CarryCapacity(w, player); limited {
+		weight, volume := systems.InventoryLoad(inv)
+		b.WriteString(fmt.Sprintf("%.1f/%.0f kg  %.1f/%.0f L\n\n", weight, hold.Weight, volume, hold.Volume))
+	}
+	if len(inv.Stacks) == 0 {
+		b.WriteString(Muted("  (empty)") + "\n")
+	}
+	for i, s := range inv.Stacks {
+		def, _ := data.Item(s.Item)
+		cursor := "  "
+		if i == v.selected {
+			cursor = "> "
+		}
+		line := fmt.Sprintf("%s%3d %s", cursor, s.Count, def.Name)
+		if def.Slot != data.SlotNone && inv.Equipped[def.Slot.String()] == s.Item {
+			line += " (" + def.Slot.String() + ")"
+		}
+		if i == v.selected {
+			line = Highlight(truncate(line, width))
+		}
+		b.WriteString(line + "\n")
+	}
+	hint := "↑/↓ or j/k select · enter use · e equip · x drop · f throw · esc close"
+	if v.aiming {
+		hint = "Throw which way? (direction keys)"
+	}
+	b.WriteString("\n" + Muted(truncate(hint, width)))
+	return b.String()
+}
+
+func truncate(s string, n int) string {
+	if n <= 0 || len([]rune(s)) <= n {
+		return s
+	}
+	return string([]rune(s)[:n-1]) + "…"
+}
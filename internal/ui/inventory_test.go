@@ -0,0 +1,46 @@
+package ui
+
+import (
+	"testing"
+
+	"harvester/pkg/ecs"
+	"harvester/pkg/systems"
+)
+
+func TestInventoryMenuQueuesItemActions(t *testing.T) {
+	model := NewModel(nil)
+	p := NewPlanetScreen(&model)
+	if systems.AddItem(model.world, model.player, "knife", 2) != 2 {
+		t.Fatal("the knives should fit in the pack")
+	}
+	press := func(k InputKind) { p.HandleInput(InputAction{Kind: k}) }
+
+	press(InputInventory)
+	if p.inventory == nil {
+		t.Fatal("i should open the pack")
+	}
+	press(InputEquip)
+	if in, _ := ecs.Get[systems.EquipIntent](model.world, model.player); in.Item != "knife" {
+		t.Fatalf("e should equip the selected item, got %+v", in)
+	}
+	press(InputDrop)
+	if in, _ := ecs.Get[systems.DropIntent](model.world, model.player); in != (systems.DropIntent{Item: "knife", Count: 1}) {
+		t.Fatalf("x should drop one of the selected item, got %+v", in)
+	}
+	press(InputThrow)
+	if _, ok := ecs.Get[systems.ThrowIntent](model.world, model.player); ok {
+		t.Fatal("a throw should wait for its direction")
+	}
+	press(InputMoveRight)
+	if in, _ := ecs.Get[systems.ThrowIntent](model.world, model.player); in != (systems.ThrowIntent{Item: "knife", DX: 1}) {
+		t.Fatalf("the throw should go the way pressed, got %+v", in)
+	}
+	press(InputMenuSelect)
+	if in, _ := ecs.Get[systems.UseIntent](model.world, model.player); in.Item != "knife" {
+		t.Fatalf("enter should use the selected item, got %+v", in)
+	}
+	press(InputMenuBack)
+	if p.inventory != nil {
+		t.Fatal("esc should close the pack")
+	}
+}
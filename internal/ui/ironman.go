@@ -0,0 +1,221 @@
+package ui
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+
+	"harvester/pkg/ecs"
+)
+
+const (
+	ironmanName   = "ironman"
+	ironmanLedger = "ironman.json"
+
+	metaRunID      = "run_id"
+	metaRunMode    = "run_mode"
+	metaRunCounter = "run_counter"
+	runModeIronman = "ironman"
+)
+
+var (
+	// ErrNoIronmanRun is returned when an ironman operation needs an active run.
+	ErrNoIronmanRun = errors.New("no ironman run in progress")
+	// ErrIronmanRunOver is returned when loading a save whose run has ended.
+	ErrIronmanRunOver = errors.New("ironman run is over")
+	// ErrIronmanTampered is returned when an ironman save is not the latest one
+	// written for its run, e.g. an older copy restored by hand.
+	ErrIronmanTampered = errors.New("ironman save does not match the run ledger")
+)
+
+// ironmanRecord is the ledger's view of one run. The ledger lives beside the
+// saves and is the authority: a save is only loadable if its counter matches.
+type ironmanRecord struct {
+	Counter uint64 `json:"counter"`
+	Over    bool   `json:"over,omitempty"`
+	Reason  string `json:"reason,omitempty"`
+}
+
+type ironmanLedgerFile struct {
+	Runs map[string]ironmanRecord `json:"runs"`
+}
+
+// IronmanRun identifies the ironman run bound to the current game.
+type IronmanRun struct {
+	ID      string
+	Counter uint64
+}
+
+// HasIronman reports whether a live ironman save exists
+func (sgm *SaveGameManager) HasIronman() bool {
+	_, err := os.Stat(sgm.slotPath(ironmanName))
+	return err == nil
+}
+
+// IronmanRun returns the ironman run bound to this manager, if any
+func (sgm *SaveGameManager) IronmanRun() (IronmanRun, bool) {
+	if sgm.run == nil {
+		return IronmanRun{}, false
+	}
+	return *sgm.run, true
+}
+
+// StartIronman binds a fresh run to the world and writes its first save. Any
+// previous ironman run is forfeited: there is only ever one ironman save.
+func (sgm *SaveGameManager) StartIronman(world *ecs.World) error {
+	id, err := newRunID()
+	if err != nil {
+		return fmt.Errorf("failed to create run id: %w", err)
+	}
+	ledger, err := sgm.readLedger()
+	if err != nil {
+		return err
+	}
+	for runID, rec := range ledger.Runs {
+		if !rec.Over {
+			rec.Over, rec.Reason = true, "abandoned"
+			ledger.Runs[runID] = rec
+		}
+	}
+	sgm.run = &IronmanRun{ID: id}
+	return sgm.saveIronman(world, ledger)
+}
+
+// SaveIronman writes the run's save and advances its counter in both the save
+// and the ledger, so any earlier copy of the save stops being loadable.
+func (sgm *SaveGameManager) SaveIronman(world *ecs.World) error {
+	if sgm.run == nil {
+		return ErrNoIronmanRun
+	}
+	ledger, err := sgm.readLedger()
+	if err != nil {
+		return err
+	}
+	if rec, ok := ledger.Runs[sgm.run.ID]; ok && rec.Over {
+		return fmt.Errorf("%w: %s", ErrIronmanRunOver, rec.Reason)
+	}
+	return sgm.saveIronman(world, ledger)
+}
+
+// LoadIronman restores the ironman save and binds its run to this manager.
+// Saves from ended runs or with a stale counter are refused.
+func (sgm *SaveGameManager) LoadIronman(world *ecs.World) (*ecs.LoadReport, error) {
+	path := sgm.slotPath(ironmanName)
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read save file %s: %w", path, err)
+	}
+	snapshot, err := ecs.DecodeSnapshot(b, ecs.SaveOptions{Compress: true})
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode save file %s: %w", path, err)
+	}
+	id := snapshot.Meta[metaRunID]
+	counter, _ := strconv.ParseUint(snapshot.Meta[metaRunCounter], 10, 64)
+	if snapshot.Meta[metaRunMode] != runModeIronman || id == "" {
+		return nil, fmt.Errorf("%w: save has no run metadata", ErrIronmanTampered)
+	}
+	ledger, err := sgm.readLedger()
+	if err != nil {
+		return nil, err
+	}
+	rec, ok := ledger.Runs[id]
+	switch {
+	case !ok:
+		return nil, fmt.Errorf("%w: unknown run %s", ErrIronmanTampered, id)
+	case rec.Over:
+		return nil, fmt.Errorf("%w: %s", ErrIronmanRunOver, rec.Reason)
+	case rec.Counter != counter:
+		return nil, fmt.Errorf("%w: save %d, ledger %d", ErrIronmanTampered, counter, rec.Counter)
+	}
+	report, err := ecs.LoadWithOptions(world, snapshot, ecs.LoadOptions{Strict: sgm.strict})
+	if err != nil {
+		return report, fmt.Errorf("failed to load save file %s into world: %w", path, err)
+	}
+	sgm.run = &IronmanRun{ID: id, Counter: counter}
+	return report, nil
+}
+
+// EndIronman records the run as over and deletes its save. It is a no-op
+// when no ironman run is bound.
+func (sgm *SaveGameManager) EndIronman(reason string) error {
+	if sgm.run == nil {
+		return nil
+	}
+	ledger, err := sgm.readLedger()
+	if err != nil {
+		return err
+	}
+	rec := ledger.Runs[sgm.run.ID]
+	rec.Over, rec.Reason = true, reason
+	ledger.Runs[sgm.run.ID] = rec
+	if err := sgm.writeLedger(ledger); err != nil {
+		return err
+	}
+	sgm.run = nil
+	if err := os.Remove(sgm.slotPath(ironmanName)); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("failed to delete ironman save: %w", err)
+	}
+	return nil
+}
+
+func (sgm *SaveGameManager) saveIronman(world *ecs.World, ledger *ironmanLedgerFile) error {
+	next := ledger.Runs[sgm.run.ID].Counter + 1
+	meta := map[string]string{
+		metaRunID:      sgm.run.ID,
+		metaRunMode:    runModeIronman,
+		metaRunCounter: strconv.FormatUint(next, 10),
+	}
+	if err := sgm.saveToFile(sgm.slotPath(ironmanName), world, meta); err != nil {
+		return err
+	}
+	// The save is written before the ledger: a crash in between leaves the
+	// ledger one behind, which LoadIronman reports rather than silently accepting.
+	ledger.Runs[sgm.run.ID] = ironmanRecord{Counter: next}
+	if err := sgm.writeLedger(ledger); err != nil {
+		return err
+	}
+	sgm.run.Counter = next
+	return nil
+}
+
+func (sgm *SaveGameManager) readLedger() (*ironmanLedgerFile, error) {
+	ledger := &ironmanLedgerFile{Runs: make(map[string]ironmanRecord)}
+	b, err := os.ReadFile(filepath.Join(sgm.saveDir, ironmanLedger))
+	if errors.Is(err, os.ErrNotExist) {
+		return ledger, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read ironman ledger: %w", err)
+	}
+	if err := json.Unmarshal(b, ledger); err != nil {
+		return nil, fmt.Errorf("failed to parse ironman ledger: %w", err)
+	}
+	if ledger.Runs == nil {
+		ledger.Runs = make(map[string]ironmanRecord)
+	}
+	return ledger, nil
+}
+
+func (sgm *SaveGameManager) writeLedger(ledger *ironmanLedgerFile) error {
+	b, err := json.MarshalIndent(ledger, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode ironman ledger: %w", err)
+	}
+	if err := os.MkdirAll(sgm.saveDir, 0o755); err != nil {
+		return fmt.Errorf("failed to create save directory: %w", err)
+	}
+	return writeFileAtomic(filepath.Join(sgm.saveDir, ironmanLedger), b)
+}
+
+func newRunID() (string, error) {
+	var b [8]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b[:]), nil
+}
@@ -0,0 +1,113 @@
+package ui
+
+import (
+	"errors"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"harvester/pkg/components"
+	"harvester/pkg/ecs"
+)
+
+func TestIronmanRejectsCopiedBackSave(t *testing.T) {
+	sgm := NewSaveGameManagerAt(t.TempDir())
+	if err := sgm.StartIronman(newSavedWorld(1)); err != nil {
+		t.Fatal(err)
+	}
+	stale, err := os.ReadFile(sgm.slotPath(ironmanName))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := sgm.SaveIronman(newSavedWorld(2)); err != nil {
+		t.Fatal(err)
+	}
+
+	// The latest save loads and rebinds the run.
+	fresh := NewSaveGameManagerAt(sgm.SaveDir())
+	w := ecs.NewWorld(nil)
+	if _, err := fresh.LoadIronman(w); err != nil {
+		t.Fatalf("latest ironman save should load: %v", err)
+	}
+	if p, _ := ecs.Get[components.Position](w, 1); p.X != 2 {
+		t.Errorf("expected latest save (X=2), got X=%v", p.X)
+	}
+	run, ok := fresh.IronmanRun()
+	if !ok || run.Counter != 2 {
+		t.Errorf("expected run bound at counter 2, got %+v %v", run, ok)
+	}
+
+	// Restoring the earlier file is detected by its counter.
+	if err := writeFileAtomic(sgm.slotPath(ironmanName), stale); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := NewSaveGameManagerAt(sgm.SaveDir()).LoadIronman(ecs.NewWorld(nil)); !errors.Is(err, ErrIronmanTampered) {
+		t.Errorf("expected ErrIronmanTampered for copied-back save, got %v", err)
+	}
+}
+
+func TestIronmanDeathDeletesSave(t *testing.T) {
+	sgm := NewSaveGameManagerAt(t.TempDir())
+	if err := sgm.StartIronman(newSavedWorld(1)); err != nil {
+		t.Fatal(err)
+	}
+	kept, err := os.ReadFile(sgm.slotPath(ironmanName))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := sgm.EndIronman("hull destroyed"); err != nil {
+		t.Fatal(err)
+	}
+	if sgm.HasIronman() {
+		t.Error("ironman save should be deleted on death")
+	}
+	if err := sgm.SaveIronman(newSavedWorld(1)); !errors.Is(err, ErrNoIronmanRun) {
+		t.Errorf("saving after death should fail, got %v", err)
+	}
+
+	// A backup of the dead run's save is refused.
+	if err := writeFileAtomic(sgm.slotPath(ironmanName), kept); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := sgm.LoadIronman(ecs.NewWorld(nil)); !errors.Is(err, ErrIronmanRunOver) {
+		t.Errorf("expected ErrIronmanRunOver, got %v", err)
+	}
+}
+
+func TestGameOverReportsFailedIronmanDelete(t *testing.T) {
+	sgm := NewSaveGameManagerAt(t.TempDir())
+	if err := sgm.StartIronman(newSavedWorld(1)); err != nil {
+		t.Fatal(err)
+	}
+	// a directory where the ledger should be makes it unreadable
+	ledger := filepath.Join(sgm.SaveDir(), ironmanLedger)
+	if err := os.Remove(ledger); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Mkdir(ledger, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	model := NewModelWithRNG(rand.New(rand.NewSource(1)))
+	ctx := ecs.GetWorldContext(model.World())
+	ctx.GameOver, ctx.GameOverReason = true, "hull destroyed"
+	ecs.SetWorldContext(model.World(), ctx)
+	g := &GlobalScreen{subScreen: NewSpaceScreen(&model), saveManager: sgm}
+	g.checkGameOver()
+
+	last := model.log[len(model.log)-1].Text
+	if !strings.HasPrefix(last, "Ironman save could not be deleted") {
+		t.Fatalf("a failed delete should be reported, got %q", last)
+	}
+	if !sgm.HasIronman() {
+		t.Fatal("the save should still be there")
+	}
+}
+
+func TestIronmanSlotNameReserved(t *testing.T) {
+	sgm := NewSaveGameManagerAt(t.TempDir())
+	if err := sgm.SaveSlot(ironmanName, newSavedWorld(0)); !errors.Is(err, ErrInvalidSlotName) {
+		t.Errorf("expected ironman slot name to be reserved, got %v", err)
+	}
+}
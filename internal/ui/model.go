@@ -20,7 +20,7 @@ import (
 
 type Model struct {
 	Width, Height int
-	log           []string
+	log           []LogMessage
 	rng           *rand.Rand
 
 	world     *ecs.World
@@ -32,6 +32,7 @@ type Model struct {
 	layoutManager *LayoutManager
 	frame         int
 	prevStats     PlayerStatsData
+	eventSeq      uint64 // last world event shown in the message log
 }
 
 func (m *Model) World() *ecs.World { return m.world }
@@ -51,40 +52,44 @@ func NewModelWithRNG(r *rand.Rand) Model {
 	}
 	m.player = bs.Player
 	debug.Infof("model", "Model initialized with player entity %d", m.player)
-	for y := 0; y < 80; y++ {
-		for x := 0; x < 200; x++ {
-			n := (x*73856093 ^ y*19349663) % 100
-			if n < 6 {
-				e := w.Create()
-				ecs.Add(w, e, components.Position{X: float64(x), Y: float64(y)})
-				tt := components.TileStar
-				glyph := '*'
-				switch {
-				case n < 1:
-					tt, glyph = components.TileGalaxyCore, '¤'
-				case n < 2:
-					tt, glyph = components.TileNebula, '≈'
-				case n < 4:
-					tt, glyph = components.TileAsteroid, '·'
-				default:
-					tt, glyph = components.TileStar, '*'
-				}
-				ecs.Add(w, e, components.Tile{Glyph: glyph, Type: tt})
-				ecs.Add(w, e, components.Renderable{Glyph: glyph, TileType: tt, StyleMod: &components.ColorModifier{Special: components.EffectTwinkling}})
-			}
-			// rare comets
-			if n == 42 {
-				e := w.Create()
-				ecs.Add(w, e, components.Position{X: float64(x), Y: float64(y)})
-				ecs.Add(w, e, components.Tile{Glyph: '⤳', Type: components.TileComet})
-				ecs.Add(w, e, components.Renderable{Glyph: '⤳', TileType: components.TileComet})
-			}
-		}
-	}
 	ecs.Add(w, m.player, components.PlayerStats{Fuel: 100, Hull: 100, Drive: 1})
+	systems.StreamSectors(w)
 	return m
 }
 
+// maxLogMessages bounds the player-facing message log.
+const maxLogMessages = 8
+
+// Notify appends a line to the player-facing message log.
+func (m *Model) Notify(kind LogMessageType, text string) {
+	m.log = append(m.log, LogMessage{Text: text, Type: kind})
+	if len(m.log) > maxLogMessages {
+		m.log = m.log[len(m.log)-maxLogMessages:]
+	}
+}
+
+// drainEvents copies new world events into the message log.
+func (m *Model) drainEvents() {
+	var events []ecs.Event
+	events, m.eventSeq = m.world.EventsSince(m.eventSeq)
+	for _, ev := range events {
+		if ev.Text == "" {
+			continue
+		}
+		kind := LogInfo
+		switch {
+		case ev.Kind == systems.EventKill, ev.Kind == systems.EventQuest, ev.Kind == systems.EventLaunch, ev.Kind == systems.EventOrbit, ev.Kind == systems.EventDock:
+			kind = LogSuccess
+		case ev.Kind == systems.EventHit && ev.Target == m.player, ev.Kind == systems.EventHazard, ev.Kind == systems.EventApproach, ev.Kind == systems.EventCollision, ev.Kind == systems.EventSurfaceHazard:
+			kind = LogWarning
+		}
+		m.Notify(kind, ev.Text)
+	}
+}
+
+// Messages returns the current message log, oldest first.
+func (m *Model) Messages() []LogMessage { return m.log }
+
 func (m *Model) Init() tea.Cmd {
 	return tea.Tick(time.Second/60, func(t time.Time) tea.Msg { return t })
 }
@@ -119,6 +124,7 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		updateTimer := debug.StartSystemTimer("scheduler")
 		m.scheduler.Update(1.0/20.0, m.world)
 		updateTimer.Stop()
+		m.drainEvents()
 
 		m.frame++ // Increment frame counter for animations
 
@@ -128,16 +134,13 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 		if os.Getenv("DEBUG_TICK") == "1" {
 			debug.Debugf("performance", "Engine tick took %v", dur)
-			m.log = append(m.log, "engine dt:0.05 ui dt:0.0167 tick:"+dur.String())
-			if len(m.log) > 5 {
-				m.log = m.log[len(m.log)-5:]
-			}
+			m.Notify(LogInfo, "engine dt:0.05 ui dt:0.0167 tick:"+dur.String())
 		}
 
 		next := ecs.GetWorldContext(m.world)
 		if prev.CurrentLayer != next.CurrentLayer {
 			debug.Infof("game", "Layer changed from %s to %s", layerName(prev.CurrentLayer), layerName(next.CurrentLayer))
-			m.log = append(m.log, "Layer: "+layerName(next.CurrentLayer))
+			m.Notify(LogInfo, "Layer: "+layerName(next.CurrentLayer))
 		}
 
 		frameTimer.Stop()
@@ -312,15 +315,6 @@ func (m Model) View() string {
 	rightStr := ""
 	status := ""
 
-	// Convert log to LogMessage format for enhanced styling
-	var logMessages []LogMessage
-	for _, logLine := range m.log {
-		logMessages = append(logMessages, LogMessage{
-			Text: logLine,
-			Type: LogInfo,
-		})
-	}
-	_ = logMessages
 	logStr := ""
 	return m.renderUI(mapStr, rightStr, status, logStr)
 }
@@ -1,8 +1,15 @@
 package ui
 
-import "harvester/pkg/systems"
+import (
+	"harvester/pkg/data"
+	"harvester/pkg/ecs"
+	"harvester/pkg/systems"
+)
 
 func (m *Model) ApplyAction(a InputAction) {
+	if ecs.GetWorldContext(m.world).GameOver {
+		return
+	}
 	switch a.Kind {
 	case InputMoveLeft:
 		systems.SetPlayerInput(m.world, m.player, "left")
@@ -14,5 +21,73 @@ func (m *Model) ApplyAction(a InputAction) {
 		systems.SetPlayerInput(m.world, m.player, "down")
 	case InputEnter:
 		systems.SetPlayerInput(m.world, m.player, "enter")
+	case InputHarvest:
+		systems.SetPlayerInput(m.world, m.player, "harvest")
+	case InputWait:
+		systems.SetPlayerInput(m.world, m.player, "wait")
+	case InputWarp:
+		if ecs.GetWorldContext(m.world).CurrentLayer == ecs.LayerSpace {
+			systems.SetPlayerInput(m.world, m.player, "warp")
+		}
+	case InputTravel:
+		if ecs.GetWorldContext(m.world).CurrentLayer != ecs.LayerSpace && !systems.TravelToResource(m.world, m.player) {
+			m.Notify(LogInfo, "There is nothing in reach to harvest.")
+		}
+	case InputScan:
+		// 's' scans on planets; in space it keeps its old job as the brake
+		if ecs.GetWorldContext(m.world).CurrentLayer == ecs.LayerSpace {
+			systems.SetPlayerInput(m.world, m.player, "down")
+		} else {
+			systems.SetPlayerInput(m.world, m.player, "scan")
+		}
+	}
+}
+
+// Craft queues recipe for the player; it resolves on the next turn.
+func (m *Model) Craft(recipe string) {
+	if ecs.GetWorldContext(m.world).GameOver {
+		return
+	}
+	ecs.Add(m.world, m.player, systems.CraftIntent{Recipe: recipe})
+}
+
+// UseItem queues using one item for the player; like every item action it
+// takes the player's turn.
+func (m *Model) UseItem(item string) {
+	if ecs.GetWorldContext(m.world).GameOver {
+		return
+	}
+	ecs.Add(m.world, m.player, systems.UseIntent{Item: item})
+}
+
+// EquipItem queues putting item on, or taking it off if it is worn.
+func (m *Model) EquipItem(item string) {
+	if ecs.GetWorldContext(m.world).GameOver {
+		return
+	}
+	ecs.Add(m.world, m.player, systems.EquipIntent{Item: item})
+}
+
+// DropItem queues dropping n of item underfoot.
+func (m *Model) DropItem(item string, n int) {
+	if ecs.GetWorldContext(m.world).GameOver {
+		return
+	}
+	ecs.Add(m.world, m.player, systems.DropIntent{Item: item, Count: n})
+}
+
+// ThrowItem queues throwing one item in direction (dx, dy).
+func (m *Model) ThrowItem(item string, dx, dy int) {
+	if ecs.GetWorldContext(m.world).GameOver {
+		return
+	}
+	ecs.Add(m.world, m.player, systems.ThrowIntent{Item: item, DX: dx, DY: dy})
+}
+
+// Upgrade queues the next tier of kind for the player's ship.
+func (m *Model) Upgrade(kind data.UpgradeKind) {
+	if ecs.GetWorldContext(m.world).GameOver {
+		return
 	}
+	ecs.Add(m.world, m.player, systems.UpgradeIntent{Kind: kind})
 }
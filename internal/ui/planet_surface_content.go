@@ -42,6 +42,7 @@ func (t *planetSurfaceContent) ToLipglossLayer() *lipgloss.Layer {
 			styledChar := lipgloss.NewStyle().
 				Foreground(lipgloss.Color(fgHex)).
 				Background(lipgloss.Color(bgHex)).
+				Faint(glyph.Style&rendering.StyleDim != 0).
 				Render(string(glyph.Char))
 			
 			content.WriteString(styledChar)
@@ -5,6 +5,7 @@ import (
 
 	tea "github.com/charmbracelet/bubbletea"
 	"harvester/pkg/ecs"
+	"harvester/pkg/systems"
 )
 
 func TestGlobal_Transitions_To_Planet_WhenOnSurface(t *testing.T) {
@@ -35,3 +36,31 @@ func TestGlobal_Transitions_To_Planet_WhenOnSurface(t *testing.T) {
 		t.Fatalf("expected PlanetScreen after transition, got %T", g.subScreen)
 	}
 }
+
+func TestGlobal_Returns_To_Space_AfterLaunch(t *testing.T) {
+	gs := NewGlobalScreen()
+	s := NewStartScreen()
+	s.result = &StartResult{Action: ActionNewGame}
+	gs.subScreen = s
+	m, _ := gs.handleStartScreenResult(s.result)
+	g := m.(*GlobalScreen)
+	space := g.subScreen.(*SpaceScreen)
+	w := space.model.World()
+	ctx := ecs.GetWorldContext(w)
+	ctx.CurrentLayer = ecs.LayerPlanetSurface
+	ecs.SetWorldContext(w, ctx)
+	_, _ = g.Update(tea.WindowSizeMsg{Width: 80, Height: 24})
+	planet, ok := g.subScreen.(*PlanetScreen)
+	if !ok {
+		t.Fatalf("expected PlanetScreen, got %T", g.subScreen)
+	}
+	systems.LiftOff(w, planet.model.player)
+	_, _ = g.Update(tea.WindowSizeMsg{Width: 80, Height: 24})
+	back, ok := g.subScreen.(*SpaceScreen)
+	if !ok {
+		t.Fatalf("expected SpaceScreen after launch, got %T", g.subScreen)
+	}
+	if back.model != space.model {
+		t.Fatal("the run should continue on the same model")
+	}
+}
@@ -107,13 +107,35 @@ func mapKeyToAction(k tea.KeyMsg) InputAction {
 		return InputAction{Kind: InputMenuSelect}
 	case "esc":
 		return InputAction{Kind: InputMenuBack}
-	case "w":
+	case "w", "up":
 		return InputAction{Kind: InputMoveUp}
-	case "s":
+	case "down":
 		return InputAction{Kind: InputMoveDown}
-	case "a":
+	case "s":
+		return InputAction{Kind: InputScan}
+	case ".":
+		return InputAction{Kind: InputWait}
+	case "g":
+		return InputAction{Kind: InputHarvest}
+	case "c":
+		return InputAction{Kind: InputCraft}
+	case "u":
+		return InputAction{Kind: InputUpgrade}
+	case "b":
+		return InputAction{Kind: InputWarp}
+	case "t":
+		return InputAction{Kind: InputTravel}
+	case "i":
+		return InputAction{Kind: InputInventory}
+	case "e":
+		return InputAction{Kind: InputEquip}
+	case "x":
+		return InputAction{Kind: InputDrop}
+	case "f":
+		return InputAction{Kind: InputThrow}
+	case "a", "left":
 		return InputAction{Kind: InputMoveLeft}
-	case "d":
+	case "d", "right":
 		return InputAction{Kind: InputMoveRight}
 	case "h":
 		return InputAction{Kind: InputMenuLeft}
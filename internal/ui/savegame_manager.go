@@ -1,111 +1,306 @@
 package ui
 
 import (
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
+	"sort"
 	"strings"
 	"time"
 
 	"harvester/pkg/ecs"
 )
 
+const (
+	// SaveDirEnv overrides the directory used for save files.
+	SaveDirEnv = "HARVESTER_SAVE_DIR"
+
+	autosaveName = "autosave"
+	saveExt      = ".gz"
+	maxSlotName  = 64
+)
+
+var (
+	// ErrSlotExists is returned when a rename, copy or import would overwrite a save.
+	ErrSlotExists = errors.New("save slot already exists")
+	// ErrSlotNotFound is returned when a named save does not exist.
+	ErrSlotNotFound = errors.New("save slot not found")
+	// ErrInvalidSlotName is returned for names that cannot be used as a save file.
+	ErrInvalidSlotName = errors.New("invalid save slot name")
+)
+
 // SaveGameManager handles all save/load operations
 type SaveGameManager struct {
 	saveDir string
+	strict  bool
+	run     *IronmanRun // bound ironman run, nil for standard games
 }
 
 // SaveSlotInfo contains information about a save slot
 type SaveSlotInfo struct {
-	SlotNum  int
-	Exists   bool
+	Name     string
 	ModTime  time.Time
 	Size     int64
 	GameInfo string // extracted from save data if possible
 }
 
-// NewSaveGameManager creates a new save game manager
+// DefaultSaveDir resolves the save directory: $HARVESTER_SAVE_DIR, then
+// $XDG_DATA_HOME/harvester/saves, then ~/.local/share/harvester/saves.
+func DefaultSaveDir() string {
+	if dir := os.Getenv(SaveDirEnv); dir != "" {
+		return dir
+	}
+	if dir := os.Getenv("XDG_DATA_HOME"); dir != "" {
+		return filepath.Join(dir, "harvester", "saves")
+	}
+	if home, err := os.UserHomeDir(); err == nil {
+		return filepath.Join(home, ".local", "share", "harvester", "saves")
+	}
+	return ".saves"
+}
+
+// NewSaveGameManager creates a new save game manager rooted at DefaultSaveDir
 func NewSaveGameManager() *SaveGameManager {
+	return NewSaveGameManagerAt(DefaultSaveDir())
+}
+
+// NewSaveGameManagerAt creates a save game manager rooted at dir
+func NewSaveGameManagerAt(dir string) *SaveGameManager {
 	return &SaveGameManager{
-		saveDir: ".saves",
+		saveDir: dir,
 	}
 }
 
+// SaveDir returns the directory holding save files
+func (sgm *SaveGameManager) SaveDir() string {
+	return sgm.saveDir
+}
+
+// SetStrictLoad makes loads fail, leaving the world untouched, when any
+// component in the save cannot be restored
+func (sgm *SaveGameManager) SetStrictLoad(strict bool) {
+	sgm.strict = strict
+}
+
 // HasAutosave checks if an autosave file exists
 func (sgm *SaveGameManager) HasAutosave() bool {
-	autosavePath := filepath.Join(sgm.saveDir, "autosave.gz")
-	_, err := os.Stat(autosavePath)
+	_, err := os.Stat(sgm.slotPath(autosaveName))
 	return err == nil
 }
 
-// GetSaveSlots returns information about all save slots
-func (sgm *SaveGameManager) GetSaveSlots() []SaveSlotInfo {
-	slots := make([]SaveSlotInfo, 3)
-	for i := 1; i <= 3; i++ {
-		slotPath := filepath.Join(sgm.saveDir, fmt.Sprintf("slot%d.gz", i))
-		info := SaveSlotInfo{
-			SlotNum: i,
-			Exists:  false,
-		}
+// SlotExists reports whether a named save exists
+func (sgm *SaveGameManager) SlotExists(name string) bool {
+	if validateSlotName(name) != nil {
+		return false
+	}
+	_, err := os.Stat(sgm.slotPath(name))
+	return err == nil
+}
 
-		if stat, err := os.Stat(slotPath); err == nil {
-			info.Exists = true
-			info.ModTime = stat.ModTime()
-			info.Size = stat.Size()
-			info.GameInfo = sgm.extractGameInfo(slotPath)
-		}
+// ListSlots returns all named saves, most recently modified first.
+// The autosave is not included; use HasAutosave for it.
+func (sgm *SaveGameManager) ListSlots() ([]SaveSlotInfo, error) {
+	entries, err := os.ReadDir(sgm.saveDir)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read save directory %s: %w", sgm.saveDir, err)
+	}
 
-		slots[i-1] = info
+	var slots []SaveSlotInfo
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), saveExt) {
+			continue
+		}
+		name := strings.TrimSuffix(entry.Name(), saveExt)
+		if validateSlotName(name) != nil {
+			continue
+		}
+		stat, err := entry.Info()
+		if err != nil {
+			continue
+		}
+		slots = append(slots, SaveSlotInfo{
+			Name:     name,
+			ModTime:  stat.ModTime(),
+			Size:     stat.Size(),
+			GameInfo: sgm.extractGameInfo(sgm.slotPath(name)),
+		})
 	}
-	return slots
+
+	sort.Slice(slots, func(i, j int) bool {
+		if !slots[i].ModTime.Equal(slots[j].ModTime) {
+			return slots[i].ModTime.After(slots[j].ModTime)
+		}
+		return slots[i].Name < slots[j].Name
+	})
+	return slots, nil
 }
 
-// LoadAutosave loads the autosave file into the given world
-func (sgm *SaveGameManager) LoadAutosave(world *ecs.World) error {
-	autosavePath := filepath.Join(sgm.saveDir, "autosave.gz")
-	return sgm.loadFromFile(autosavePath, world)
+// LoadAutosave loads the autosave file into the given world. The report lists
+// any components that could not be restored.
+func (sgm *SaveGameManager) LoadAutosave(world *ecs.World) (*ecs.LoadReport, error) {
+	return sgm.loadFromFile(sgm.slotPath(autosaveName), world)
 }
 
-// LoadSlot loads a specific save slot into the given world
-func (sgm *SaveGameManager) LoadSlot(slotNum int, world *ecs.World) error {
-	slotPath := filepath.Join(sgm.saveDir, fmt.Sprintf("slot%d.gz", slotNum))
-	return sgm.loadFromFile(slotPath, world)
+// LoadSlot loads a named save slot into the given world
+func (sgm *SaveGameManager) LoadSlot(name string, world *ecs.World) (*ecs.LoadReport, error) {
+	if err := validateSlotName(name); err != nil {
+		return nil, err
+	}
+	return sgm.loadFromFile(sgm.slotPath(name), world)
 }
 
 // SaveAutosave saves the world to the autosave file
 func (sgm *SaveGameManager) SaveAutosave(world *ecs.World) error {
-	autosavePath := filepath.Join(sgm.saveDir, "autosave.gz")
-	return sgm.saveToFile(autosavePath, world)
+	return sgm.saveToFile(sgm.slotPath(autosaveName), world, nil)
+}
+
+// SaveSlot saves the world to a named save slot, replacing any previous save
+func (sgm *SaveGameManager) SaveSlot(name string, world *ecs.World) error {
+	if err := validateSlotName(name); err != nil {
+		return err
+	}
+	return sgm.saveToFile(sgm.slotPath(name), world, nil)
+}
+
+// RenameSlot renames a save slot; the target name must be unused
+func (sgm *SaveGameManager) RenameSlot(from, to string) error {
+	if err := sgm.checkTransfer(from, to); err != nil {
+		return err
+	}
+	if err := os.Rename(sgm.slotPath(from), sgm.slotPath(to)); err != nil {
+		return fmt.Errorf("failed to rename save %q to %q: %w", from, to, err)
+	}
+	return nil
+}
+
+// CopySlot duplicates a save slot under a new, unused name
+func (sgm *SaveGameManager) CopySlot(from, to string) error {
+	if err := sgm.checkTransfer(from, to); err != nil {
+		return err
+	}
+	if err := copyFile(sgm.slotPath(from), sgm.slotPath(to)); err != nil {
+		return fmt.Errorf("failed to copy save %q to %q: %w", from, to, err)
+	}
+	return nil
+}
+
+// DeleteSlot removes a save slot
+func (sgm *SaveGameManager) DeleteSlot(name string) error {
+	if err := validateSlotName(name); err != nil {
+		return err
+	}
+	if err := os.Remove(sgm.slotPath(name)); err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return fmt.Errorf("%w: %s", ErrSlotNotFound, name)
+		}
+		return fmt.Errorf("failed to delete save %q: %w", name, err)
+	}
+	return nil
+}
+
+// ExportSlot copies a save slot to an arbitrary path outside the save directory
+func (sgm *SaveGameManager) ExportSlot(name, dest string) error {
+	if err := validateSlotName(name); err != nil {
+		return err
+	}
+	if !sgm.SlotExists(name) {
+		return fmt.Errorf("%w: %s", ErrSlotNotFound, name)
+	}
+	if err := copyFile(sgm.slotPath(name), dest); err != nil {
+		return fmt.Errorf("failed to export save %q: %w", name, err)
+	}
+	return nil
+}
+
+// ImportSlot copies an external save file into the save directory under name.
+// The file must decode as a snapshot so broken files never show up in listings.
+func (sgm *SaveGameManager) ImportSlot(src, name string) error {
+	if err := validateSlotName(name); err != nil {
+		return err
+	}
+	if sgm.SlotExists(name) {
+		return fmt.Errorf("%w: %s", ErrSlotExists, name)
+	}
+	b, err := os.ReadFile(src)
+	if err != nil {
+		return fmt.Errorf("failed to read import file %s: %w", src, err)
+	}
+	if _, err := ecs.DecodeSnapshot(b, ecs.SaveOptions{Compress: true}); err != nil {
+		return fmt.Errorf("failed to decode import file %s: %w", src, err)
+	}
+	if err := os.MkdirAll(sgm.saveDir, 0o755); err != nil {
+		return fmt.Errorf("failed to create save directory: %w", err)
+	}
+	return writeFileAtomic(sgm.slotPath(name), b)
 }
 
-// SaveSlot saves the world to a specific save slot
-func (sgm *SaveGameManager) SaveSlot(slotNum int, world *ecs.World) error {
-	slotPath := filepath.Join(sgm.saveDir, fmt.Sprintf("slot%d.gz", slotNum))
-	return sgm.saveToFile(slotPath, world)
+func (sgm *SaveGameManager) slotPath(name string) string {
+	return filepath.Join(sgm.saveDir, name+saveExt)
+}
+
+func (sgm *SaveGameManager) checkTransfer(from, to string) error {
+	if err := validateSlotName(from); err != nil {
+		return err
+	}
+	if err := validateSlotName(to); err != nil {
+		return err
+	}
+	if !sgm.SlotExists(from) {
+		return fmt.Errorf("%w: %s", ErrSlotNotFound, from)
+	}
+	if sgm.SlotExists(to) {
+		return fmt.Errorf("%w: %s", ErrSlotExists, to)
+	}
+	return nil
+}
+
+// validateSlotName accepts letters, digits, spaces, '-' and '_' so names map
+// directly onto file names on every platform. The autosave and ironman names
+// are reserved.
+func validateSlotName(name string) error {
+	if name == autosaveName || name == ironmanName {
+		return fmt.Errorf("%w: %q is reserved", ErrInvalidSlotName, name)
+	}
+	if name == "" || len(name) > maxSlotName || strings.TrimSpace(name) != name {
+		return fmt.Errorf("%w: %q", ErrInvalidSlotName, name)
+	}
+	for _, r := range name {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9':
+		case r == ' ', r == '-', r == '_':
+		default:
+			return fmt.Errorf("%w: %q", ErrInvalidSlotName, name)
+		}
+	}
+	return nil
 }
 
 // loadFromFile loads a save file into the given world
-func (sgm *SaveGameManager) loadFromFile(path string, world *ecs.World) error {
+func (sgm *SaveGameManager) loadFromFile(path string, world *ecs.World) (*ecs.LoadReport, error) {
 	b, err := os.ReadFile(path)
 	if err != nil {
-		return fmt.Errorf("failed to read save file %s: %w", path, err)
+		return nil, fmt.Errorf("failed to read save file %s: %w", path, err)
 	}
 
 	snapshot, err := ecs.DecodeSnapshot(b, ecs.SaveOptions{Compress: true})
 	if err != nil {
-		return fmt.Errorf("failed to decode save file %s: %w", path, err)
+		return nil, fmt.Errorf("failed to decode save file %s: %w", path, err)
 	}
 
-	err = ecs.Load(world, snapshot, nil)
+	report, err := ecs.LoadWithOptions(world, snapshot, ecs.LoadOptions{Strict: sgm.strict})
 	if err != nil {
-		return fmt.Errorf("failed to load save file %s into world: %w", path, err)
+		return report, fmt.Errorf("failed to load save file %s into world: %w", path, err)
 	}
 
-	return nil
+	return report, nil
 }
 
-// saveToFile saves the world to a file
-func (sgm *SaveGameManager) saveToFile(path string, world *ecs.World) error {
+// saveToFile saves the world to a file, attaching meta to the snapshot
+func (sgm *SaveGameManager) saveToFile(path string, world *ecs.World, meta map[string]string) error {
 	// Ensure save directory exists
 	if err := os.MkdirAll(sgm.saveDir, 0o755); err != nil {
 		return fmt.Errorf("failed to create save directory: %w", err)
@@ -115,20 +310,49 @@ func (sgm *SaveGameManager) saveToFile(path string, world *ecs.World) error {
 	if err != nil {
 		return fmt.Errorf("failed to create world snapshot: %w", err)
 	}
+	snapshot.Meta = meta
 
 	b, err := ecs.EncodeSnapshot(snapshot, ecs.SaveOptions{Compress: true})
 	if err != nil {
 		return fmt.Errorf("failed to encode snapshot: %w", err)
 	}
 
-	err = os.WriteFile(path, b, 0o644)
+	return writeFileAtomic(path, b)
+}
+
+// writeFileAtomic writes through a temp file so a crash never leaves a torn save
+func writeFileAtomic(path string, b []byte) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".tmp-*")
 	if err != nil {
+		return fmt.Errorf("failed to create temp file for %s: %w", path, err)
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(b); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to write save file %s: %w", path, err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to write save file %s: %w", path, err)
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
 		return fmt.Errorf("failed to write save file %s: %w", path, err)
 	}
-
 	return nil
 }
 
+func copyFile(src, dst string) error {
+	b, err := os.ReadFile(src)
+	if err != nil {
+		return err
+	}
+	if dir := filepath.Dir(dst); dir != "" {
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			return err
+		}
+	}
+	return writeFileAtomic(dst, b)
+}
+
 // extractGameInfo extracts basic information from a save file
 func (sgm *SaveGameManager) extractGameInfo(path string) string {
 	b, err := os.ReadFile(path)
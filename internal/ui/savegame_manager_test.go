@@ -0,0 +1,156 @@
+package ui
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"harvester/pkg/components"
+	"harvester/pkg/ecs"
+)
+
+func newSavedWorld(x float64) *ecs.World {
+	w := ecs.NewWorld(nil)
+	e := w.Create()
+	ecs.Add(w, e, components.Position{X: x, Y: 1})
+	return w
+}
+
+func TestDefaultSaveDirOverrides(t *testing.T) {
+	t.Setenv(SaveDirEnv, "/tmp/harvester-override")
+	if got := DefaultSaveDir(); got != "/tmp/harvester-override" {
+		t.Errorf("expected env override, got %s", got)
+	}
+	t.Setenv(SaveDirEnv, "")
+	t.Setenv("XDG_DATA_HOME", "/tmp/xdg")
+	if got := DefaultSaveDir(); got != filepath.Join("/tmp/xdg", "harvester", "saves") {
+		t.Errorf("expected XDG data dir, got %s", got)
+	}
+}
+
+func TestSaveSlotsNamedAndUnlimited(t *testing.T) {
+	sgm := NewSaveGameManagerAt(t.TempDir())
+	names := []string{"alpha", "beta run", "gamma_2", "delta-4", "epsilon"}
+	for i, name := range names {
+		if err := sgm.SaveSlot(name, newSavedWorld(float64(i))); err != nil {
+			t.Fatalf("save %q: %v", name, err)
+		}
+	}
+	if err := sgm.SaveAutosave(newSavedWorld(0)); err != nil {
+		t.Fatalf("autosave: %v", err)
+	}
+	slots, err := sgm.ListSlots()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(slots) != len(names) {
+		t.Fatalf("expected %d slots (autosave excluded), got %d", len(names), len(slots))
+	}
+
+	w := ecs.NewWorld(nil)
+	if _, err := sgm.LoadSlot("gamma_2", w); err != nil {
+		t.Fatal(err)
+	}
+	if p, ok := ecs.Get[components.Position](w, 1); !ok || p.X != 2 {
+		t.Errorf("expected gamma_2 to restore X=2, got %+v", p)
+	}
+}
+
+func TestSaveSlotRejectsBadNames(t *testing.T) {
+	sgm := NewSaveGameManagerAt(t.TempDir())
+	for _, name := range []string{"", "../escape", "a/b", "autosave", " padded"} {
+		if err := sgm.SaveSlot(name, newSavedWorld(0)); !errors.Is(err, ErrInvalidSlotName) {
+			t.Errorf("expected ErrInvalidSlotName for %q, got %v", name, err)
+		}
+	}
+}
+
+func TestSaveSlotRenameCopyDelete(t *testing.T) {
+	sgm := NewSaveGameManagerAt(t.TempDir())
+	if err := sgm.SaveSlot("one", newSavedWorld(1)); err != nil {
+		t.Fatal(err)
+	}
+	if err := sgm.SaveSlot("two", newSavedWorld(2)); err != nil {
+		t.Fatal(err)
+	}
+	if err := sgm.RenameSlot("one", "two"); !errors.Is(err, ErrSlotExists) {
+		t.Errorf("rename onto existing slot should fail, got %v", err)
+	}
+	if err := sgm.RenameSlot("one", "first"); err != nil {
+		t.Fatal(err)
+	}
+	if sgm.SlotExists("one") || !sgm.SlotExists("first") {
+		t.Error("rename should move the save")
+	}
+	if err := sgm.CopySlot("first", "backup"); err != nil {
+		t.Fatal(err)
+	}
+	if !sgm.SlotExists("first") || !sgm.SlotExists("backup") {
+		t.Error("copy should keep both saves")
+	}
+	if err := sgm.DeleteSlot("first"); err != nil {
+		t.Fatal(err)
+	}
+	if err := sgm.DeleteSlot("first"); !errors.Is(err, ErrSlotNotFound) {
+		t.Errorf("deleting a missing slot should report ErrSlotNotFound, got %v", err)
+	}
+	slots, _ := sgm.ListSlots()
+	if len(slots) != 2 {
+		t.Errorf("expected backup and two to remain, got %d slots", len(slots))
+	}
+}
+
+func TestSaveSlotExportImport(t *testing.T) {
+	sgm := NewSaveGameManagerAt(t.TempDir())
+	if err := sgm.SaveSlot("keeper", newSavedWorld(7)); err != nil {
+		t.Fatal(err)
+	}
+	out := filepath.Join(t.TempDir(), "exported.gz")
+	if err := sgm.ExportSlot("keeper", out); err != nil {
+		t.Fatal(err)
+	}
+
+	other := NewSaveGameManagerAt(t.TempDir())
+	if err := other.ImportSlot(out, "imported"); err != nil {
+		t.Fatal(err)
+	}
+	w := ecs.NewWorld(nil)
+	if _, err := other.LoadSlot("imported", w); err != nil {
+		t.Fatal(err)
+	}
+	if p, _ := ecs.Get[components.Position](w, 1); p.X != 7 {
+		t.Errorf("expected imported save to restore X=7, got %v", p.X)
+	}
+	if err := other.ImportSlot(out, "imported"); !errors.Is(err, ErrSlotExists) {
+		t.Errorf("importing over an existing slot should fail, got %v", err)
+	}
+}
+
+func TestLoadSlotReportsPartialLoad(t *testing.T) {
+	sgm := NewSaveGameManagerAt(t.TempDir())
+	s, err := ecs.Save(newSavedWorld(3), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	s.Components["components.Position"][1] = []byte(`{"X":"broken"}`)
+	b, err := ecs.EncodeSnapshot(s, ecs.SaveOptions{Compress: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := writeFileAtomic(sgm.slotPath("damaged"), b); err != nil {
+		t.Fatal(err)
+	}
+
+	report, err := sgm.LoadSlot("damaged", ecs.NewWorld(nil))
+	if err != nil {
+		t.Fatalf("lenient load should succeed, got %v", err)
+	}
+	if report.Clean() || report.Failed() != 1 {
+		t.Errorf("expected one failed row in report, got %+v", report)
+	}
+
+	sgm.SetStrictLoad(true)
+	if _, err := sgm.LoadSlot("damaged", ecs.NewWorld(nil)); !errors.Is(err, ecs.ErrPartialLoad) {
+		t.Errorf("strict load should fail with ErrPartialLoad, got %v", err)
+	}
+}
@@ -3,15 +3,15 @@ package ui
 import (
 	tea "github.com/charmbracelet/bubbletea"
 	"harvester/pkg/debug"
-	"harvester/pkg/ecs"
 	"harvester/pkg/rendering"
 )
 
 // SpaceScreen handles space navigation and planet selection
 type SpaceScreen struct {
-	model  *Model
-	width  int
-	height int
+	model    *Model
+	width    int
+	height   int
+	upgrades *upgradeMenu
 }
 
 func (s *SpaceScreen) RegisterContent(renderer *rendering.ViewRenderer) {
@@ -33,6 +33,13 @@ func (s *SpaceScreen) RegisterContent(renderer *rendering.ViewRenderer) {
 	} else {
 		debug.Warn("spacescreen", "buildGameGlyphs returned nil")
 	}
+	if len(s.model.log) > 0 {
+		renderer.RegisterContent(newMessageLogContent(s.model, w, h))
+	}
+	if s.upgrades != nil {
+		s.upgrades.w, s.upgrades.h = w, h
+		renderer.RegisterContent(s.upgrades)
+	}
 }
 
 func NewSpaceScreen(model *Model) *SpaceScreen {
@@ -46,12 +53,8 @@ func (s *SpaceScreen) Init() tea.Cmd {
 }
 
 func (s *SpaceScreen) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
-	// Always forward to model so input and ticks are processed
-	if act, ok := msg.(GlobalAction); ok && act == ActionSwitchToGame {
-		if s.shouldTransitionToPlanet() {
-			return s, tea.Quit
-		}
-	}
+	// Always forward to model so input and ticks are processed. Landing is
+	// picked up by GlobalScreen, which swaps in the planet screen.
 	_, cmd := s.model.Update(msg)
 	return s, cmd
 }
@@ -59,6 +62,17 @@ func (s *SpaceScreen) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 func (s *SpaceScreen) View() string { return s.model.View() }
 
 func (s *SpaceScreen) HandleInput(a InputAction) tea.Cmd {
+	// the upgrade overlay takes all input while it is open
+	if s.upgrades != nil {
+		if !s.upgrades.HandleInput(a) {
+			s.upgrades = nil
+		}
+		return nil
+	}
+	if a.Kind == InputUpgrade {
+		s.upgrades = newUpgradeMenu(s.model)
+		return nil
+	}
 	s.model.ApplyAction(a)
 	return nil
 }
@@ -78,9 +92,3 @@ func (s *SpaceScreen) SetDimensions(width, height int) {
 	s.width = width
 	s.height = height
 }
-
-// Check if player is over a planet and pressed enter
-func (s *SpaceScreen) shouldTransitionToPlanet() bool {
-	ctx := ecs.GetWorldContext(s.model.World())
-	return ctx.CurrentLayer == ecs.LayerPlanetSurface
-}
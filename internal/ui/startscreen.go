@@ -9,6 +9,7 @@ import (
 	"github.com/charmbracelet/lipgloss/v2"
 	screens "harvester/internal/ui/screens"
 	"harvester/pkg/components"
+	"harvester/pkg/debug"
 	"harvester/pkg/ecs"
 	"harvester/pkg/rendering"
 	"harvester/pkg/systems"
@@ -63,11 +64,19 @@ const (
 	ActionLoadSlot
 	ActionNewGame
 	ActionQuit
+	ActionContinueIronman
 )
 
 type StartResult struct {
-	Action  StartAction
-	SlotNum int // for ActionLoadSlot
+	Action   StartAction
+	SlotName string // for ActionLoadSlot
+	Ironman  bool   // for ActionNewGame: single save, deleted on death
+}
+
+// newGameModes are offered after choosing New Game, in menu order.
+var newGameModes = []struct{ Name, Description string }{
+	{"Standard", "Save and load freely"},
+	{"Ironman", "One save per run, deleted on death"},
 }
 
 type StartScreen struct {
@@ -76,6 +85,8 @@ type StartScreen struct {
 	saveSlots    []SaveSlotInfo
 	showSlots    bool
 	selectedSlot int
+	showModes    bool
+	selectedMode int
 
 	width  int
 	height int
@@ -155,7 +166,9 @@ func (s *StartScreen) generateBackgroundTerrain() {
 		s.backgroundWorld.Destroy(e)
 	}
 
-	// Use the same TerrainGen system as TOFT
+	// Use the same TerrainGen system as TOFT, forgetting the cached level
+	// since its tiles were just cleared
+	ecs.Remove[systems.TerrainLevel](s.backgroundWorld, 1)
 	terrainGen := systems.TerrainGen{}
 	terrainGen.Update(0, s.backgroundWorld)
 }
@@ -186,7 +199,9 @@ func (s *StartScreen) renderBackgroundContent() rendering.LayerContent {
 // Compositor-driven menu content
 func (s *StartScreen) renderMenuContent() rendering.LayerContent {
 	var content string
-	if s.showSlots {
+	if s.showModes {
+		content = s.renderModeSelection()
+	} else if s.showSlots {
 		content = s.renderSlotSelection()
 	} else {
 		content = s.renderMainMenu()
@@ -228,23 +243,23 @@ func (s *StartScreen) scanSaveFiles() {
 	autosaveExists := s.saveManager.HasAutosave()
 
 	// Check save slots
-	s.saveSlots = s.saveManager.GetSaveSlots()
+	slots, err := s.saveManager.ListSlots()
+	if err != nil {
+		debug.Warnf("startscreen", "Failed to list save slots: %v", err)
+	}
+	s.saveSlots = slots
 
 	// Build menu based on what saves exist
 	s.menuItems = []string{}
+	if s.saveManager.HasIronman() {
+		s.menuItems = append(s.menuItems, "Continue Ironman")
+	}
 	if autosaveExists {
 		s.menuItems = append(s.menuItems, "Continue")
 	}
 
 	// Only show "Load Game" if any slots exist
-	hasSlots := false
-	for _, slot := range s.saveSlots {
-		if slot.Exists {
-			hasSlots = true
-			break
-		}
-	}
-	if hasSlots {
+	if len(s.saveSlots) > 0 {
 		s.menuItems = append(s.menuItems, "Load Game")
 	}
 
@@ -286,6 +301,9 @@ func (s *StartScreen) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return s, nil
 		}
 
+		if s.showModes {
+			return s.updateModeSelection(msg)
+		}
 		if s.showSlots {
 			return s.updateSlotSelection(msg)
 		}
@@ -296,25 +314,38 @@ func (s *StartScreen) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 }
 
 func (s *StartScreen) HandleInput(a InputAction) tea.Cmd {
+	if s.showModes {
+		switch a.Kind {
+		case InputMenuUp:
+			if s.selectedMode > 0 {
+				s.selectedMode--
+			}
+		case InputMenuDown:
+			if s.selectedMode < len(newGameModes)-1 {
+				s.selectedMode++
+			}
+		case InputMenuSelect:
+			s.result = s.newGameResult()
+		case InputMenuBack:
+			s.showModes = false
+		case InputQuit:
+			s.result = &StartResult{Action: ActionQuit}
+		}
+		return nil
+	}
 	if s.showSlots {
 		switch a.Kind {
 		case InputMenuUp:
-			for i := s.selectedSlot - 1; i >= 0; i-- {
-				if s.saveSlots[i].Exists {
-					s.selectedSlot = i
-					break
-				}
+			if s.selectedSlot > 0 {
+				s.selectedSlot--
 			}
 		case InputMenuDown:
-			for i := s.selectedSlot + 1; i < len(s.saveSlots); i++ {
-				if s.saveSlots[i].Exists {
-					s.selectedSlot = i
-					break
-				}
+			if s.selectedSlot < len(s.saveSlots)-1 {
+				s.selectedSlot++
 			}
 		case InputMenuSelect:
-			if s.saveSlots[s.selectedSlot].Exists {
-				s.result = &StartResult{Action: ActionLoadSlot, SlotNum: s.saveSlots[s.selectedSlot].SlotNum}
+			if s.selectedSlot < len(s.saveSlots) {
+				s.result = &StartResult{Action: ActionLoadSlot, SlotName: s.saveSlots[s.selectedSlot].Name}
 			}
 		case InputMenuBack:
 			s.showSlots = false
@@ -335,19 +366,16 @@ func (s *StartScreen) HandleInput(a InputAction) tea.Cmd {
 	case InputMenuSelect:
 		selectedItem := s.menuItems[s.selected]
 		switch selectedItem {
+		case "Continue Ironman":
+			s.result = &StartResult{Action: ActionContinueIronman}
 		case "Continue":
 			s.result = &StartResult{Action: ActionContinue}
 		case "Load Game":
 			s.showSlots = true
 			s.selectedSlot = 0
-			for i, slot := range s.saveSlots {
-				if slot.Exists {
-					s.selectedSlot = i
-					break
-				}
-			}
 		case "New Game":
-			s.result = &StartResult{Action: ActionNewGame}
+			s.showModes = true
+			s.selectedMode = 0
 		case "Quit":
 			s.result = &StartResult{Action: ActionQuit}
 		}
@@ -370,22 +398,18 @@ func (s *StartScreen) updateMainMenu(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	case "enter", " ":
 		selectedItem := s.menuItems[s.selected]
 		switch selectedItem {
+		case "Continue Ironman":
+			s.result = &StartResult{Action: ActionContinueIronman}
+			return s, tea.Quit
 		case "Continue":
 			s.result = &StartResult{Action: ActionContinue}
 			return s, tea.Quit
 		case "Load Game":
 			s.showSlots = true
 			s.selectedSlot = 0
-			// Find first existing slot to select
-			for i, slot := range s.saveSlots {
-				if slot.Exists {
-					s.selectedSlot = i
-					break
-				}
-			}
 		case "New Game":
-			s.result = &StartResult{Action: ActionNewGame}
-			return s, tea.Quit
+			s.showModes = true
+			s.selectedMode = 0
 		case "Quit":
 			s.result = &StartResult{Action: ActionQuit}
 			// Don't start animation here - let GlobalScreen handle it
@@ -400,29 +424,48 @@ func (s *StartScreen) updateMainMenu(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	return s, nil
 }
 
+func (s *StartScreen) updateModeSelection(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "up", "k":
+		if s.selectedMode > 0 {
+			s.selectedMode--
+		}
+	case "down", "j":
+		if s.selectedMode < len(newGameModes)-1 {
+			s.selectedMode++
+		}
+	case "enter", " ":
+		s.result = s.newGameResult()
+		return s, tea.Quit
+	case "esc", "backspace":
+		s.showModes = false
+	case "q":
+		s.result = &StartResult{Action: ActionQuit}
+		return s, tea.Quit
+	}
+
+	return s, nil
+}
+
+func (s *StartScreen) newGameResult() *StartResult {
+	return &StartResult{Action: ActionNewGame, Ironman: newGameModes[s.selectedMode].Name == "Ironman"}
+}
+
 func (s *StartScreen) updateSlotSelection(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	switch msg.String() {
 	case "up", "k":
-		// Find previous existing slot
-		for i := s.selectedSlot - 1; i >= 0; i-- {
-			if s.saveSlots[i].Exists {
-				s.selectedSlot = i
-				break
-			}
+		if s.selectedSlot > 0 {
+			s.selectedSlot--
 		}
 	case "down", "j":
-		// Find next existing slot
-		for i := s.selectedSlot + 1; i < len(s.saveSlots); i++ {
-			if s.saveSlots[i].Exists {
-				s.selectedSlot = i
-				break
-			}
+		if s.selectedSlot < len(s.saveSlots)-1 {
+			s.selectedSlot++
 		}
 	case "enter", " ":
-		if s.saveSlots[s.selectedSlot].Exists {
+		if s.selectedSlot < len(s.saveSlots) {
 			s.result = &StartResult{
-				Action:  ActionLoadSlot,
-				SlotNum: s.saveSlots[s.selectedSlot].SlotNum,
+				Action:   ActionLoadSlot,
+				SlotName: s.saveSlots[s.selectedSlot].Name,
 			}
 			return s, tea.Quit
 		}
@@ -503,15 +546,11 @@ func (s *StartScreen) renderSlotSelection() string {
 	// Save slot list - compact without full width
 	var slotLines []string
 	for i, slot := range s.saveSlots {
-		if !slot.Exists {
-			continue // Skip non-existent slots
-		}
-
 		timeStr := slot.ModTime.Format("2006-01-02 15:04")
 		sizeStr := formatFileSize(slot.Size)
 
-		slotContent := fmt.Sprintf("Slot %d - %s (%s) - %s",
-			slot.SlotNum, slot.GameInfo, sizeStr, timeStr)
+		slotContent := fmt.Sprintf("%s - %s (%s) - %s",
+			slot.Name, slot.GameInfo, sizeStr, timeStr)
 
 		var style *StyleBuilder
 		if i == s.selectedSlot {
@@ -545,6 +584,44 @@ func (s *StartScreen) renderSlotSelection() string {
 	return content
 }
 
+func (s *StartScreen) renderModeSelection() string {
+	title := NewStyleBuilder().
+		Theme(ThemePrimary).
+		Bold(true).
+		Render("NEW GAME")
+
+	var modeLines []string
+	for i, mode := range newGameModes {
+		modeContent := fmt.Sprintf("%-8s - %s", mode.Name, mode.Description)
+
+		var style *StyleBuilder
+		if i == s.selectedMode {
+			modeContent = fmt.Sprintf("▶ %s ◀", modeContent)
+			style = NewStyleBuilder().Theme(ThemeSecondary).Bold(true)
+		} else {
+			modeContent = fmt.Sprintf("  %s  ", modeContent)
+			style = NewStyleBuilder().Theme(ThemeMuted)
+		}
+
+		modeLines = append(modeLines, style.Render(modeContent))
+	}
+
+	controls := NewStyleBuilder().
+		Theme(ThemeMuted).
+		Render("↑↓ navigate • Enter start • Esc back • Q quit")
+
+	content := NewComponentBuilder().
+		Add(title).
+		Add("").
+		Add(strings.Join(modeLines, "\n")).
+		Add("").
+		Add(controls).
+		Layout(lipgloss.Top).
+		Build()
+
+	return content
+}
+
 func formatFileSize(size int64) string {
 	if size < 1024 {
 		return fmt.Sprintf("%dB", size)
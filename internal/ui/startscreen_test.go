@@ -2,6 +2,7 @@ package ui
 
 import (
 	"os"
+	"path/filepath"
 	"testing"
 
 	tea "github.com/charmbracelet/bubbletea"
@@ -59,25 +60,32 @@ func TestStartScreenNavigation(t *testing.T) {
 }
 
 func TestSaveFileDetection(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv(SaveDirEnv, dir)
+	for _, name := range []string{"first run", "second-run"} {
+		_ = os.WriteFile(filepath.Join(dir, name+".gz"), []byte("test"), 0o644)
+	}
+
 	s := NewStartScreen()
 
-	// The saveSlots should be initialized (even if files don't exist)
-	if len(s.saveSlots) != 3 {
-		t.Error("Should detect 3 save slots")
+	if len(s.saveSlots) != 2 {
+		t.Fatalf("Should detect 2 save slots, got %d", len(s.saveSlots))
 	}
 
-	for i, slot := range s.saveSlots {
-		if slot.SlotNum != i+1 {
-			t.Errorf("Slot %d should have SlotNum %d", i, i+1)
-		}
+	names := map[string]bool{}
+	for _, slot := range s.saveSlots {
+		names[slot.Name] = true
+	}
+	if !names["first run"] || !names["second-run"] {
+		t.Errorf("Unexpected slot names: %v", names)
 	}
 }
 
 func TestMenuItemsWithSaves(t *testing.T) {
 	// Create a temporary autosave for testing
-	_ = os.MkdirAll(".saves", 0o755)
-	_ = os.WriteFile(".saves/autosave.gz", []byte("test"), 0o644)
-	defer os.Remove(".saves/autosave.gz")
+	dir := t.TempDir()
+	t.Setenv(SaveDirEnv, dir)
+	_ = os.WriteFile(filepath.Join(dir, "autosave.gz"), []byte("test"), 0o644)
 
 	s := NewStartScreen()
 
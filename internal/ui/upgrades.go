@@ -0,0 +1,102 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss/v2"
+	"harvester/pkg/components"
+	"harvester/pkg/data"
+	"harvester/pkg/ecs"
+	"harvester/pkg/rendering"
+	"harvester/pkg/systems"
+)
+
+// upgradeMenu is the ship upgrade overlay opened with 'u'. It lists every
+// upgrade line with its installed tier and the cost of the next one.
+type upgradeMenu struct {
+	model    *Model
+	lines    []data.UpgradeDef
+	selected int
+	w, h     int
+}
+
+func newUpgradeMenu(model *Model) *upgradeMenu {
+	return &upgradeMenu{model: model, lines: data.Upgrades()}
+}
+
+// HandleInput moves the selection or buys, and reports whether the menu
+// stays open.
+func (u *upgradeMenu) HandleInput(a InputAction) bool {
+	switch a.Kind {
+	case InputMenuBack, InputUpgrade:
+		return false
+	case InputMenuUp, InputMoveUp:
+		if u.selected > 0 {
+			u.selected--
+		}
+	case InputMenuDown, InputMoveDown:
+		if u.selected < len(u.lines)-1 {
+			u.selected++
+		}
+	case InputMenuSelect, InputEnter:
+		if len(u.lines) > 0 {
+			u.model.Upgrade(u.lines[u.selected].Kind)
+		}
+	}
+	return true
+}
+
+func (u *upgradeMenu) GetLayer() rendering.Layer { return rendering.LayerMenu }
+func (u *upgradeMenu) GetZ() int                 { return rendering.ZMenu }
+
+func (u *upgradeMenu) ToLipglossLayer() *lipgloss.Layer {
+	width := min(max(40, u.w-10), 72)
+	panel := ThemedPanel("Ship upgrades", u.content(width-4), ecs.GetWorldContext(u.model.world).CurrentLayer, width, 0)
+	x := max(0, (u.w-width)/2)
+	return lipgloss.NewLayer(panel).X(x).Y(2).Z(u.GetZ()).ID("upgrades")
+}
+
+func (u *upgradeMenu) content(width int) string {
+	w, player := u.model.world, u.model.player
+	var b strings.Builder
+	for i, def := range u.lines {
+		cursor := "  "
+		if i == u.selected {
+			cursor = "> "
+		}
+		tier := systems.UpgradeTier(w, player, def.Kind)
+		line := fmt.Sprintf("%s%-8s %3s/%-3s %s", cursor, def.Name, systems.RomanTier(tier), systems.RomanTier(def.MaxTier()), def.Effect)
+		err := systems.CanUpgrade(w, player, def.Kind)
+		if err != nil {
+			line = Muted(truncate(line, width))
+		} else if i == u.selected {
+			line = Highlight(truncate(line, width))
+		}
+		b.WriteString(line + "\n")
+		if i != u.selected {
+			continue
+		}
+		if cost, ok := def.Cost(tier + 1); ok {
+			b.WriteString(Muted("    next: "+truncate(describeCost(cost), width-10)) + "\n")
+		}
+		if err != nil {
+			b.WriteString(Muted("    "+truncate(err.Error(), width-4)) + "\n")
+		}
+	}
+	if ps, ok := ecs.Get[components.PlayerStats](w, player); ok {
+		b.WriteString("\n" + fmt.Sprintf("Hull %d · Fuel %d", ps.Hull, ps.Fuel) + "\n")
+	}
+	b.WriteString("\n" + Muted("↑/↓ or j/k select · enter install · esc close"))
+	return b.String()
+}
+
+// describeCost renders an upgrade cost as "5 energy cell, 4 iron ore".
+func describeCost(cost []data.ItemQty) string {
+	parts := make([]string, len(cost))
+	for i, q := range cost {
+		def, _ := data.Item(q.Item)
+		parts[i] = fmt.Sprintf("%d %s", q.Count, def.Name)
+	}
+	return strings.Join(parts, ", ")
+}
@@ -0,0 +1,21 @@
+package components
+
+// Name is how messages refer to an entity ("the wolf").
+type Name struct{ Text string }
+
+// Melee lets an entity make bump attacks. Accuracy is the percent chance to
+// hit; damage is rolled uniformly in [MinDamage, MaxDamage]. Cooldown is the
+// number of seconds between swings and Recover the time left until the next.
+type Melee struct {
+	Accuracy  int
+	MinDamage int
+	MaxDamage int
+	Cooldown  float64
+	Recover   float64
+}
+
+// Armor subtracts Value from every hit that gets past the shield.
+type Armor struct{ Value int }
+
+// Shield absorbs damage point for point until Current is exhausted.
+type Shield struct{ Current, Max int }
@@ -5,14 +5,34 @@ type Resource struct {
 	Amount int
 }
 
-type Inventory struct{ Items map[string]int }
+// ItemStack is a pile of identical items. Stacks never exceed the item's
+// MaxStack; systems.AddItem opens a new stack instead.
+type ItemStack struct {
+	Item  string
+	Count int
+}
+
+// Inventory holds item stacks in pickup order and the item id equipped in
+// each slot. Equipped items stay in Stacks and still count towards weight.
+type Inventory struct {
+	Stacks   []ItemStack
+	Equipped map[string]string `json:",omitempty"`
+}
 
-func (i *Inventory) Ensure() {
-	if i.Items == nil {
-		i.Items = make(map[string]int)
+// Count returns how many of item the inventory holds across all stacks.
+func (i Inventory) Count(item string) int {
+	n := 0
+	for _, s := range i.Stacks {
+		if s.Item == item {
+			n += s.Count
+		}
 	}
+	return n
 }
 
+// Workstation lets crafters within one tile use recipes that need Kind.
+type Workstation struct{ Kind string }
+
 type Faction struct{ Name string }
 
 type Damage struct{ Amount int }
@@ -1,5 +1,3 @@
 package components
 
 type Player struct{}
-
-type FuelTank struct{ Current int }
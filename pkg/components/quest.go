@@ -1,5 +1,7 @@
 package components
 
+// Quest is one entry in the player's quest log. Completing a quest with
+// EscapeReward set lets the player launch off the planet.
 type Quest struct {
 	ID           string
 	PlanetID     int
@@ -9,14 +11,48 @@ type Quest struct {
 	EscapeReward bool
 }
 
+// Done reports whether every objective is complete.
+func (q Quest) Done() bool {
+	for _, o := range q.Objectives {
+		if !o.Completed {
+			return false
+		}
+	}
+	return true
+}
+
+// Objective types. Target names what the objective is about: an item id
+// for collect, a creature name for kill (empty matches any), a speaker id
+// for talk and a recipe id for craft. Depth objectives complete at Count
+// depth. Location is an "x,y" surface spot: the place to visit, where the
+// speaker stands, or where a craft objective's station is set up.
+const (
+	ObjectiveCollect = "collect"
+	ObjectiveKill    = "kill"
+	ObjectiveDepth   = "depth"
+	ObjectiveVisit   = "visit"
+	ObjectiveTalk    = "talk"
+	ObjectiveCraft   = "craft"
+)
+
+// QuestObjective is one step of a quest. Progress counts towards Count.
 type QuestObjective struct {
 	Type      string
 	Target    string
 	Count     int
 	Location  string
+	Progress  int
 	Completed bool
 }
 
+// Reward types. Item rewards carry an item id in Value; fuel and hull
+// rewards carry an amount.
+const (
+	RewardItem = "item"
+	RewardFuel = "fuel"
+	RewardHull = "hull"
+)
+
 type Reward struct {
 	Type  string
 	Value interface{}
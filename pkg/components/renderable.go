@@ -17,6 +17,11 @@ const (
 	TileGalaxyCore
 	TileAsteroid
 	TileComet
+	TileBlackHole
+	TileWormhole
+	TileAnomaly
+	TileIce
+	TileStructure
 )
 
 type SpecialEffect int
@@ -1,9 +1,15 @@
 package components
 
+// PlayerStats holds the ship's hull and fuel and the tier of each installed
+// upgrade.
 type PlayerStats struct {
-	Fuel  int
-	Hull  int
-	Drive int
+	Fuel    int
+	Hull    int
+	Drive   int
+	Sensors int
+	Cargo   int
+	Shield  int
+	Warp    int
 }
 
 type WorldInfo struct {
@@ -0,0 +1,49 @@
+package components
+
+// FogMemory records which tiles of the current map the player has seen. It
+// is a row-major bitset sized to the map and belongs to one level: the
+// planet, layer and depth it was drawn on.
+type FogMemory struct {
+	PlanetID int
+	Layer    int
+	Depth    int
+	Width    int
+	Height   int
+	Seen     []byte
+}
+
+// NewFogMemory returns an empty memory for a width by height map.
+func NewFogMemory(planetID, layer, depth, width, height int) FogMemory {
+	return FogMemory{
+		PlanetID: planetID,
+		Layer:    layer,
+		Depth:    depth,
+		Width:    width,
+		Height:   height,
+		Seen:     make([]byte, (width*height+7)/8),
+	}
+}
+
+// Has reports whether (x, y) has been seen.
+func (f FogMemory) Has(x, y int) bool {
+	i, ok := f.index(x, y)
+	return ok && f.Seen[i/8]&(1<<(i%8)) != 0
+}
+
+// Mark records (x, y) as seen.
+func (f FogMemory) Mark(x, y int) {
+	if i, ok := f.index(x, y); ok {
+		f.Seen[i/8] |= 1 << (i % 8)
+	}
+}
+
+func (f FogMemory) index(x, y int) (int, bool) {
+	if x < 0 || y < 0 || x >= f.Width || y >= f.Height {
+		return 0, false
+	}
+	i := y*f.Width + x
+	if i/8 >= len(f.Seen) {
+		return 0, false
+	}
+	return i, true
+}
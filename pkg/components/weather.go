@@ -0,0 +1,71 @@
+package components
+
+import "math"
+
+// WeatherKind is the kind of weather a front brings.
+type WeatherKind int
+
+const (
+	WeatherClear WeatherKind = iota
+	WeatherRain
+	WeatherSnowstorm
+	WeatherAshFall
+	WeatherDust
+)
+
+func (k WeatherKind) String() string {
+	switch k {
+	case WeatherRain:
+		return "rain"
+	case WeatherSnowstorm:
+		return "snowstorm"
+	case WeatherAshFall:
+		return "ash fall"
+	case WeatherDust:
+		return "dust storm"
+	}
+	return "clear"
+}
+
+// WeatherFront is a patch of weather drifting across the map. It is
+// strongest, at Intensity, over its centre and fades to nothing at Radius,
+// and it dies down over its last moments before Remaining runs out.
+type WeatherFront struct {
+	Kind      WeatherKind
+	X, Y      float64
+	VX, VY    float64 // tiles per second
+	Radius    float64
+	Intensity float64 // 0 to 1
+	Remaining float64 // seconds
+}
+
+// frontFade is how many seconds a front takes to die down.
+const frontFade = 10.0
+
+// At returns the front's intensity over (x, y).
+func (f WeatherFront) At(x, y float64) float64 {
+	d := math.Hypot(x-f.X, y-f.Y)
+	if f.Radius <= 0 || d >= f.Radius || f.Remaining <= 0 {
+		return 0
+	}
+	return f.Intensity * (1 - d/f.Radius) * math.Min(1, f.Remaining/frontFade)
+}
+
+// Weather is the weather over the planet the player is on. It lives on
+// entity 1. Version changes whenever the fronts do.
+type Weather struct {
+	PlanetID int
+	Fronts   []WeatherFront
+	Version  uint64
+}
+
+// At returns the strongest weather over (x, y) and its intensity there.
+func (w Weather) At(x, y float64) (WeatherKind, float64) {
+	kind, best := WeatherClear, 0.0
+	for _, f := range w.Fronts {
+		if i := f.At(x, y); i > best {
+			kind, best = f.Kind, i
+		}
+	}
+	return kind, best
+}
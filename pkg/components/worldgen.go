@@ -1,5 +1,3 @@
 package components
 
 type RiverTag struct{}
-
-type Weather struct{ Rain bool }
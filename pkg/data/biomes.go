@@ -0,0 +1,225 @@
+package data
+
+import (
+	"math/rand"
+	"strings"
+
+	"harvester/pkg/components"
+)
+
+// BiomeGenerator builds the planets of one biome: the planet itself, the
+// depth layers beneath its surface with the features and hazards found in
+// each, and the quests offered on landing. At least one quest carries
+// EscapeReward and holds the ship until it is done.
+type BiomeGenerator interface {
+	Biome() BiomeType
+	// Name is the biome's world class, as in docs/GAME_VISION.md.
+	Name() string
+	// Generate rolls a planet of the biome; the same seed gives the same
+	// planet.
+	Generate(id int, seed int64) Planet
+	// DepthLayers splits p from the surface down to its MaxDepth.
+	DepthLayers(p Planet) []DepthLayer
+	// Quests returns the quest definitions offered on the biome's planets.
+	Quests() []components.Quest
+}
+
+// biomeWorld is a table-driven BiomeGenerator.
+type biomeWorld struct {
+	biome    BiomeType
+	name     string
+	depth    [2]int     // MaxDepth range
+	gravity  [2]float64 // GravityModifier range
+	surface  DepthLayer
+	shallow  DepthLayer
+	deep     DepthLayer
+	quests   []components.Quest
+	fixedMap func() Planet // hand-made planet in place of a rolled one
+}
+
+func (b biomeWorld) Biome() BiomeType           { return b.biome }
+func (b biomeWorld) Name() string               { return b.name }
+func (b biomeWorld) Quests() []components.Quest { return b.quests }
+
+func (b biomeWorld) Generate(id int, seed int64) Planet {
+	if b.fixedMap != nil {
+		p := b.fixedMap()
+		p.Seed = seed
+		return p
+	}
+	r := rand.New(rand.NewSource(seed))
+	var name strings.Builder
+	for i := 2 + r.Intn(2); i > 0; i-- {
+		name.WriteString(planetSyllables[r.Intn(len(planetSyllables))])
+	}
+	n := name.String()
+	return Planet{
+		ID:              id,
+		Name:            strings.ToUpper(n[:1]) + n[1:],
+		Biome:           b.biome,
+		MaxDepth:        b.depth[0] + 10*r.Intn((b.depth[1]-b.depth[0])/10+1),
+		GravityModifier: b.gravity[0] + r.Float64()*(b.gravity[1]-b.gravity[0]),
+		Seed:            seed,
+	}
+}
+
+// DepthLayers clips the surface, shallow and deep layers to p's depth.
+func (b biomeWorld) DepthLayers(p Planet) []DepthLayer {
+	var out []DepthLayer
+	for _, l := range []DepthLayer{b.surface, b.shallow, b.deep} {
+		if l.MinDepth > p.MaxDepth {
+			break
+		}
+		l.MaxDepth = min(l.MaxDepth, p.MaxDepth)
+		out = append(out, l)
+	}
+	if n := len(out); n > 0 {
+		out[n-1].MaxDepth = p.MaxDepth
+	}
+	return out
+}
+
+var biomeGenerators = map[BiomeType]BiomeGenerator{
+	BiomeToftForest: biomeWorld{
+		biome: BiomeToftForest, name: "Toft",
+		surface:  ToftDepthLayers()[0],
+		shallow:  ToftDepthLayers()[1],
+		deep:     ToftDepthLayers()[2],
+		quests:   questBook[1],
+		fixedMap: func() Planet { pg := PlanetGenerator{}; return *pg.GenerateToft() },
+	},
+	BiomeVolcanic: biomeWorld{
+		biome: BiomeVolcanic, name: "Vulcanus", depth: [2]int{120, 200}, gravity: [2]float64{1.0, 1.6},
+		surface: DepthLayer{MinDepth: 0, MaxDepth: 10, DifficultyMod: 1.0,
+			UniqueFeatures: []string{"lava_flows", "geysers", "glass_formations"}, Hazards: []string{"extreme_heat"}},
+		shallow: DepthLayer{MinDepth: 11, MaxDepth: 50, DifficultyMod: 2.0,
+			UniqueFeatures: []string{"lava_tubes", "thermal_vents"}, Hazards: []string{"extreme_heat", "toxic_vents"}},
+		deep: DepthLayer{MinDepth: 51, MaxDepth: 200, DifficultyMod: 4.0,
+			UniqueFeatures: []string{"magma_chambers", "heat_crystals", "molten_core"}, Hazards: []string{"extreme_heat", "toxic_vents", "lava_eruption"}},
+		quests: []components.Quest{
+			{ID: "forge_heat_shield", Description: "Gather volcanic glass and salvage and forge a heat shield fit for re-entry.",
+				Objectives: []components.QuestObjective{
+					{Type: components.ObjectiveCollect, Target: "obsidian", Count: 10},
+					{Type: components.ObjectiveCollect, Target: "scrap", Count: 4},
+					{Type: components.ObjectiveCraft, Target: "heat_shield", Count: 1, Location: "120,30"},
+				},
+				Rewards:      []components.Reward{{Type: components.RewardFuel, Value: 25}},
+				EscapeReward: true},
+			{ID: "geyser_survey", Description: "Chart the geyser field for the old prospectors' cache.",
+				Objectives: []components.QuestObjective{
+					{Type: components.ObjectiveVisit, Target: "geyser_field", Count: 1, Location: "60,50"},
+				},
+				Rewards: []components.Reward{{Type: components.RewardItem, Value: "pickaxe"}}},
+			{ID: "heat_crystals", Description: "Bring up five heat crystals from the glowing seams.",
+				Objectives: []components.QuestObjective{
+					{Type: components.ObjectiveCollect, Target: "heat_crystal", Count: 5},
+				},
+				Rewards: []components.Reward{{Type: components.RewardHull, Value: 15}}},
+		},
+	},
+	BiomeIce: biomeWorld{
+		biome: BiomeIce, name: "Glacialis", depth: [2]int{100, 180}, gravity: [2]float64{0.7, 1.2},
+		surface: DepthLayer{MinDepth: 0, MaxDepth: 10, DifficultyMod: 1.0,
+			UniqueFeatures: []string{"frozen_tundra", "ice_caves", "aurora"}, Hazards: []string{"freezing_winds"}},
+		shallow: DepthLayer{MinDepth: 11, MaxDepth: 50, DifficultyMod: 2.0,
+			UniqueFeatures: []string{"permafrost", "frozen_lakes"}, Hazards: []string{"freezing_winds", "blizzard"}},
+		deep: DepthLayer{MinDepth: 51, MaxDepth: 180, DifficultyMod: 3.5,
+			UniqueFeatures: []string{"frozen_specimens", "ice_crystal_cores"}, Hazards: []string{"blizzard", "ice_collapse"}},
+		quests: []components.Quest{
+			{ID: "revive_cryo_core", Description: "Bring ice essence down to the ancient cryo core and wake its systems.",
+				Objectives: []components.QuestObjective{
+					{Type: components.ObjectiveCollect, Target: "ice_essence", Count: 8},
+					{Type: components.ObjectiveDepth, Count: 30},
+					{Type: components.ObjectiveTalk, Target: "cryo_core", Count: 1, Location: "140,60"},
+				},
+				Rewards:      []components.Reward{{Type: components.RewardFuel, Value: 25}},
+				EscapeReward: true},
+			{ID: "frozen_specimens", Description: "Cut six ice crystals from the tundra for the sensor array.",
+				Objectives: []components.QuestObjective{
+					{Type: components.ObjectiveCollect, Target: "ice_crystal", Count: 6},
+				},
+				Rewards: []components.Reward{{Type: components.RewardItem, Value: "repair_kit"}}},
+		},
+	},
+	BiomeAncientRuins: biomeWorld{
+		biome: BiomeAncientRuins, name: "Mechanicus", depth: [2]int{150, 220}, gravity: [2]float64{0.9, 1.3},
+		surface: DepthLayer{MinDepth: 0, MaxDepth: 10, DifficultyMod: 1.0,
+			UniqueFeatures: []string{"abandoned_structures", "broken_machines", "dig_sites"}, Hazards: []string{"radiation"}},
+		shallow: DepthLayer{MinDepth: 11, MaxDepth: 50, DifficultyMod: 2.5,
+			UniqueFeatures: []string{"data_vaults", "sealed_halls"}, Hazards: []string{"radiation", "defense_turrets"}},
+		deep: DepthLayer{MinDepth: 51, MaxDepth: 220, DifficultyMod: 4.5,
+			UniqueFeatures: []string{"ancient_data_cores", "guardian_foundries"}, Hazards: []string{"radiation", "defense_turrets", "data_corruption"}},
+		quests: []components.Quest{
+			{ID: "rebuild_defense_grid", Description: "Salvage ancient circuits and bring the central core's defence grid back online.",
+				Objectives: []components.QuestObjective{
+					{Type: components.ObjectiveCollect, Target: "ancient_circuit", Count: 10},
+					{Type: components.ObjectiveDepth, Count: 20},
+					{Type: components.ObjectiveTalk, Target: "central_core", Count: 1, Location: "150,20"},
+				},
+				Rewards:      []components.Reward{{Type: components.RewardFuel, Value: 25}},
+				EscapeReward: true},
+			{ID: "salvage_run", Description: "Strip six loads of scrap from the broken machines.",
+				Objectives: []components.QuestObjective{
+					{Type: components.ObjectiveCollect, Target: "scrap", Count: 6},
+				},
+				Rewards: []components.Reward{{Type: components.RewardItem, Value: "spear"}}},
+		},
+	},
+}
+
+// Generator returns the generator for biome, falling back to Toft's.
+func Generator(biome BiomeType) BiomeGenerator {
+	if g, ok := biomeGenerators[biome]; ok {
+		return g
+	}
+	return biomeGenerators[BiomeToftForest]
+}
+
+// wildBiomes are the biomes rolled for generated planets; Toft's forest is
+// the one hand-made world.
+var wildBiomes = []BiomeType{BiomeVolcanic, BiomeIce, BiomeAncientRuins}
+
+// LayerAt returns the layer of layers holding depth, or the deepest layer
+// past the bottom.
+func LayerAt(layers []DepthLayer, depth int) DepthLayer {
+	for _, l := range layers {
+		if depth >= l.MinDepth && depth <= l.MaxDepth {
+			return l
+		}
+	}
+	if len(layers) == 0 {
+		return DepthLayer{DifficultyMod: 1}
+	}
+	return layers[len(layers)-1]
+}
+
+// SurfaceHazard is an environmental danger a depth layer can hold. Each
+// turn it strikes with Chance and deals Damage scaled by the layer's
+// DifficultyMod, but only while the player is close to the Near terrain it
+// comes from.
+type SurfaceHazard struct {
+	ID     string
+	Name   string
+	Near   components.TileType
+	Chance float64
+	Damage int
+	Text   string
+}
+
+var surfaceHazards = map[string]SurfaceHazard{
+	"extreme_heat":    {ID: "extreme_heat", Name: "the heat", Near: components.TileLava, Chance: 0.02, Damage: 1, Text: "The heat shimmers off the rock and saps your strength."},
+	"toxic_vents":     {ID: "toxic_vents", Name: "toxic gas", Near: components.TileLava, Chance: 0.02, Damage: 2, Text: "A vent hisses out a cloud of toxic gas."},
+	"lava_eruption":   {ID: "lava_eruption", Name: "a lava eruption", Near: components.TileLava, Chance: 0.01, Damage: 4, Text: "A fissure erupts, spattering lava!"},
+	"freezing_winds":  {ID: "freezing_winds", Name: "the cold", Near: components.TileIce, Chance: 0.02, Damage: 1, Text: "A freezing wind cuts through your gear."},
+	"blizzard":        {ID: "blizzard", Name: "the blizzard", Near: components.TileIce, Chance: 0.015, Damage: 2, Text: "A blizzard howls in, burying the path in snow."},
+	"ice_collapse":    {ID: "ice_collapse", Name: "falling ice", Near: components.TileIce, Chance: 0.01, Damage: 4, Text: "The ice overhead cracks and comes down!"},
+	"radiation":       {ID: "radiation", Name: "radiation", Near: components.TileStructure, Chance: 0.02, Damage: 1, Text: "Your dosimeter clicks faster near the old reactors."},
+	"defense_turrets": {ID: "defense_turrets", Name: "a defence turret", Near: components.TileStructure, Chance: 0.015, Damage: 3, Text: "A malfunctioning turret swivels and fires!"},
+	"data_corruption": {ID: "data_corruption", Name: "a corrupted system", Near: components.TileStructure, Chance: 0.01, Damage: 3, Text: "Corrupted machinery lashes out with an arc of current!"},
+}
+
+// Hazard returns the surface hazard with id.
+func Hazard(id string) (SurfaceHazard, bool) {
+	h, ok := surfaceHazards[id]
+	return h, ok
+}
@@ -0,0 +1,132 @@
+package data
+
+import "sort"
+
+type ItemCategory int
+
+const (
+	ItemMaterial ItemCategory = iota
+	ItemConsumable
+	ItemEquipment
+	ItemQuest
+)
+
+type EquipSlot int
+
+const (
+	SlotNone EquipSlot = iota
+	SlotWeapon
+	SlotArmor
+	SlotTool
+)
+
+func (s EquipSlot) String() string {
+	switch s {
+	case SlotWeapon:
+		return "weapon"
+	case SlotArmor:
+		return "armor"
+	case SlotTool:
+		return "tool"
+	}
+	return "none"
+}
+
+// ItemEffect is what using a consumable does to the user.
+type ItemEffect struct {
+	Heal int
+	Hull int
+	Fuel int
+}
+
+// HullCapacity is the ship's undamaged hull; repairs stop there.
+const HullCapacity = 100
+
+// ItemDef describes one kind of item. Weight is in kilograms and Volume in
+// litres; both are per unit.
+type ItemDef struct {
+	ID          string
+	Name        string
+	Description string
+	Category    ItemCategory
+	Weight      float64
+	Volume      float64
+	MaxStack    int
+	Slot        EquipSlot
+	Use         ItemEffect
+	Damage      int // melee bonus while equipped
+	Armor       int // armour bonus while equipped
+	ThrowDamage int
+}
+
+// defaultMaxStack applies to kinds missing from the catalogue, such as
+// resources added by a newer planet generator.
+const defaultMaxStack = 99
+
+var itemCatalogue = map[string]ItemDef{
+	"ore":             {ID: "ore", Name: "iron ore", Description: "Raw ore chipped from rock.", Category: ItemMaterial, Weight: 2, Volume: 1, MaxStack: 50, ThrowDamage: 2},
+	"wood":            {ID: "wood", Name: "timber", Description: "Straight forest timber.", Category: ItemMaterial, Weight: 1.5, Volume: 2, MaxStack: 40, ThrowDamage: 1},
+	"herbs":           {ID: "herbs", Name: "healing herbs", Description: "Bitter leaves that close wounds.", Category: ItemConsumable, Weight: 0.1, Volume: 0.2, MaxStack: 20, Use: ItemEffect{Heal: 6}},
+	"obsidian":        {ID: "obsidian", Name: "volcanic glass", Description: "Black glass from cooled lava.", Category: ItemMaterial, Weight: 1, Volume: 0.5, MaxStack: 50, ThrowDamage: 3},
+	"ice_crystal":     {ID: "ice_crystal", Name: "ice crystal", Description: "A crystal that never melts.", Category: ItemMaterial, Weight: 0.5, Volume: 0.5, MaxStack: 50},
+	"scrap":           {ID: "scrap", Name: "salvaged scrap", Description: "Twisted ancient alloy.", Category: ItemMaterial, Weight: 3, Volume: 2, MaxStack: 30, ThrowDamage: 2},
+	"energy":          {ID: "energy", Name: "energy cell", Description: "Harvested galactic energy.", Category: ItemMaterial, Weight: 0.5, Volume: 0.5, MaxStack: 99},
+	"data":            {ID: "data", Name: "data shard", Description: "Survey data from a galaxy node.", Category: ItemMaterial, Weight: 0.1, Volume: 0.1, MaxStack: 99},
+	"fuel_cell":       {ID: "fuel_cell", Name: "fuel cell", Description: "Refills the ship's tank.", Category: ItemConsumable, Weight: 4, Volume: 3, MaxStack: 10, Use: ItemEffect{Fuel: 25}},
+	"repair_kit":      {ID: "repair_kit", Name: "hull repair kit", Description: "Patches and sealant.", Category: ItemConsumable, Weight: 5, Volume: 4, MaxStack: 5, Use: ItemEffect{Hull: 20}},
+	"knife":           {ID: "knife", Name: "survival knife", Description: "Better than bare hands.", Category: ItemEquipment, Weight: 0.5, Volume: 0.3, MaxStack: 1, Slot: SlotWeapon, Damage: 2, ThrowDamage: 4},
+	"spear":           {ID: "spear", Name: "hunting spear", Description: "Long reach, heavy point.", Category: ItemEquipment, Weight: 2.5, Volume: 3, MaxStack: 1, Slot: SlotWeapon, Damage: 4, ThrowDamage: 6},
+	"leather_armor":   {ID: "leather_armor", Name: "leather armour", Description: "Stiff hide stitched into a vest.", Category: ItemEquipment, Weight: 6, Volume: 5, MaxStack: 1, Slot: SlotArmor, Armor: 2},
+	"pickaxe":         {ID: "pickaxe", Name: "pickaxe", Description: "For ore and stubborn rock.", Category: ItemEquipment, Weight: 3, Volume: 3, MaxStack: 1, Slot: SlotTool, Damage: 1},
+	"heat_shield":     {ID: "heat_shield", Name: "heat shield", Description: "Volcanic glass plates bonded to salvaged alloy; rated for re-entry.", Category: ItemQuest, Weight: 12, Volume: 8, MaxStack: 1},
+	"heat_crystal":    {ID: "heat_crystal", Name: "heat crystal", Description: "A crystal that glows with trapped magma heat.", Category: ItemQuest, Weight: 0.5, Volume: 0.3, MaxStack: 20},
+	"ice_essence":     {ID: "ice_essence", Name: "ice essence", Description: "Meltwater that stays colder than ice.", Category: ItemQuest, Weight: 0.3, Volume: 0.2, MaxStack: 20},
+	"ancient_circuit": {ID: "ancient_circuit", Name: "ancient circuit", Description: "A circuit board from before the ruins fell.", Category: ItemQuest, Weight: 0.2, Volume: 0.1, MaxStack: 30},
+	"trade_contract":  {ID: "trade_contract", Name: "trade contract", Description: "A sealed royal trade contract.", Category: ItemQuest, Weight: 0, Volume: 0.1, MaxStack: 99},
+}
+
+// Item returns the definition for id. Unknown ids get a plain material
+// definition so old saves and new resource kinds still stack and weigh.
+func Item(id string) (ItemDef, bool) {
+	if def, ok := itemCatalogue[id]; ok {
+		return def, true
+	}
+	return ItemDef{ID: id, Name: id, Category: ItemMaterial, Weight: 1, Volume: 1, MaxStack: defaultMaxStack}, false
+}
+
+// Items lists every catalogued item, sorted by id.
+func Items() []ItemDef {
+	out := make([]ItemDef, 0, len(itemCatalogue))
+	for _, def := range itemCatalogue {
+		out = append(out, def)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].ID < out[j].ID })
+	return out
+}
+
+// CargoHold is how much a ship's hold, and the pack carried from it, can
+// take.
+type CargoHold struct {
+	Weight float64
+	Volume float64
+}
+
+// cargoHolds is indexed by Cargo upgrade tier; tier 0 is the stock hold.
+var cargoHolds = []CargoHold{
+	{Weight: 60, Volume: 40},
+	{Weight: 90, Volume: 60},
+	{Weight: 130, Volume: 90},
+	{Weight: 180, Volume: 130},
+}
+
+// CargoCapacity returns the hold for a Cargo tier, clamped to the tiers
+// that exist.
+func CargoCapacity(tier int) CargoHold {
+	if tier < 0 {
+		tier = 0
+	}
+	if tier >= len(cargoHolds) {
+		tier = len(cargoHolds) - 1
+	}
+	return cargoHolds[tier]
+}
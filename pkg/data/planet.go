@@ -6,6 +6,7 @@ const (
 	BiomeToftForest BiomeType = iota
 	BiomeVolcanic
 	BiomeIce
+	BiomeAncientRuins
 )
 
 type Planet struct {
@@ -22,6 +23,7 @@ type DepthLayer struct {
 	MaxDepth       int
 	DifficultyMod  float64
 	UniqueFeatures []string
+	Hazards        []string // SurfaceHazard ids
 }
 
 type PlanetGenerator struct {
@@ -34,6 +36,25 @@ func (pg *PlanetGenerator) GenerateToft() *Planet {
 	return &Planet{ID: 1, Name: "Toft", Biome: BiomeToftForest, MaxDepth: 120, GravityModifier: 1.0, Seed: pg.Seed}
 }
 
+var planetSyllables = []string{"ka", "tor", "vel", "mi", "dra", "sol", "ix", "um", "bar", "eth", "no", "ri", "zan", "qua", "lo", "pen"}
+
+// Generate rolls planet id from the generator's seed with the biome's
+// generator, keeping MaxDepth if one is set.
+func (pg *PlanetGenerator) Generate(id int) *Planet {
+	p := Generator(pg.Biome).Generate(id, pg.Seed)
+	if pg.MaxDepth > 0 {
+		p.MaxDepth = pg.MaxDepth
+	}
+	return &p
+}
+
+// RandomPlanet rolls a planet of one of the wild biomes from seed. The same
+// seed always gives the same planet.
+func RandomPlanet(id int, seed int64) Planet {
+	biome := wildBiomes[uint64(seed)%uint64(len(wildBiomes))]
+	return Generator(biome).Generate(id, seed)
+}
+
 func ToftDepthLayers() []DepthLayer {
 	return []DepthLayer{
 		{MinDepth: 0, MaxDepth: 10, DifficultyMod: 1.0, UniqueFeatures: []string{"villages", "forest_edge"}},
@@ -0,0 +1,61 @@
+package data
+
+import "harvester/pkg/components"
+
+// questBook holds the quests offered on each planet, keyed by planet id.
+var questBook = map[int][]components.Quest{
+	1: {
+		{ID: "royal_charter", Description: "Recover the five royal trade contracts and present them to the harbour master.",
+			Objectives: []components.QuestObjective{
+				{Type: components.ObjectiveCollect, Target: "trade_contract", Count: 5},
+				{Type: components.ObjectiveTalk, Target: "harbour_master", Count: 1, Location: "100,40"},
+			},
+			Rewards:      []components.Reward{{Type: components.RewardFuel, Value: 25}},
+			EscapeReward: true},
+		{ID: "beast_cull", Description: "Thin out the wild beasts troubling the villages.",
+			Objectives: []components.QuestObjective{
+				{Type: components.ObjectiveKill, Target: "wild beast", Count: 3},
+			},
+			Rewards: []components.Reward{{Type: components.RewardItem, Value: "herbs"}, {Type: components.RewardHull, Value: 10}}},
+		{ID: "forest_survey", Description: "Survey the forest edge, then push ten leagues into the wilds.",
+			Objectives: []components.QuestObjective{
+				{Type: components.ObjectiveVisit, Target: "forest_edge", Count: 1, Location: "40,20"},
+				{Type: components.ObjectiveDepth, Count: 10},
+			},
+			Rewards: []components.Reward{{Type: components.RewardItem, Value: "repair_kit"}}},
+		{ID: "apprentice", Description: "Prove yourself at the forge by making a survival knife.",
+			Objectives: []components.QuestObjective{
+				{Type: components.ObjectiveCraft, Target: "knife", Count: 1, Location: "104,42"},
+			},
+			Rewards: []components.Reward{{Type: components.RewardItem, Value: "leather_armor"}}},
+	},
+}
+
+// PlanetQuests returns fresh copies of the quests offered on planet, with
+// PlanetID filled in. Planets without quests return nil.
+func PlanetQuests(planet int) []components.Quest {
+	return copyQuests(planet, questBook[planet])
+}
+
+// QuestsFor returns the quests offered on planet: its own from the quest
+// book, or else the ones its biome's generator offers.
+func QuestsFor(planet int, biome BiomeType) []components.Quest {
+	if _, ok := questBook[planet]; ok {
+		return PlanetQuests(planet)
+	}
+	return copyQuests(planet, Generator(biome).Quests())
+}
+
+func copyQuests(planet int, defs []components.Quest) []components.Quest {
+	if len(defs) == 0 {
+		return nil
+	}
+	out := make([]components.Quest, len(defs))
+	for i, q := range defs {
+		q.PlanetID = planet
+		q.Objectives = append([]components.QuestObjective(nil), q.Objectives...)
+		q.Rewards = append([]components.Reward(nil), q.Rewards...)
+		out[i] = q
+	}
+	return out
+}
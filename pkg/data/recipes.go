@@ -0,0 +1,66 @@
+package data
+
+import "sort"
+
+// ItemQty is a count of one item kind.
+type ItemQty struct {
+	Item  string
+	Count int
+}
+
+// Recipe turns Inputs into Outputs. Tools must be carried but are not used
+// up. Station names a workstation that must be within reach, and a non-zero
+// ModuleTier requires that tier of the Module upgrade on the ship.
+type Recipe struct {
+	ID         string
+	Name       string
+	Inputs     []ItemQty
+	Tools      []string
+	Station    string
+	Module     UpgradeKind
+	ModuleTier int
+	Outputs    []ItemQty
+}
+
+// Workstation kinds that recipes can require.
+const (
+	StationWorkbench = "workbench"
+	StationForge     = "forge"
+)
+
+var recipeBook = map[string]Recipe{
+	"knife": {ID: "knife", Name: "Survival knife",
+		Inputs: []ItemQty{{"ore", 2}, {"wood", 1}}, Station: StationForge,
+		Outputs: []ItemQty{{"knife", 1}}},
+	"spear": {ID: "spear", Name: "Hunting spear",
+		Inputs: []ItemQty{{"wood", 2}, {"obsidian", 1}}, Tools: []string{"knife"},
+		Outputs: []ItemQty{{"spear", 1}}},
+	"pickaxe": {ID: "pickaxe", Name: "Pickaxe",
+		Inputs: []ItemQty{{"wood", 1}, {"ore", 3}}, Station: StationWorkbench,
+		Outputs: []ItemQty{{"pickaxe", 1}}},
+	"repair_kit": {ID: "repair_kit", Name: "Hull repair kit",
+		Inputs: []ItemQty{{"scrap", 3}, {"ore", 2}}, Station: StationWorkbench,
+		Outputs: []ItemQty{{"repair_kit", 1}}},
+	"fuel_cell": {ID: "fuel_cell", Name: "Fuel cell",
+		Inputs: []ItemQty{{"energy", 4}, {"ore", 1}}, Module: Drive, ModuleTier: 1,
+		Outputs: []ItemQty{{"fuel_cell", 1}}},
+	"heat_shield": {ID: "heat_shield", Name: "Heat shield",
+		Inputs: []ItemQty{{"obsidian", 10}, {"scrap", 4}}, Tools: []string{"pickaxe"}, Station: StationForge,
+		Outputs: []ItemQty{{"heat_shield", 1}}},
+}
+
+// LookupRecipe returns the recipe with id.
+func LookupRecipe(id string) (Recipe, bool) {
+	r, ok := recipeBook[id]
+	return r, ok
+}
+
+// Recipes lists every recipe, sorted by id.
+func Recipes() []Recipe {
+	out := make([]Recipe, 0, len(recipeBook))
+	for _, r := range recipeBook {
+		out = append(out, r)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].ID < out[j].ID })
+	return out
+}
@@ -0,0 +1,99 @@
+package data
+
+// UpgradeDef is one line of ship upgrades. Costs[i] buys tier i+1, so the
+// line tops out at len(Costs).
+type UpgradeDef struct {
+	Kind   UpgradeKind
+	Name   string
+	Effect string
+	Costs  [][]ItemQty
+}
+
+// MaxTier is the highest tier the line offers.
+func (u UpgradeDef) MaxTier() int { return len(u.Costs) }
+
+// Cost returns what buying tier costs, and false past the top tier.
+func (u UpgradeDef) Cost(tier int) ([]ItemQty, bool) {
+	if tier < 1 || tier > len(u.Costs) {
+		return nil, false
+	}
+	return u.Costs[tier-1], true
+}
+
+var upgradeBook = []UpgradeDef{
+	{Kind: Drive, Name: "Drive", Effect: "more thrust, quicker turns, leaner burn",
+		Costs: [][]ItemQty{
+			{{"energy", 5}},
+			{{"energy", 10}, {"ore", 4}},
+			{{"energy", 15}, {"scrap", 4}},
+			{{"energy", 25}, {"scrap", 6}, {"data", 4}},
+			{{"energy", 40}, {"obsidian", 6}, {"data", 8}},
+		}},
+	{Kind: Sensors, Name: "Sensors", Effect: "wider field of view",
+		Costs: [][]ItemQty{
+			{{"data", 3}},
+			{{"data", 6}, {"ice_crystal", 2}},
+			{{"data", 10}, {"ice_crystal", 4}},
+			{{"data", 16}, {"energy", 10}},
+			{{"data", 24}, {"ice_crystal", 8}},
+		}},
+	{Kind: Cargo, Name: "Cargo", Effect: "bigger hold",
+		Costs: [][]ItemQty{
+			{{"ore", 6}, {"wood", 4}},
+			{{"ore", 10}, {"scrap", 4}},
+			{{"scrap", 10}, {"energy", 10}},
+		}},
+	{Kind: Shield, Name: "Shield", Effect: "absorbs hits and blunts hazards",
+		Costs: [][]ItemQty{
+			{{"scrap", 4}, {"energy", 6}},
+			{{"scrap", 8}, {"obsidian", 4}},
+			{{"scrap", 12}, {"ice_crystal", 6}, {"energy", 12}},
+		}},
+	{Kind: Warp, Name: "Warp", Effect: "short-range blink in space",
+		Costs: [][]ItemQty{
+			{{"energy", 30}, {"data", 12}, {"ice_crystal", 4}},
+		}},
+}
+
+// Upgrade returns the upgrade line for kind.
+func Upgrade(kind UpgradeKind) (UpgradeDef, bool) {
+	for _, u := range upgradeBook {
+		if u.Kind == kind {
+			return u, true
+		}
+	}
+	return UpgradeDef{}, false
+}
+
+// Upgrades lists every upgrade line in menu order.
+func Upgrades() []UpgradeDef {
+	out := make([]UpgradeDef, len(upgradeBook))
+	copy(out, upgradeBook)
+	return out
+}
+
+// FuelCapacity is the size of the ship's tank.
+const FuelCapacity = 100
+
+// Effects of each tier. Drive I is the stock drive, so Drive effects scale
+// from there; the others start at tier 0 with nothing installed.
+
+// DriveThrust is the thrust the drive pushes at full throttle.
+func DriveThrust(tier int) float64 { return 100 * (1 + 0.25*float64(driveStep(tier))) }
+
+// DriveTurn is the stiffness of the heading spring; higher turns faster.
+func DriveTurn(tier int) float64 { return 6 + 1.5*float64(driveStep(tier)) }
+
+// DriveBurn scales fuel burn.
+func DriveBurn(tier int) float64 { return 1 - 0.12*float64(driveStep(tier)) }
+
+// ShieldCapacity is the damage the shield soaks before hull or health.
+func ShieldCapacity(tier int) int { return 10 * max(0, tier) }
+
+// ShieldMitigation is the fraction of hazard damage the shield turns away.
+func ShieldMitigation(tier int) float64 { return 0.2 * float64(max(0, min(tier, 3))) }
+
+// WarpRange is how far, in tiles, a blink carries the ship.
+func WarpRange(tier int) int { return 8 * max(0, tier) }
+
+func driveStep(tier int) int { return max(0, tier-1) }
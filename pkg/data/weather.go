@@ -0,0 +1,55 @@
+package data
+
+import "harvester/pkg/components"
+
+// WeatherPattern is a kind of weather front a biome brews. Chance is how
+// likely one is to blow in per second; its length of stay, size and
+// strength are rolled from the ranges.
+type WeatherPattern struct {
+	Kind      components.WeatherKind
+	Chance    float64
+	Seconds   [2]float64
+	Radius    [2]float64
+	Intensity [2]float64
+}
+
+var biomeWeather = map[BiomeType][]WeatherPattern{
+	BiomeToftForest: {
+		{Kind: components.WeatherRain, Chance: 0.01, Seconds: [2]float64{60, 180}, Radius: [2]float64{15, 35}, Intensity: [2]float64{0.4, 1}},
+	},
+	BiomeVolcanic: {
+		{Kind: components.WeatherAshFall, Chance: 0.008, Seconds: [2]float64{40, 120}, Radius: [2]float64{10, 25}, Intensity: [2]float64{0.5, 1}},
+		{Kind: components.WeatherDust, Chance: 0.004, Seconds: [2]float64{30, 90}, Radius: [2]float64{20, 40}, Intensity: [2]float64{0.3, 0.8}},
+	},
+	BiomeIce: {
+		{Kind: components.WeatherSnowstorm, Chance: 0.01, Seconds: [2]float64{60, 200}, Radius: [2]float64{20, 45}, Intensity: [2]float64{0.4, 1}},
+	},
+	BiomeAncientRuins: {
+		{Kind: components.WeatherDust, Chance: 0.008, Seconds: [2]float64{40, 120}, Radius: [2]float64{15, 35}, Intensity: [2]float64{0.4, 1}},
+		{Kind: components.WeatherRain, Chance: 0.003, Seconds: [2]float64{40, 120}, Radius: [2]float64{15, 30}, Intensity: [2]float64{0.3, 0.7}},
+	},
+}
+
+// Weather returns the weather fronts biome brews.
+func Weather(biome BiomeType) []WeatherPattern { return biomeWeather[biome] }
+
+// WeatherEffect is what a kind of weather does at full intensity: Drag
+// slows movement (speed divided by 1+Drag), Sight is lost from the sight
+// radius, Fire speeds fires up or, below zero, puts them out, and Ignite is
+// the chance per turn that a front sets a tree under it alight.
+type WeatherEffect struct {
+	Drag   float64
+	Sight  float64
+	Fire   float64
+	Ignite float64
+}
+
+var weatherEffects = map[components.WeatherKind]WeatherEffect{
+	components.WeatherRain:      {Drag: 1, Sight: 2, Fire: -1},
+	components.WeatherSnowstorm: {Drag: 1.5, Sight: 4, Fire: -1},
+	components.WeatherAshFall:   {Drag: 0.25, Sight: 3, Fire: 1, Ignite: 0.05},
+	components.WeatherDust:      {Drag: 0.5, Sight: 3, Fire: 0.5},
+}
+
+// EffectOf returns what weather of kind does at full intensity.
+func EffectOf(kind components.WeatherKind) WeatherEffect { return weatherEffects[kind] }
@@ -0,0 +1,215 @@
+package ecs
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+)
+
+// CanonicalJSON encodes s so that equal snapshots always produce identical
+// bytes: component types and object keys are sorted, entities are ordered
+// numerically and numbers are re-formatted in one fixed style. The free list
+// keeps its order because it decides which entity Create returns next.
+func CanonicalJSON(s *Snapshot) ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteString(`{"version":`)
+	buf.WriteString(strconv.Itoa(s.Version))
+	buf.WriteString(`,"seed":`)
+	buf.WriteString(strconv.FormatInt(s.Seed, 10))
+	if s.Draws != 0 {
+		buf.WriteString(`,"draws":`)
+		buf.WriteString(strconv.FormatUint(s.Draws, 10))
+	}
+	buf.WriteString(`,"next":`)
+	buf.WriteString(strconv.FormatUint(uint64(s.Next), 10))
+	buf.WriteString(`,"free":`)
+	if s.Free == nil {
+		buf.WriteString("null")
+	} else {
+		buf.WriteByte('[')
+		for i, e := range s.Free {
+			if i > 0 {
+				buf.WriteByte(',')
+			}
+			buf.WriteString(strconv.FormatUint(uint64(e), 10))
+		}
+		buf.WriteByte(']')
+	}
+	if len(s.Meta) > 0 {
+		keys := make([]string, 0, len(s.Meta))
+		for k := range s.Meta {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		buf.WriteString(`,"meta":{`)
+		for i, k := range keys {
+			if i > 0 {
+				buf.WriteByte(',')
+			}
+			writeCanonicalString(&buf, k)
+			buf.WriteByte(':')
+			writeCanonicalString(&buf, s.Meta[k])
+		}
+		buf.WriteByte('}')
+	}
+	buf.WriteString(`,"components":`)
+	if s.Components == nil {
+		buf.WriteString("null")
+		buf.WriteByte('}')
+		return buf.Bytes(), nil
+	}
+	names := make([]string, 0, len(s.Components))
+	for name := range s.Components {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	buf.WriteByte('{')
+	for i, name := range names {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		writeCanonicalString(&buf, name)
+		buf.WriteByte(':')
+		data := s.Components[name]
+		if data == nil {
+			buf.WriteString("null")
+			continue
+		}
+		entities := make([]Entity, 0, len(data))
+		for e := range data {
+			entities = append(entities, e)
+		}
+		sort.Slice(entities, func(a, b int) bool { return entities[a] < entities[b] })
+		buf.WriteByte('{')
+		for j, e := range entities {
+			if j > 0 {
+				buf.WriteByte(',')
+			}
+			buf.WriteByte('"')
+			buf.WriteString(strconv.FormatUint(uint64(e), 10))
+			buf.WriteString(`":`)
+			if err := canonicalizeRaw(&buf, data[e]); err != nil {
+				return nil, fmt.Errorf("canonicalize %s[%d]: %w", name, e, err)
+			}
+		}
+		buf.WriteByte('}')
+	}
+	buf.WriteString("}}")
+	return buf.Bytes(), nil
+}
+
+// SnapshotHash returns the hex SHA-256 of the canonical encoding of s.
+func SnapshotHash(s *Snapshot) (string, error) {
+	b, err := CanonicalJSON(s)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(b)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// Hash fingerprints the persisted world state. Two worlds with the same hash
+// hold the same entities, components, allocator state and RNG position;
+// non-persisted components are not covered.
+func (w *World) Hash() (string, error) {
+	s, err := Save(w, nil)
+	if err != nil {
+		return "", err
+	}
+	return SnapshotHash(s)
+}
+
+func canonicalizeRaw(buf *bytes.Buffer, raw json.RawMessage) error {
+	if len(raw) == 0 {
+		buf.WriteString("null")
+		return nil
+	}
+	dec := json.NewDecoder(bytes.NewReader(raw))
+	dec.UseNumber()
+	var v any
+	if err := dec.Decode(&v); err != nil {
+		return err
+	}
+	return writeCanonicalValue(buf, v)
+}
+
+func writeCanonicalValue(buf *bytes.Buffer, v any) error {
+	switch x := v.(type) {
+	case nil:
+		buf.WriteString("null")
+	case bool:
+		buf.WriteString(strconv.FormatBool(x))
+	case string:
+		writeCanonicalString(buf, x)
+	case json.Number:
+		return writeCanonicalNumber(buf, x)
+	case []any:
+		buf.WriteByte('[')
+		for i, el := range x {
+			if i > 0 {
+				buf.WriteByte(',')
+			}
+			if err := writeCanonicalValue(buf, el); err != nil {
+				return err
+			}
+		}
+		buf.WriteByte(']')
+	case map[string]any:
+		keys := make([]string, 0, len(x))
+		for k := range x {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		buf.WriteByte('{')
+		for i, k := range keys {
+			if i > 0 {
+				buf.WriteByte(',')
+			}
+			writeCanonicalString(buf, k)
+			buf.WriteByte(':')
+			if err := writeCanonicalValue(buf, x[k]); err != nil {
+				return err
+			}
+		}
+		buf.WriteByte('}')
+	default:
+		return fmt.Errorf("unexpected JSON value %T", v)
+	}
+	return nil
+}
+
+// writeCanonicalNumber prints integers verbatim and every other number as the
+// shortest float64 representation, so 1, 1.0 and 1e0 all encode as "1".
+func writeCanonicalNumber(buf *bytes.Buffer, n json.Number) error {
+	if i, err := strconv.ParseInt(n.String(), 10, 64); err == nil {
+		buf.WriteString(strconv.FormatInt(i, 10))
+		return nil
+	}
+	if u, err := strconv.ParseUint(n.String(), 10, 64); err == nil {
+		buf.WriteString(strconv.FormatUint(u, 10))
+		return nil
+	}
+	f, err := n.Float64()
+	if err != nil {
+		return err
+	}
+	if math.IsInf(f, 0) || math.IsNaN(f) {
+		return fmt.Errorf("number %s out of range", n)
+	}
+	if f == math.Trunc(f) && math.Abs(f) < 1<<53 {
+		buf.WriteString(strconv.FormatInt(int64(f), 10))
+		return nil
+	}
+	buf.WriteString(strconv.FormatFloat(f, 'g', -1, 64))
+	return nil
+}
+
+func writeCanonicalString(buf *bytes.Buffer, s string) {
+	b, _ := json.Marshal(s)
+	buf.Write(b)
+}
@@ -19,7 +19,7 @@ type encodedBlob struct {
 }
 
 func EncodeSnapshot(s *Snapshot, opt SaveOptions) ([]byte, error) {
-	b, err := json.Marshal(s)
+	b, err := CanonicalJSON(s)
 	if err != nil {
 		return nil, err
 	}
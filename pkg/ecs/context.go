@@ -14,6 +14,21 @@ type WorldContext struct {
 	Depth         int
 	BiomeType     int
 	QuestProgress QuestProgress
+	// GameOver is set once the run has ended; GameOverReason says why.
+	GameOver       bool
+	GameOverReason string
+	// CompletedPlanets lists the planets the player has launched from.
+	CompletedPlanets []int `json:",omitempty"`
+}
+
+// PlanetCompleted reports whether the player has already launched from planet.
+func (c WorldContext) PlanetCompleted(planet int) bool {
+	for _, p := range c.CompletedPlanets {
+		if p == planet {
+			return true
+		}
+	}
+	return false
 }
 
 type QuestProgress struct {
@@ -27,13 +42,28 @@ type QuestProgress struct {
 
 type contextKey struct{}
 
-var contextEntity Entity = 0
-
+// ensureContextEntity returns the entity holding w's WorldContext, adopting
+// one restored by Load or creating it on first use. It is tracked per world so
+// that several worlds (tests, the start screen backdrop) never share an ID.
 func ensureContextEntity(w *World) Entity {
-	if contextEntity == 0 {
-		contextEntity = w.Create()
+	w.mu.RLock()
+	e := w.ctxEntity
+	w.mu.RUnlock()
+	if e != 0 {
+		return e
+	}
+	storeOf[WorldContext](w).ForEach(func(owner Entity, _ *WorldContext) {
+		if e == 0 || owner < e {
+			e = owner
+		}
+	})
+	if e == 0 {
+		e = w.Create()
 	}
-	return contextEntity
+	w.mu.Lock()
+	w.ctxEntity = e
+	w.mu.Unlock()
+	return e
 }
 
 func SetWorldContext(w *World, ctx WorldContext) {
@@ -0,0 +1,49 @@
+package ecs
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// EntityState is one entity's persisted components, keyed by the same type
+// names as Snapshot.Components. It lets an entity be lifted out of a world
+// and put back later, as a new entity.
+type EntityState map[string]json.RawMessage
+
+// SaveEntity encodes the persisted components of e.
+func SaveEntity(w *World, e Entity) (EntityState, error) {
+	s := EntityState{}
+	for _, ps := range persisted {
+		raw, ok, err := ps.get(w, e)
+		if err != nil {
+			return nil, fmt.Errorf("save %s of entity %d: %w", ps.name, e, err)
+		}
+		if ok {
+			s[ps.name] = raw
+		}
+	}
+	return s, nil
+}
+
+// RestoreEntity creates an entity holding the components of s. Components
+// of types no longer persisted are skipped. If one fails to decode nothing
+// is created.
+func RestoreEntity(w *World, s EntityState) (Entity, error) {
+	var writes []func(Entity)
+	for _, ps := range persisted {
+		raw, ok := s[ps.name]
+		if !ok {
+			continue
+		}
+		write, err := ps.put(w, raw)
+		if err != nil {
+			return 0, fmt.Errorf("restore %s: %w", ps.name, err)
+		}
+		writes = append(writes, write)
+	}
+	e := w.Create()
+	for _, write := range writes {
+		write(e)
+	}
+	return e, nil
+}
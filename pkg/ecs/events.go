@@ -0,0 +1,49 @@
+package ecs
+
+// eventLogSize bounds how many events the world keeps; readers that fall
+// further behind than this simply miss the oldest ones.
+const eventLogSize = 256
+
+// Event is a gameplay notification, such as a hit or a kill, that systems
+// publish for the UI and for other systems to react to. Kinds are defined by
+// the systems that emit them. Events are not persisted.
+type Event struct {
+	Seq    uint64
+	Kind   string
+	Source Entity
+	Target Entity
+	Amount int
+	// Subject names what the event is about, such as an item or recipe id.
+	Subject string
+	Text    string
+}
+
+// Emit appends ev to the world's event log and returns its sequence number.
+func (w *World) Emit(ev Event) uint64 {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.eventSeq++
+	ev.Seq = w.eventSeq
+	w.events = append(w.events, ev)
+	if len(w.events) > eventLogSize {
+		w.events = append(w.events[:0], w.events[len(w.events)-eventLogSize:]...)
+	}
+	return ev.Seq
+}
+
+// EventsSince returns the retained events with a sequence number above seq,
+// oldest first, and the sequence number to pass on the next call.
+func (w *World) EventsSince(seq uint64) ([]Event, uint64) {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+	i := len(w.events)
+	for i > 0 && w.events[i-1].Seq > seq {
+		i--
+	}
+	if i == len(w.events) {
+		return nil, w.eventSeq
+	}
+	out := make([]Event, len(w.events)-i)
+	copy(out, w.events[i:])
+	return out, w.eventSeq
+}
@@ -0,0 +1,90 @@
+package ecs
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// maxReportedErrors caps how many per-entity failures a component keeps, so a
+// save with thousands of bad rows does not produce a report of the same size.
+const maxReportedErrors = 5
+
+// ErrPartialLoad is returned by a strict load when any component fails to decode.
+var ErrPartialLoad = errors.New("snapshot did not load cleanly")
+
+// LoadOptions controls how a snapshot is restored.
+type LoadOptions struct {
+	// Decode unmarshals one component payload; nil means json.Unmarshal.
+	Decode func(data []byte, v any) error
+	// Strict aborts the load, leaving the world untouched, when any component
+	// fails to decode or the snapshot holds component types this build does not know.
+	Strict bool
+}
+
+// EntityLoadError records why one entity's component could not be restored.
+type EntityLoadError struct {
+	Entity Entity
+	Err    error
+}
+
+// ComponentLoadStats summarises the restore of one component type.
+type ComponentLoadStats struct {
+	Component string
+	Loaded    int
+	Failed    int
+	// Errors holds the first few failures; Failed is the full count.
+	Errors []EntityLoadError
+}
+
+// LoadReport describes what a Load restored and what it had to drop.
+type LoadReport struct {
+	Components []ComponentLoadStats
+	// Unknown lists snapshot component types that no persisted store claims.
+	Unknown []string
+}
+
+// Failed returns the number of component rows that could not be restored.
+func (r *LoadReport) Failed() int {
+	n := 0
+	for _, c := range r.Components {
+		n += c.Failed
+	}
+	return n
+}
+
+// Clean reports whether every row in the snapshot was restored.
+func (r *LoadReport) Clean() bool {
+	return r.Failed() == 0 && len(r.Unknown) == 0
+}
+
+// Warnings returns one human-readable line per problem, suitable for a message log.
+func (r *LoadReport) Warnings() []string {
+	var out []string
+	for _, c := range r.Components {
+		if c.Failed == 0 {
+			continue
+		}
+		line := fmt.Sprintf("%s: %d of %d failed to load", c.Component, c.Failed, c.Loaded+c.Failed)
+		if len(c.Errors) > 0 {
+			line += fmt.Sprintf(" (entity %d: %v)", c.Errors[0].Entity, c.Errors[0].Err)
+		}
+		out = append(out, line)
+	}
+	for _, name := range r.Unknown {
+		out = append(out, fmt.Sprintf("%s: unknown component type ignored", name))
+	}
+	return out
+}
+
+// err summarises the report as an ErrPartialLoad error.
+func (r *LoadReport) err() error {
+	return fmt.Errorf("%w: %s", ErrPartialLoad, strings.Join(r.Warnings(), "; "))
+}
+
+func (c *ComponentLoadStats) fail(e Entity, err error) {
+	c.Failed++
+	if len(c.Errors) < maxReportedErrors {
+		c.Errors = append(c.Errors, EntityLoadError{Entity: e, Err: err})
+	}
+}
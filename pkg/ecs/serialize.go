@@ -2,8 +2,9 @@ package ecs
 
 import (
 	"encoding/json"
-	"math/rand"
+	"fmt"
 	"reflect"
+	"sort"
 
 	"harvester/pkg/components"
 )
@@ -11,9 +12,13 @@ import (
 type Snapshot struct {
 	Version    int                                   `json:"version"`
 	Seed       int64                                 `json:"seed"`
+	Draws      uint64                                `json:"draws,omitempty"` // values drawn from the world RNG since it was seeded
 	Next       Entity                                `json:"next"`
 	Free       []Entity                              `json:"free"`
 	Components map[string]map[Entity]json.RawMessage `json:"components"`
+	// Meta carries save-level bookkeeping (run identity, save counters) that is
+	// not part of the world itself. Save leaves it empty; Load ignores it.
+	Meta map[string]string `json:"meta,omitempty"`
 }
 
 func Save(w *World, enc func(v any) ([]byte, error)) (*Snapshot, error) {
@@ -27,6 +32,7 @@ func Save(w *World, enc func(v any) ([]byte, error)) (*Snapshot, error) {
 	// entity allocator state
 	w.mu.RLock()
 	s.Seed = w.seed
+	s.Draws = w.src.n
 	s.Next = w.next
 	if len(w.free) > 0 {
 		cp := make([]Entity, len(w.free))
@@ -35,96 +41,263 @@ func Save(w *World, enc func(v any) ([]byte, error)) (*Snapshot, error) {
 	}
 	w.mu.RUnlock()
 	// Persist known baseline component stores explicitly for type safety
-	s.Components[typeName[components.Position]()] = dumpStore(enc, storeOf[components.Position](w))
-	s.Components[typeName[components.Velocity]()] = dumpStore(enc, storeOf[components.Velocity](w))
-	s.Components[typeName[components.Camera]()] = dumpStore(enc, storeOf[components.Camera](w))
-	s.Components[typeName[components.PlayerStats]()] = dumpStore(enc, storeOf[components.PlayerStats](w))
-	s.Components[typeName[components.WorldInfo]()] = dumpStore(enc, storeOf[components.WorldInfo](w))
-	s.Components[typeName[components.Input]()] = dumpStore(enc, storeOf[components.Input](w))
-	s.Components[typeName[components.Inventory]()] = dumpStore(enc, storeOf[components.Inventory](w))
-	s.Components[typeName[components.Resource]()] = dumpStore(enc, storeOf[components.Resource](w))
-	s.Components[typeName[components.Tile]()] = dumpStore(enc, storeOf[components.Tile](w))
-	s.Components[typeName[components.Renderable]()] = dumpStore(enc, storeOf[components.Renderable](w))
-	s.Components[typeName[components.Health]()] = dumpStore(enc, storeOf[components.Health](w))
-	// persist WorldContext and surface-related systems' ad hoc components
-	s.Components[typeName[WorldContext]()] = dumpStore(enc, storeOf[WorldContext](w))
+	for _, ps := range persisted {
+		data, err := ps.dump(w, enc)
+		if err != nil {
+			return nil, fmt.Errorf("save %s: %w", ps.name, err)
+		}
+		s.Components[ps.name] = data
+	}
 	return s, nil
 }
 
-func Load(w *World, s *Snapshot, dec func(data []byte, v any) error) error {
+// Load restores s into w, skipping rows that fail to decode. The returned
+// report lists what was restored and what was dropped.
+func Load(w *World, s *Snapshot, dec func(data []byte, v any) error) (*LoadReport, error) {
+	return LoadWithOptions(w, s, LoadOptions{Decode: dec})
+}
+
+// LoadWithOptions restores s into w. Every component is decoded before the
+// world is touched, so a strict load that fails leaves w as it was.
+func LoadWithOptions(w *World, s *Snapshot, opt LoadOptions) (*LoadReport, error) {
 	w.saveMu.Lock()
 	defer w.saveMu.Unlock()
+	dec := opt.Decode
 	if dec == nil {
 		dec = json.Unmarshal
 	}
 	if err := maybeMigrateSnapshot(s); err != nil {
-		return err
+		return nil, err
+	}
+	report := &LoadReport{}
+	known := make(map[string]bool, len(persisted))
+	applies := make([]func(), 0, len(persisted))
+	for _, ps := range persisted {
+		known[ps.name] = true
+		apply, stats := ps.load(w, dec, s.Components[ps.name])
+		report.Components = append(report.Components, stats)
+		if apply != nil {
+			applies = append(applies, apply)
+		}
+	}
+	for name := range s.Components {
+		if !known[name] {
+			report.Unknown = append(report.Unknown, name)
+		}
+	}
+	sort.Strings(report.Unknown)
+	if opt.Strict && !report.Clean() {
+		return report, report.err()
 	}
 	// restore allocator deterministically
 	w.mu.Lock()
 	if s.Version >= 1 && s.Seed != 0 {
-		w.seed = s.Seed
-		w.rng = rand.New(rand.NewSource(w.seed))
+		w.reseed(s.Seed, s.Draws)
 	}
 	w.next = s.Next
+	w.ctxEntity = 0 // rediscover the context holder from the loaded stores
 	w.free = nil
 	if len(s.Free) > 0 {
 		w.free = make([]Entity, len(s.Free))
 		copy(w.free, s.Free)
 	}
 	w.mu.Unlock()
-	loadStore(dec, storeOf[components.Position](w), s.Components[typeName[components.Position]()])
-	loadStore(dec, storeOf[components.Velocity](w), s.Components[typeName[components.Velocity]()])
-	loadStore(dec, storeOf[components.Camera](w), s.Components[typeName[components.Camera]()])
-	loadStore(dec, storeOf[components.PlayerStats](w), s.Components[typeName[components.PlayerStats]()])
-	loadStore(dec, storeOf[components.WorldInfo](w), s.Components[typeName[components.WorldInfo]()])
-	loadStore(dec, storeOf[components.Input](w), s.Components[typeName[components.Input]()])
-	loadStore(dec, storeOf[components.Inventory](w), s.Components[typeName[components.Inventory]()])
-	loadStore(dec, storeOf[components.Resource](w), s.Components[typeName[components.Resource]()])
-	loadStore(dec, storeOf[components.Tile](w), s.Components[typeName[components.Tile]()])
-	loadStore(dec, storeOf[components.Renderable](w), s.Components[typeName[components.Renderable]()])
-	loadStore(dec, storeOf[components.Health](w), s.Components[typeName[components.Health]()])
-	loadStore(dec, storeOf[WorldContext](w), s.Components[typeName[WorldContext]()])
-	return nil
+	for _, apply := range applies {
+		apply()
+	}
+	return report, nil
 }
 
-func dumpStore[T any](enc func(v any) ([]byte, error), st *store[T]) map[Entity]json.RawMessage {
+func dumpStore[T any](enc func(v any) ([]byte, error), st *store[T]) (map[Entity]json.RawMessage, error) {
 	m := make(map[Entity]json.RawMessage)
+	var firstErr error
 	st.ForEach(func(e Entity, t *T) {
-		b, _ := enc(t)
+		if firstErr != nil {
+			return
+		}
+		b, err := enc(t)
+		if err != nil {
+			firstErr = fmt.Errorf("entity %d: %w", e, err)
+			return
+		}
 		m[e] = b
 	})
-	return m
+	return m, firstErr
 }
 
-func loadStore[T any](dec func([]byte, any) error, st *store[T], data map[Entity]json.RawMessage) {
+// decodeStore decodes every row of data and returns a function that replaces
+// the store's contents with the decoded rows. Rows are visited in entity order
+// so the failures kept in the stats are stable. A nil data map leaves the store
+// alone and yields a nil apply.
+func decodeStore[T any](dec func([]byte, any) error, st *store[T], name string, data map[Entity]json.RawMessage) (func(), ComponentLoadStats) {
+	stats := ComponentLoadStats{Component: name}
 	if data == nil {
-		return
-	}
-	// clear existing for deterministic restore
-	st.mu.Lock()
-	st.data = make(map[Entity]T)
-	st.index = make(map[Entity]struct{})
-	st.mu.Unlock()
-	for e, raw := range data {
+		return nil, stats
+	}
+	entities := make([]Entity, 0, len(data))
+	for e := range data {
+		entities = append(entities, e)
+	}
+	sort.Slice(entities, func(i, j int) bool { return entities[i] < entities[j] })
+	decoded := make(map[Entity]T, len(data))
+	for _, e := range entities {
 		var v T
-		if dec(raw, &v) == nil {
-			// post-unmarshal fixups for known types
-			switch any(&v).(type) {
-			case *components.Inventory:
-				iv := any(&v).(*components.Inventory)
-				iv.Ensure()
+		if err := dec(data[e], &v); err != nil {
+			stats.fail(e, err)
+			continue
+		}
+		// post-unmarshal fixups for known types
+		switch any(&v).(type) {
+		case *components.Inventory:
+			iv := any(&v).(*components.Inventory)
+			if iv.Equipped == nil {
+				iv.Equipped = make(map[string]string)
 			}
+		}
+		decoded[e] = v
+		stats.Loaded++
+	}
+	return func() {
+		// clear existing for deterministic restore
+		st.mu.Lock()
+		st.data = make(map[Entity]T)
+		st.index = make(map[Entity]struct{})
+		st.changes++
+		st.mu.Unlock()
+		for e, v := range decoded {
 			st.Add(e, v)
 		}
+	}, stats
+}
+
+// persistedStore binds a component type to its snapshot key.
+type persistedStore struct {
+	name string
+	dump func(w *World, enc func(v any) ([]byte, error)) (map[Entity]json.RawMessage, error)
+	load func(w *World, dec func([]byte, any) error, data map[Entity]json.RawMessage) (func(), ComponentLoadStats)
+	// get and put move a single entity's row; put decodes straight away and
+	// returns the write for later.
+	get func(w *World, e Entity) (json.RawMessage, bool, error)
+	put func(w *World, raw json.RawMessage) (func(Entity), error)
+}
+
+func persist[T any]() persistedStore {
+	return persistedStore{
+		name: typeName[T](),
+		dump: func(w *World, enc func(v any) ([]byte, error)) (map[Entity]json.RawMessage, error) {
+			return dumpStore(enc, storeOf[T](w))
+		},
+		load: func(w *World, dec func([]byte, any) error, data map[Entity]json.RawMessage) (func(), ComponentLoadStats) {
+			return decodeStore(dec, storeOf[T](w), typeName[T](), data)
+		},
+		get: func(w *World, e Entity) (json.RawMessage, bool, error) {
+			v, ok := storeOf[T](w).Get(e)
+			if !ok {
+				return nil, false, nil
+			}
+			b, err := json.Marshal(v)
+			return b, true, err
+		},
+		put: func(w *World, raw json.RawMessage) (func(Entity), error) {
+			var v T
+			if err := json.Unmarshal(raw, &v); err != nil {
+				return nil, err
+			}
+			return func(e Entity) { storeOf[T](w).Add(e, v) }, nil
+		},
+	}
+}
+
+// persisted lists the component stores written to snapshots, in save order.
+var persisted = []persistedStore{
+	persist[components.Position](),
+	persist[components.Velocity](),
+	persist[components.Camera](),
+	persist[components.PlayerStats](),
+	persist[components.WorldInfo](),
+	persist[components.Input](),
+	persist[components.Inventory](),
+	persist[components.Resource](),
+	persist[components.Tile](),
+	persist[components.Renderable](),
+	persist[components.Health](),
+	persist[components.Name](),
+	persist[components.Melee](),
+	persist[components.Armor](),
+	persist[components.Shield](),
+	persist[components.FogMemory](),
+	persist[components.Workstation](),
+	persist[components.Weather](),
+	// persist WorldContext and surface-related systems' ad hoc components
+	persist[WorldContext](),
+}
+
+// RegisterComponent adds T to the persisted component types. Packages that
+// define components outside pkg/components register them from init.
+// Registering a type twice is a no-op.
+func RegisterComponent[T any]() {
+	name := typeName[T]()
+	for _, ps := range persisted {
+		if ps.name == name {
+			return
+		}
+	}
+	persisted = append(persisted, persist[T]())
+}
+
+// PersistedComponents returns the snapshot keys of every persisted component type.
+func PersistedComponents() []string {
+	names := make([]string, len(persisted))
+	for i, ps := range persisted {
+		names[i] = ps.name
 	}
+	return names
 }
 
 func typeName[T any]() string { return reflect.TypeOf((*T)(nil)).Elem().String() }
 
-var snapshotMigrations = map[int]func(*Snapshot) error{}
+// snapshotMigrations upgrades a snapshot from the keyed version to the next.
+var snapshotMigrations = map[int]func(*Snapshot) error{
+	1: migrateInventoryStacks,
+}
+
+func currentSnapshotVersion() int { return 2 }
+
+// migrateInventoryStacks rewrites version 1 inventories, a plain
+// {"Items": {kind: count}} map, as one stack per kind in sorted order.
+// Oversized stacks are split by systems.AddItem the next time they change.
+func migrateInventoryStacks(s *Snapshot) error {
+	rows := s.Components[typeName[components.Inventory]()]
+	for e, raw := range rows {
+		var old struct{ Items map[string]int }
+		if err := json.Unmarshal(raw, &old); err != nil {
+			return fmt.Errorf("migrate inventory of entity %d: %w", e, err)
+		}
+		kinds := make([]string, 0, len(old.Items))
+		for k, n := range old.Items {
+			if n > 0 {
+				kinds = append(kinds, k)
+			}
+		}
+		sort.Strings(kinds)
+		inv := components.Inventory{Stacks: make([]components.ItemStack, 0, len(kinds))}
+		for _, k := range kinds {
+			inv.Stacks = append(inv.Stacks, components.ItemStack{Item: k, Count: old.Items[k]})
+		}
+		b, err := json.Marshal(inv)
+		if err != nil {
+			return fmt.Errorf("migrate inventory of entity %d: %w", e, err)
+		}
+		rows[e] = b
+	}
+	return nil
+}
+
+// CurrentSnapshotVersion reports the snapshot version written by Save.
+func CurrentSnapshotVersion() int { return currentSnapshotVersion() }
 
-func currentSnapshotVersion() int { return 1 }
+// MigrateSnapshot upgrades s in place to the current version using the registered migrations.
+func MigrateSnapshot(s *Snapshot) error { return maybeMigrateSnapshot(s) }
 
 func maybeMigrateSnapshot(s *Snapshot) error {
 	v := s.Version
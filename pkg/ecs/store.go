@@ -6,6 +6,8 @@ type store[T any] struct {
 	mu    sync.RWMutex
 	data  map[Entity]T
 	index map[Entity]struct{}
+	// changes counts entities joining or leaving the store, see Changes
+	changes uint64
 }
 
 func newStore[T any]() *store[T] {
@@ -17,6 +19,9 @@ func newStore[T any]() *store[T] {
 
 func (s *store[T]) Add(e Entity, c T) {
 	s.mu.Lock()
+	if _, ok := s.index[e]; !ok {
+		s.changes++
+	}
 	s.data[e] = c
 	s.index[e] = struct{}{}
 	s.mu.Unlock()
@@ -31,6 +36,9 @@ func (s *store[T]) Get(e Entity) (T, bool) {
 
 func (s *store[T]) Remove(e Entity) {
 	s.mu.Lock()
+	if _, ok := s.index[e]; ok {
+		s.changes++
+	}
 	delete(s.data, e)
 	delete(s.index, e)
 	s.mu.Unlock()
@@ -0,0 +1,77 @@
+package ecs
+
+import (
+	"fmt"
+	"sort"
+)
+
+// SnapshotIssue describes a consistency problem found in a snapshot.
+type SnapshotIssue struct {
+	Component string
+	Entity    Entity
+	Problem   string
+}
+
+func (i SnapshotIssue) String() string {
+	switch {
+	case i.Component == "":
+		return fmt.Sprintf("entity %d: %s", i.Entity, i.Problem)
+	case i.Entity == 0:
+		return fmt.Sprintf("%s: %s", i.Component, i.Problem)
+	}
+	return fmt.Sprintf("%s[%d]: %s", i.Component, i.Entity, i.Problem)
+}
+
+// ValidateSnapshot checks that every entity in the allocator's free list and
+// the component tables' keys is one the allocator could have handed out and has
+// not freed. It does not decode payloads, so entities a component refers to
+// from inside its data are not checked. Issues are sorted by component then
+// entity so output is stable.
+func ValidateSnapshot(s *Snapshot) []SnapshotIssue {
+	var issues []SnapshotIssue
+	if s.Version > currentSnapshotVersion() {
+		issues = append(issues, SnapshotIssue{Problem: fmt.Sprintf("snapshot version %d is newer than supported version %d", s.Version, currentSnapshotVersion())})
+	}
+
+	free := make(map[Entity]bool, len(s.Free))
+	for _, e := range s.Free {
+		switch {
+		case e == 0 || e > s.Next:
+			issues = append(issues, SnapshotIssue{Entity: e, Problem: fmt.Sprintf("free list entry outside allocated range 1..%d", s.Next)})
+		case free[e]:
+			issues = append(issues, SnapshotIssue{Entity: e, Problem: "listed twice in free list"})
+		}
+		free[e] = true
+	}
+
+	known := make(map[string]bool, len(persisted))
+	for _, name := range PersistedComponents() {
+		known[name] = true
+	}
+	for name, data := range s.Components {
+		if !known[name] {
+			issues = append(issues, SnapshotIssue{Component: name, Problem: "unknown component type; it will be ignored on load"})
+		}
+		for e, raw := range data {
+			switch {
+			case e == 0 || e > s.Next:
+				issues = append(issues, SnapshotIssue{Component: name, Entity: e, Problem: fmt.Sprintf("entity outside allocated range 1..%d", s.Next)})
+			case free[e]:
+				issues = append(issues, SnapshotIssue{Component: name, Entity: e, Problem: "component attached to a freed entity"})
+			case len(raw) == 0:
+				issues = append(issues, SnapshotIssue{Component: name, Entity: e, Problem: "empty component payload"})
+			}
+		}
+	}
+
+	sort.Slice(issues, func(i, j int) bool {
+		if issues[i].Component != issues[j].Component {
+			return issues[i].Component < issues[j].Component
+		}
+		if issues[i].Entity != issues[j].Entity {
+			return issues[i].Entity < issues[j].Entity
+		}
+		return issues[i].Problem < issues[j].Problem
+	})
+	return issues
+}
@@ -12,19 +12,65 @@ type World struct {
 	free   []Entity
 	stores map[reflect.Type]any
 	rng    *rand.Rand
+	src    *countingSource
 	seed   int64
 	saveMu sync.Mutex
+
+	events   []Event
+	eventSeq uint64
+
+	ctxEntity Entity // holder of the WorldContext, see ensureContextEntity
 }
 
+// NewWorld creates an empty world seeded from r: the world seed, which picks
+// the universe and everything generated from it, is drawn from r, and the
+// world RNG starts from that seed as it would after a Load. A nil r gives
+// seed 1.
 func NewWorld(r *rand.Rand) *World {
-	if r == nil {
-		r = rand.New(rand.NewSource(1))
+	seed := int64(1)
+	if r != nil {
+		seed = r.Int63()
 	}
-	return &World{stores: make(map[reflect.Type]any), rng: r, seed: 1}
+	w := &World{stores: make(map[reflect.Type]any)}
+	w.reseed(seed, 0)
+	return w
+}
+
+// reseed restarts the world RNG from seed and fast-forwards it past draws
+// values, putting it back where a saved game left it.
+func (w *World) reseed(seed int64, draws uint64) {
+	w.seed = seed
+	w.src = &countingSource{src: rand.NewSource(seed).(rand.Source64)}
+	for w.src.n < draws {
+		w.src.Int63()
+	}
+	w.rng = rand.New(w.src)
+}
+
+// countingSource counts the values drawn from src so a save can record how
+// far along its sequence the world RNG is.
+type countingSource struct {
+	src rand.Source64
+	n   uint64
+}
+
+func (c *countingSource) Int63() int64   { c.n++; return c.src.Int63() }
+func (c *countingSource) Uint64() uint64 { c.n++; return c.src.Uint64() }
+func (c *countingSource) Seed(seed int64) {
+	c.src.Seed(seed)
+	c.n = 0
 }
 
 func RandFromSeed(seed int64) *rand.Rand { return rand.New(rand.NewSource(seed)) }
 
+// Rand returns the world RNG. Saves record how far along its seed's sequence
+// it is and Load puts it back there, so reloading a game rolls the same dice
+// that would have come next rather than rolling again.
+func (w *World) Rand() *rand.Rand { return w.rng }
+
+// Seed returns the seed the world RNG was last initialised from.
+func (w *World) Seed() int64 { return w.seed }
+
 func (w *World) Create() Entity {
 	w.mu.Lock()
 	defer w.mu.Unlock()
@@ -67,16 +113,20 @@ func Add[T any](w *World, e Entity, c T)      { storeOf[T](w).Add(e, c) }
 func Get[T any](w *World, e Entity) (T, bool) { return storeOf[T](w).Get(e) }
 func Remove[T any](w *World, e Entity)        { storeOf[T](w).Remove(e) }
 
+// Changes returns a counter that goes up whenever an entity gains or loses a
+// T, so a cache built from the T entities can tell it is stale without
+// walking them. Replacing a T already held does not count.
+func Changes[T any](w *World) uint64 {
+	st := storeOf[T](w)
+	st.mu.RLock()
+	defer st.mu.RUnlock()
+	return st.changes
+}
+
 func removeFromStore(st any, e Entity) {
-	switch s := st.(type) {
-	case *store[int]:
+	// every store[T] has Remove; asserting on it avoids reflecting per call,
+	// which made clearing a level's terrain slow
+	if s, ok := st.(interface{ Remove(Entity) }); ok {
 		s.Remove(e)
-	default:
-		// Using reflection to call Remove on generic store
-		rv := reflect.ValueOf(st)
-		m := rv.MethodByName("Remove")
-		if m.IsValid() {
-			m.Call([]reflect.Value{reflect.ValueOf(e)})
-		}
 	}
 }
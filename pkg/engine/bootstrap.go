@@ -27,19 +27,28 @@ func New(r *rand.Rand) Bootstrap {
 	ecs.Add(w, p, components.Input{})
 	ecs.Add(w, p, components.Velocity{})
 	ecs.Add(w, p, components.Acceleration{})
-	ecs.Add(w, p, components.FuelTank{Current: 100})
 	ecs.Add(w, p, components.Orientation{})
 	ecs.Add(w, p, components.Thrust{})
 	ecs.Add(w, p, components.SpaceFlightSprings{})
 	ecs.Add(w, p, components.PulseSpring{Target: 1})
+	ecs.Add(w, p, components.Health{HP: 30, Max: 30})
+	ecs.Add(w, p, components.Melee{Accuracy: 75, MinDamage: 1, MaxDamage: 4, Cooldown: 0.5})
+	ecs.Add(w, p, systems.Actor{Speed: systems.NormalSpeed, Energy: systems.ActionCost})
 
 	// Create camera system with player as target
 	camera := &systems.CameraSystem{Target: p}
 
 	reg := ecs.SystemRegistry{
-		UniversalSystems: []ecs.System{systems.InputSystem{}, &systems.PulseSystem{}, systems.Tick{}, camera, systems.LevelManager{}, render},
-		SpaceSystems:     []ecs.System{systems.SpaceMovement{}, systems.FuelSystem{}, systems.PlanetApproachSystem{}, systems.PlanetSelection{}},
-		SurfaceSystems:   []ecs.System{systems.SurfaceHeartbeat{}, systems.TerrainGen{}, systems.SurfaceMovement{}, systems.DepthProgression{}, systems.WeatherTick{}, systems.RiverFlow{}, systems.TradeRoutePatrols{}, systems.WildlifeSpawn{}, systems.KingdomGuards{}, systems.QuestSystem{}},
+		UniversalSystems: []ecs.System{systems.InputSystem{}, &systems.PulseSystem{}, systems.Tick{}, camera, systems.LevelManager{}, systems.Upgrades{}, systems.PlayerDeath{}, render},
+		SpaceSystems:     []ecs.System{systems.SectorStreaming{}, systems.CometDrift{}, systems.SpaceMovement{}, systems.WarpDrive{}, systems.FuelSystem{}, systems.GalaxyHarvest{}, systems.Docking{}, systems.PlanetApproachSystem{}, systems.Stranding{}},
+		SurfaceSystems: []ecs.System{systems.SurfaceHeartbeat{}, systems.Stairways{}, systems.TerrainGen{}, systems.Launch{}, systems.AutoTravelSystem{}, systems.TurnScheduler{
+			Systems:      []ecs.System{systems.SurfaceMovement{}, systems.Harvest{}, systems.Items{}, systems.Crafting{}, systems.WeatherTick{}, systems.FireSpread{}, systems.RiverFlow{}, systems.TradeRoutePatrols{}, systems.WildlifeSpawn{}, systems.KingdomGuards{}, systems.Vision{}, systems.CreatureAI{}, systems.IntentMovement{}, systems.Combat{}, &systems.DecaySystem{}, systems.BiomeHazards{}, systems.QuestSystem{}},
+			ActorSystems: []ecs.System{systems.Vision{}, systems.CreatureAI{}, systems.IntentMovement{}, systems.Combat{}},
+		}, systems.Vision{}},
+		DeepSystems: []ecs.System{systems.Stairways{}, systems.TerrainGen{}, systems.Launch{}, systems.AutoTravelSystem{}, systems.TurnScheduler{
+			Systems:      []ecs.System{systems.SurfaceMovement{}, systems.Harvest{}, systems.Items{}, systems.Crafting{}, systems.Vision{}, systems.CreatureAI{}, systems.IntentMovement{}, systems.Combat{}, &systems.DecaySystem{}, systems.BiomeHazards{}, systems.QuestSystem{}},
+			ActorSystems: []ecs.System{systems.Vision{}, systems.CreatureAI{}, systems.IntentMovement{}, systems.Combat{}},
+		}, systems.Vision{}},
 	}
 	s := ecs.NewSchedulerWithContext(reg)
 	ecs.Add(w, 1, components.WorldInfo{Width: 200, Height: 80})
@@ -0,0 +1,95 @@
+// Package fov computes fields of view over a tile grid using recursive
+// shadowcasting. It knows nothing about the ECS; callers describe the map
+// through an Opaque function.
+package fov
+
+// Opaque reports whether the tile at (x, y) blocks sight. Opaque tiles are
+// themselves visible; they only hide what lies behind them.
+type Opaque func(x, y int) bool
+
+// octants maps shadowcasting row/column offsets onto the eight octants
+// around the origin: dx = col*xx + row*xy, dy = col*yx + row*yy.
+var octants = [8][4]int{
+	{1, 0, 0, 1},
+	{0, 1, 1, 0},
+	{0, -1, 1, 0},
+	{-1, 0, 0, 1},
+	{-1, 0, 0, -1},
+	{0, -1, -1, 0},
+	{0, 1, -1, 0},
+	{1, 0, 0, -1},
+}
+
+// Compute calls visit for every tile visible from (ox, oy) within radius,
+// including the origin. Tiles on octant boundaries may be visited twice.
+func Compute(ox, oy, radius int, opaque Opaque, visit func(x, y int)) {
+	if radius < 0 {
+		return
+	}
+	visit(ox, oy)
+	for _, o := range octants {
+		castLight(ox, oy, radius, 1, 1.0, 0.0, o[0], o[1], o[2], o[3], opaque, visit)
+	}
+}
+
+// CanSee reports whether (tx, ty) is visible from (ox, oy) within radius.
+func CanSee(ox, oy, tx, ty, radius int, opaque Opaque) bool {
+	dx, dy := tx-ox, ty-oy
+	if dx*dx+dy*dy > radius*radius {
+		return false
+	}
+	seen := false
+	Compute(ox, oy, radius, opaque, func(x, y int) {
+		if x == tx && y == ty {
+			seen = true
+		}
+	})
+	return seen
+}
+
+// castLight scans one octant row by row, recursing whenever a run of opaque
+// tiles splits the light into a narrower cone.
+func castLight(ox, oy, radius, row int, start, end float64, xx, xy, yx, yy int, opaque Opaque, visit func(x, y int)) {
+	if start < end {
+		return
+	}
+	r2 := radius * radius
+	for j := row; j <= radius; j++ {
+		dx, dy := -j-1, -j
+		blocked := false
+		newStart := start
+		for dx <= 0 {
+			dx++
+			lSlope := (float64(dx) - 0.5) / (float64(dy) + 0.5)
+			rSlope := (float64(dx) + 0.5) / (float64(dy) - 0.5)
+			if start < rSlope {
+				continue
+			}
+			if end > lSlope {
+				break
+			}
+			x := ox + dx*xx + dy*xy
+			y := oy + dx*yx + dy*yy
+			if dx*dx+dy*dy <= r2 {
+				visit(x, y)
+			}
+			if blocked {
+				if opaque(x, y) {
+					newStart = rSlope
+					continue
+				}
+				blocked = false
+				start = newStart
+				continue
+			}
+			if opaque(x, y) && j < radius {
+				blocked = true
+				castLight(ox, oy, radius, j+1, start, lSlope, xx, xy, yx, yy, opaque, visit)
+				newStart = rSlope
+			}
+		}
+		if blocked {
+			return
+		}
+	}
+}
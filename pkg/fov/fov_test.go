@@ -0,0 +1,51 @@
+package fov
+
+import "testing"
+
+func walls(pts ...[2]int) Opaque {
+	set := map[[2]int]bool{}
+	for _, p := range pts {
+		set[p] = true
+	}
+	return func(x, y int) bool { return set[[2]int{x, y}] }
+}
+
+func TestOpenFieldIsCircular(t *testing.T) {
+	seen := map[[2]int]bool{}
+	Compute(0, 0, 3, walls(), func(x, y int) { seen[[2]int{x, y}] = true })
+	for _, p := range [][2]int{{0, 0}, {3, 0}, {0, -3}, {2, 2}, {-2, -2}} {
+		if !seen[p] {
+			t.Errorf("%v should be visible", p)
+		}
+	}
+	if seen[[2]int{3, 3}] || seen[[2]int{4, 0}] {
+		t.Error("tiles beyond the radius should be hidden")
+	}
+}
+
+func TestWallCastsShadow(t *testing.T) {
+	op := walls([2]int{2, 0})
+	if !CanSee(0, 0, 2, 0, 8, op) {
+		t.Error("the wall itself should be visible")
+	}
+	if CanSee(0, 0, 5, 0, 8, op) {
+		t.Error("tile directly behind the wall should be hidden")
+	}
+	if !CanSee(0, 0, 5, 3, 8, op) {
+		t.Error("tile off to the side should still be visible")
+	}
+}
+
+func TestSymmetricCorridor(t *testing.T) {
+	var row [][2]int
+	for x := -10; x <= 10; x++ {
+		row = append(row, [2]int{x, -1}, [2]int{x, 1})
+	}
+	op := walls(row...)
+	if !CanSee(0, 0, 7, 0, 8, op) || !CanSee(0, 0, -7, 0, 8, op) {
+		t.Error("a straight corridor should be visible both ways")
+	}
+	if CanSee(0, 0, 4, 3, 8, op) {
+		t.Error("nothing beyond the corridor walls should be visible")
+	}
+}
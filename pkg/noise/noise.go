@@ -0,0 +1,66 @@
+// Package noise generates seeded, smoothly varying 2D noise for terrain.
+// It is fractal value noise: random values on an integer lattice, blended
+// with a smoothstep and summed over octaves of rising frequency.
+package noise
+
+import "math"
+
+// Field is a fractal noise field. The zero Octaves is treated as one.
+type Field struct {
+	Seed int64
+	// Scale is the size in tiles of the coarsest features.
+	Scale float64
+	// Octaves is how many layers of finer detail are summed.
+	Octaves int
+	// Persistence is how much each octave contributes relative to the one
+	// before it.
+	Persistence float64
+}
+
+// New returns a field with the usual half persistence.
+func New(seed int64, scale float64, octaves int) Field {
+	return Field{Seed: seed, Scale: scale, Octaves: octaves, Persistence: 0.5}
+}
+
+// At returns the field's value at (x, y), in [0, 1].
+func (f Field) At(x, y float64) float64 {
+	scale := f.Scale
+	if scale <= 0 {
+		scale = 1
+	}
+	octaves := max(f.Octaves, 1)
+	var sum, norm float64
+	amp, freq := 1.0, 1/scale
+	for o := 0; o < octaves; o++ {
+		sum += amp * value(f.Seed+int64(o)*0x5DEECE66D, x*freq, y*freq)
+		norm += amp
+		amp *= f.Persistence
+		freq *= 2
+	}
+	return sum / norm
+}
+
+// value is single-octave value noise in [0, 1].
+func value(seed int64, x, y float64) float64 {
+	x0, y0 := math.Floor(x), math.Floor(y)
+	ix, iy := int64(x0), int64(y0)
+	sx, sy := smooth(x-x0), smooth(y-y0)
+	a := lerp(lattice(seed, ix, iy), lattice(seed, ix+1, iy), sx)
+	b := lerp(lattice(seed, ix, iy+1), lattice(seed, ix+1, iy+1), sx)
+	return lerp(a, b, sy)
+}
+
+// lattice hashes a lattice point to a value in [0, 1].
+func lattice(seed, x, y int64) float64 {
+	h := uint64(seed) ^ uint64(x)*0x9E3779B97F4A7C15 ^ uint64(y)*0xC2B2AE3D27D4EB4F
+	h ^= h >> 33
+	h *= 0xFF51AFD7ED558CCD
+	h ^= h >> 33
+	h *= 0xC4CEB9FE1A85EC53
+	h ^= h >> 33
+	return float64(h>>11) / float64(1<<53)
+}
+
+func smooth(t float64) float64 { return t * t * (3 - 2*t) }
+
+func lerp(a, b, t float64) float64 { return a + (b-a)*t }
@@ -0,0 +1,43 @@
+package noise
+
+import (
+	"math"
+	"testing"
+)
+
+func TestFieldIsDeterministicAndBounded(t *testing.T) {
+	f := New(7, 16, 4)
+	for y := 0.0; y < 40; y += 0.7 {
+		for x := 0.0; x < 40; x += 0.9 {
+			v := f.At(x, y)
+			if v < 0 || v > 1 {
+				t.Fatalf("value %f at (%f,%f) out of range", v, x, y)
+			}
+			if v != f.At(x, y) {
+				t.Fatal("the same point should give the same value")
+			}
+		}
+	}
+}
+
+func TestFieldIsSmooth(t *testing.T) {
+	f := New(3, 12, 1)
+	for x := 0.0; x < 50; x += 0.25 {
+		if d := math.Abs(f.At(x+0.25, 5) - f.At(x, 5)); d > 0.2 {
+			t.Fatalf("noise jumps by %f between neighbours at x=%f", d, x)
+		}
+	}
+}
+
+func TestSeedsDiffer(t *testing.T) {
+	a, b := New(1, 8, 3), New(2, 8, 3)
+	same := 0
+	for x := 0.0; x < 20; x++ {
+		if a.At(x, 3.5) == b.At(x, 3.5) {
+			same++
+		}
+	}
+	if same > 2 {
+		t.Fatalf("different seeds should give different fields, %d equal samples", same)
+	}
+}
@@ -0,0 +1,94 @@
+package pathfind
+
+import "container/heap"
+
+// AStar returns the cheapest path from start to goal, excluding start and
+// including goal. ok is false when goal cannot be reached.
+func AStar(g Grid, start, goal Point) (path []Point, ok bool) {
+	if !inBounds(g, start.X, start.Y) || !passable(g, goal.X, goal.Y) {
+		return nil, false
+	}
+	if start == goal {
+		return nil, true
+	}
+	w := g.Width()
+	idx := func(p Point) int { return p.Y*w + p.X }
+	size := w * g.Height()
+	cost := make([]float64, size)
+	from := make([]int, size)
+	for i := range cost {
+		cost[i] = Impassable
+		from[i] = -1
+	}
+	cost[idx(start)] = 0
+	open := &nodeHeap{{p: start, f: float64(manhattan(start, goal))}}
+	for open.Len() > 0 {
+		cur := heap.Pop(open).(node)
+		if cur.p == goal {
+			break
+		}
+		if cur.g > cost[idx(cur.p)] {
+			continue // stale entry
+		}
+		for _, d := range neighbours {
+			n := Point{cur.p.X + d.X, cur.p.Y + d.Y}
+			if !passable(g, n.X, n.Y) {
+				continue
+			}
+			ng := cur.g + g.Cost(n.X, n.Y)
+			if ng < cost[idx(n)] {
+				cost[idx(n)] = ng
+				from[idx(n)] = idx(cur.p)
+				heap.Push(open, node{p: n, g: ng, f: ng + float64(manhattan(n, goal))})
+			}
+		}
+	}
+	if from[idx(goal)] < 0 {
+		return nil, false
+	}
+	for i := idx(goal); i != idx(start); i = from[i] {
+		path = append(path, Point{i % w, i / w})
+	}
+	for l, r := 0, len(path)-1; l < r; l, r = l+1, r-1 {
+		path[l], path[r] = path[r], path[l]
+	}
+	return path, true
+}
+
+func manhattan(a, b Point) int {
+	dx, dy := a.X-b.X, a.Y-b.Y
+	if dx < 0 {
+		dx = -dx
+	}
+	if dy < 0 {
+		dy = -dy
+	}
+	return dx + dy
+}
+
+type node struct {
+	p    Point
+	g, f float64
+}
+
+// nodeHeap orders by f, breaking ties on position so results are stable.
+type nodeHeap []node
+
+func (h nodeHeap) Len() int { return len(h) }
+func (h nodeHeap) Less(i, j int) bool {
+	if h[i].f != h[j].f {
+		return h[i].f < h[j].f
+	}
+	if h[i].p.Y != h[j].p.Y {
+		return h[i].p.Y < h[j].p.Y
+	}
+	return h[i].p.X < h[j].p.X
+}
+func (h nodeHeap) Swap(i, j int) { h[i], h[j] = h[j], h[i] }
+func (h *nodeHeap) Push(x any)   { *h = append(*h, x.(node)) }
+func (h *nodeHeap) Pop() any {
+	old := *h
+	n := old[len(old)-1]
+	*h = old[:len(old)-1]
+	return n
+}
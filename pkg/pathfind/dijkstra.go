@@ -0,0 +1,139 @@
+package pathfind
+
+import (
+	"container/heap"
+	"sync"
+)
+
+// DijkstraMap holds the cost to reach the nearest goal from every tile. Any
+// number of agents can follow it downhill toward the goals, or uphill to flee.
+type DijkstraMap struct {
+	w, h int
+	dist []float64
+}
+
+// NewDijkstraMap floods g outward from goals.
+func NewDijkstraMap(g Grid, goals ...Point) *DijkstraMap {
+	m := &DijkstraMap{w: g.Width(), h: g.Height()}
+	m.dist = make([]float64, m.w*m.h)
+	for i := range m.dist {
+		m.dist[i] = Impassable
+	}
+	open := &nodeHeap{}
+	for _, p := range goals {
+		if passable(g, p.X, p.Y) {
+			m.dist[p.Y*m.w+p.X] = 0
+			heap.Push(open, node{p: p})
+		}
+	}
+	for open.Len() > 0 {
+		cur := heap.Pop(open).(node)
+		if cur.f > m.dist[cur.p.Y*m.w+cur.p.X] {
+			continue
+		}
+		for _, d := range neighbours {
+			n := Point{cur.p.X + d.X, cur.p.Y + d.Y}
+			if !passable(g, n.X, n.Y) {
+				continue
+			}
+			// moving from n to cur costs cur's entry cost
+			nd := cur.f + g.Cost(cur.p.X, cur.p.Y)
+			if nd < m.dist[n.Y*m.w+n.X] {
+				m.dist[n.Y*m.w+n.X] = nd
+				heap.Push(open, node{p: n, f: nd})
+			}
+		}
+	}
+	return m
+}
+
+// Distance returns the cost from (x, y) to the nearest goal; Impassable when
+// unreachable or off the map.
+func (m *DijkstraMap) Distance(x, y int) float64 {
+	if x < 0 || y < 0 || x >= m.w || y >= m.h {
+		return Impassable
+	}
+	return m.dist[y*m.w+x]
+}
+
+// Toward returns the step from (x, y) that most reduces the distance to a
+// goal. ok is false at a goal or when no neighbour is closer.
+func (m *DijkstraMap) Toward(x, y int) (dx, dy int, ok bool) {
+	best := m.Distance(x, y)
+	for _, d := range neighbours {
+		if v := m.Distance(x+d.X, y+d.Y); v < best {
+			best, dx, dy, ok = v, d.X, d.Y, true
+		}
+	}
+	return dx, dy, ok
+}
+
+// Away returns the reachable step from (x, y) that most increases the
+// distance to the goals. ok is false when cornered.
+func (m *DijkstraMap) Away(x, y int) (dx, dy int, ok bool) {
+	best := m.Distance(x, y)
+	for _, d := range neighbours {
+		v := m.Distance(x+d.X, y+d.Y)
+		if v != Impassable && v > best {
+			best, dx, dy, ok = v, d.X, d.Y, true
+		}
+	}
+	return dx, dy, ok
+}
+
+// FlowCache keeps recently used Dijkstra maps keyed by goal and grid version,
+// so agents chasing the same target share one flood fill. Bump the version
+// whenever the grid changes.
+type FlowCache struct {
+	mu      sync.Mutex
+	limit   int
+	entries map[flowKey]*DijkstraMap
+	order   []flowKey
+}
+
+type flowKey struct {
+	goal    Point
+	version uint64
+}
+
+// NewFlowCache returns a cache holding at most limit maps.
+func NewFlowCache(limit int) *FlowCache {
+	return &FlowCache{limit: max(limit, 1), entries: make(map[flowKey]*DijkstraMap)}
+}
+
+// Get returns the map toward goal for version of g, building it on a miss and
+// evicting the least recently used map when full.
+func (c *FlowCache) Get(g Grid, version uint64, goal Point) *DijkstraMap {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	k := flowKey{goal, version}
+	if m, ok := c.entries[k]; ok {
+		c.touch(k)
+		return m
+	}
+	m := NewDijkstraMap(g, goal)
+	c.entries[k] = m
+	c.order = append(c.order, k)
+	for len(c.order) > c.limit {
+		delete(c.entries, c.order[0])
+		c.order = c.order[1:]
+	}
+	return m
+}
+
+// Len reports how many maps are cached.
+func (c *FlowCache) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return len(c.entries)
+}
+
+func (c *FlowCache) touch(k flowKey) {
+	for i, o := range c.order {
+		if o == k {
+			copy(c.order[i:], c.order[i+1:])
+			c.order[len(c.order)-1] = k
+			return
+		}
+	}
+}
@@ -0,0 +1,73 @@
+// Package pathfind finds routes over a weighted tile grid: A* for single
+// journeys and Dijkstra maps (flow fields) for many agents sharing a goal.
+// Movement is 4-directional to match player and creature movement.
+package pathfind
+
+import "math"
+
+// Impassable marks a tile that cannot be entered.
+var Impassable = math.Inf(1)
+
+// Point is a tile coordinate.
+type Point struct{ X, Y int }
+
+// Grid reports the cost of entering each tile. Costs are at least 1;
+// Impassable tiles are never entered.
+type Grid interface {
+	Width() int
+	Height() int
+	Cost(x, y int) float64
+}
+
+// CostGrid is a dense Grid. Tiles default to cost 1.
+type CostGrid struct {
+	w, h  int
+	costs []float64
+}
+
+// NewCostGrid returns a w×h grid with every tile costing 1.
+func NewCostGrid(w, h int) *CostGrid {
+	g := &CostGrid{w: max(w, 0), h: max(h, 0)}
+	g.costs = make([]float64, g.w*g.h)
+	for i := range g.costs {
+		g.costs[i] = 1
+	}
+	return g
+}
+
+func (g *CostGrid) Width() int  { return g.w }
+func (g *CostGrid) Height() int { return g.h }
+
+// Cost returns the cost of entering (x, y); tiles off the grid are Impassable.
+func (g *CostGrid) Cost(x, y int) float64 {
+	if !inBounds(g, x, y) {
+		return Impassable
+	}
+	return g.costs[y*g.w+x]
+}
+
+// Set sets the cost of entering (x, y). Out-of-range tiles are ignored.
+func (g *CostGrid) Set(x, y int, cost float64) {
+	if inBounds(g, x, y) {
+		g.costs[y*g.w+x] = cost
+	}
+}
+
+// Scale multiplies the cost of every passable tile by f.
+func (g *CostGrid) Scale(f float64) {
+	for i, c := range g.costs {
+		if !math.IsInf(c, 1) {
+			g.costs[i] = c * f
+		}
+	}
+}
+
+var neighbours = [4]Point{{1, 0}, {-1, 0}, {0, 1}, {0, -1}}
+
+func inBounds(g Grid, x, y int) bool {
+	return x >= 0 && y >= 0 && x < g.Width() && y < g.Height()
+}
+
+func passable(g Grid, x, y int) bool {
+	return inBounds(g, x, y) && !math.IsInf(g.Cost(x, y), 1)
+}
@@ -0,0 +1,96 @@
+package pathfind
+
+import "testing"
+
+// wallGrid is 10x5 with a vertical wall at x=5 open only at y=4.
+func wallGrid() *CostGrid {
+	g := NewCostGrid(10, 5)
+	for y := 0; y < 4; y++ {
+		g.Set(5, y, Impassable)
+	}
+	return g
+}
+
+func TestAStarRoutesAroundWalls(t *testing.T) {
+	path, ok := AStar(wallGrid(), Point{0, 0}, Point{9, 0})
+	if !ok {
+		t.Fatal("expected a path through the gap")
+	}
+	if path[len(path)-1] != (Point{9, 0}) {
+		t.Fatalf("path should end at goal, got %v", path[len(path)-1])
+	}
+	through := false
+	for _, p := range path {
+		if p.X == 5 && p.Y != 4 {
+			t.Fatalf("path crosses the wall at %v", p)
+		}
+		if p == (Point{5, 4}) {
+			through = true
+		}
+	}
+	if !through || len(path) != 17 {
+		t.Errorf("expected the 17-step route via (5,4), got %d steps", len(path))
+	}
+}
+
+func TestAStarPrefersCheapTiles(t *testing.T) {
+	g := NewCostGrid(5, 3)
+	for x := 1; x < 4; x++ {
+		g.Set(x, 1, 4) // a river across the direct row
+	}
+	path, _ := AStar(g, Point{0, 1}, Point{4, 1})
+	for _, p := range path {
+		if p.Y == 1 && p.X > 0 && p.X < 4 {
+			t.Fatalf("path should detour around costly tiles, used %v", p)
+		}
+	}
+}
+
+func TestAStarUnreachable(t *testing.T) {
+	g := wallGrid()
+	g.Set(5, 4, Impassable)
+	if _, ok := AStar(g, Point{0, 0}, Point{9, 0}); ok {
+		t.Fatal("sealed wall should make goal unreachable")
+	}
+}
+
+func TestDijkstraMapMatchesAStar(t *testing.T) {
+	g := wallGrid()
+	goal := Point{9, 0}
+	m := NewDijkstraMap(g, goal)
+	path, _ := AStar(g, Point{0, 0}, goal)
+	if int(m.Distance(0, 0)) != len(path) {
+		t.Fatalf("distance %v should equal path length %d", m.Distance(0, 0), len(path))
+	}
+	x, y, steps := 0, 0, 0
+	for (Point{x, y}) != goal && steps < 100 {
+		dx, dy, ok := m.Toward(x, y)
+		if !ok {
+			t.Fatalf("stuck at %d,%d", x, y)
+		}
+		x, y = x+dx, y+dy
+		steps++
+	}
+	if steps != len(path) {
+		t.Errorf("following the flow field took %d steps, want %d", steps, len(path))
+	}
+	if dx, _, ok := m.Away(8, 0); !ok || dx != -1 {
+		t.Errorf("fleeing from the goal should step west, got dx=%d ok=%v", dx, ok)
+	}
+}
+
+func TestFlowCacheReusesAndEvicts(t *testing.T) {
+	g := wallGrid()
+	c := NewFlowCache(2)
+	a := c.Get(g, 1, Point{9, 0})
+	if c.Get(g, 1, Point{9, 0}) != a {
+		t.Fatal("same goal and version should hit the cache")
+	}
+	if c.Get(g, 2, Point{9, 0}) == a {
+		t.Fatal("a new grid version should rebuild the map")
+	}
+	c.Get(g, 2, Point{0, 0})
+	if c.Len() != 2 {
+		t.Fatalf("cache should hold at most 2 maps, has %d", c.Len())
+	}
+}
@@ -0,0 +1,303 @@
+package systems
+
+import (
+	"sort"
+
+	"harvester/pkg/components"
+	"harvester/pkg/ecs"
+	"harvester/pkg/pathfind"
+)
+
+// AIState is what a creature is currently trying to do.
+type AIState int
+
+const (
+	AIIdle AIState = iota
+	AIWander
+	AIFlee
+	AIHunt
+	AIGuard
+	AIReturnHome
+)
+
+func (s AIState) String() string {
+	switch s {
+	case AIIdle:
+		return "idle"
+	case AIWander:
+		return "wander"
+	case AIFlee:
+		return "flee"
+	case AIHunt:
+		return "hunt"
+	case AIGuard:
+		return "guard"
+	case AIReturnHome:
+		return "return-home"
+	}
+	return "unknown"
+}
+
+// Brain drives a creature. Perception is the sight radius in tiles; guards
+// (Guard set) stay within Leash tiles of home. Think is the seconds between
+// decisions and Wait the time left until the next one. Steps counts decisions
+// and seeds the creature's rolls, so behaviour replays from a save.
+type Brain struct {
+	State      AIState
+	Target     ecs.Entity
+	HomeX      int
+	HomeY      int
+	Perception int
+	Leash      int
+	Guard      bool
+	Timid      bool
+	Think      float64
+	Wait       float64
+	Steps      uint64
+}
+
+// MoveIntent asks IntentMovement to step an entity by one tile.
+type MoveIntent struct{ DX, DY int }
+
+func init() {
+	ecs.RegisterComponent[Brain]()
+	ecs.RegisterComponent[Wildlife]()
+	ecs.RegisterComponent[Patrol]()
+}
+
+// CreatureAI picks a behaviour for every Brain and turns it into intents.
+// Creatures are visited in entity order and roll from their own seeded
+// stream, so the outcome does not depend on map iteration order.
+type CreatureAI struct{}
+
+func (CreatureAI) Update(dt float64, w *ecs.World) {
+	player, playerPos, hasPlayer := findPlayer(w)
+	var thinkers []ecs.Entity
+	ecs.View1Of[Brain](w).Each(func(e ecs.Entity, _ *Brain) { thinkers = append(thinkers, e) })
+	sort.Slice(thinkers, func(i, j int) bool { return thinkers[i] < thinkers[j] })
+	if len(thinkers) == 0 {
+		return
+	}
+	nav := Navigation(w)
+
+	for _, e := range thinkers {
+		b, ok := ecs.Get[Brain](w, e)
+		if !ok {
+			continue
+		}
+		pos, ok := ecs.Get[components.Position](w, e)
+		if !ok {
+			continue
+		}
+		// Actors spend energy from the turn scheduler; anything else thinks
+		// on a timer.
+		if a, ok := ecs.Get[Actor](w, e); ok {
+			if a.Energy < ActionCost {
+				continue
+			}
+			a.Energy -= ActionCost
+			ecs.Add(w, e, a)
+		} else {
+			b.Wait -= dt
+			if b.Wait > 0 {
+				ecs.Add(w, e, b)
+				continue
+			}
+			b.Wait += b.Think
+		}
+		b.Steps++
+		roll := aiRoll(w.Seed(), e, b.Steps)
+		x, y := int(pos.X), int(pos.Y)
+
+		target, tx, ty, seen := perceiveTarget(w, e, b, x, y, player, playerPos, hasPlayer)
+		b.State = chooseState(w, e, b, x, y, seen, roll)
+		b.Target = 0
+		switch b.State {
+		case AIHunt:
+			b.Target = target
+			if chebyshev(x, y, tx, ty) == 1 && (x == tx || y == ty) {
+				ecs.Add(w, e, AttackIntent{Target: target})
+			} else {
+				dx, dy := stepVia(nav, x, y, pathfind.Point{X: tx, Y: ty})
+				ecs.Add(w, e, MoveIntent{DX: dx, DY: dy})
+			}
+		case AIFlee:
+			dx, dy := fleeVia(nav, x, y, pathfind.Point{X: tx, Y: ty})
+			ecs.Add(w, e, MoveIntent{DX: dx, DY: dy})
+		case AIReturnHome:
+			dx, dy := stepVia(nav, x, y, pathfind.Point{X: b.HomeX, Y: b.HomeY})
+			ecs.Add(w, e, MoveIntent{DX: dx, DY: dy})
+		case AIWander:
+			dx, dy := cardinal(roll >> 8)
+			if b.Guard && chebyshev(x+dx, y+dy, b.HomeX, b.HomeY) > b.Leash {
+				// Patrols route back along the road rather than bouncing
+				// off the leash edge.
+				dx, dy = stepVia(nav, x, y, pathfind.Point{X: b.HomeX, Y: b.HomeY})
+			}
+			ecs.Add(w, e, MoveIntent{DX: dx, DY: dy})
+		}
+		ecs.Add(w, e, b)
+	}
+}
+
+// perceiveTarget finds what e would react to: the player for wildlife, the
+// nearest hostile creature for guards.
+func perceiveTarget(w *ecs.World, e ecs.Entity, b Brain, x, y int, player ecs.Entity, playerPos components.Position, hasPlayer bool) (ecs.Entity, int, int, bool) {
+	// Vision leaves a shadowcast Sight on every Brain; without one (e.g. no
+	// map yet) creatures perceive everything within range.
+	sight, hasSight := ecs.Get[Sight](w, e)
+	inView := func(tx, ty int) bool { return !hasSight || sight.Sees(tx, ty) }
+	if b.Guard {
+		best, bx, by, bestD := ecs.Entity(0), 0, 0, b.Perception+1
+		ecs.View2Of[Wildlife, components.Position](w).Each(func(t ecs.Tuple2[Wildlife, components.Position]) {
+			if !t.A.Hostile || t.E == e || !inView(int(t.B.X), int(t.B.Y)) {
+				return
+			}
+			d := chebyshev(x, y, int(t.B.X), int(t.B.Y))
+			if d < bestD || (d == bestD && t.E < best) {
+				best, bx, by, bestD = t.E, int(t.B.X), int(t.B.Y), d
+			}
+		})
+		return best, bx, by, best != 0
+	}
+	if !hasPlayer {
+		return 0, 0, 0, false
+	}
+	px, py := int(playerPos.X), int(playerPos.Y)
+	return player, px, py, chebyshev(x, y, px, py) <= b.Perception && inView(px, py)
+}
+
+// chooseState applies the behaviour rules in priority order.
+func chooseState(w *ecs.World, e ecs.Entity, b Brain, x, y int, seen bool, roll uint64) AIState {
+	wounded := false
+	if h, ok := ecs.Get[components.Health](w, e); ok && h.Max > 0 && h.HP*3 < h.Max {
+		wounded = true
+	}
+	hostile := b.Guard
+	if wl, ok := ecs.Get[Wildlife](w, e); ok {
+		hostile = wl.Hostile
+	}
+	switch {
+	case b.Guard && chebyshev(x, y, b.HomeX, b.HomeY) > b.Leash:
+		return AIReturnHome
+	case seen && (wounded || (b.Timid && !hostile)):
+		return AIFlee
+	case seen && hostile:
+		return AIHunt
+	case b.State == AIReturnHome && (x != b.HomeX || y != b.HomeY):
+		return AIReturnHome
+	case b.Guard && roll%4 != 0:
+		return AIGuard
+	case roll%3 == 0:
+		return AIIdle
+	}
+	return AIWander
+}
+
+// IntentMovement applies MoveIntents for non-player entities. A step into an
+// occupied tile becomes an attack when the occupant is the mover's target and
+// is otherwise blocked; steps off the map are dropped.
+type IntentMovement struct{}
+
+func (IntentMovement) Update(dt float64, w *ecs.World) {
+	wi, _ := ecs.Get[components.WorldInfo](w, 1)
+	var movers []ecs.Entity
+	ecs.View1Of[MoveIntent](w).Each(func(e ecs.Entity, _ *MoveIntent) { movers = append(movers, e) })
+	sort.Slice(movers, func(i, j int) bool { return movers[i] < movers[j] })
+	for _, e := range movers {
+		mi, _ := ecs.Get[MoveIntent](w, e)
+		ecs.Remove[MoveIntent](w, e)
+		pos, ok := ecs.Get[components.Position](w, e)
+		if !ok || (mi.DX == 0 && mi.DY == 0) {
+			continue
+		}
+		nx, ny := int(pos.X)+mi.DX, int(pos.Y)+mi.DY
+		if wi.Width > 0 && (nx < 0 || ny < 0 || nx >= wi.Width || ny >= wi.Height) {
+			continue
+		}
+		if occupant := occupantAt(w, nx, ny, e); occupant != 0 {
+			if b, ok := ecs.Get[Brain](w, e); ok && b.Target == occupant {
+				ecs.Add(w, e, AttackIntent{Target: occupant})
+			}
+			continue
+		}
+		pos.X, pos.Y = float64(nx), float64(ny)
+		ecs.Add(w, e, pos)
+	}
+}
+
+// occupantAt returns an entity with health on (x, y) other than self, or 0.
+func occupantAt(w *ecs.World, x, y int, self ecs.Entity) ecs.Entity {
+	var found ecs.Entity
+	ecs.View2Of[components.Position, components.Health](w).Each(func(t ecs.Tuple2[components.Position, components.Health]) {
+		if t.E != self && int(t.A.X) == x && int(t.A.Y) == y && (found == 0 || t.E < found) {
+			found = t.E
+		}
+	})
+	return found
+}
+
+func findPlayer(w *ecs.World) (ecs.Entity, components.Position, bool) {
+	var (
+		player ecs.Entity
+		pos    components.Position
+	)
+	ecs.View2Of[components.Player, components.Position](w).Each(func(t ecs.Tuple2[components.Player, components.Position]) {
+		player, pos = t.E, *t.B
+	})
+	return player, pos, player != 0
+}
+
+// stepToward returns a single cardinal step from (x, y) toward (tx, ty),
+// moving along the longer axis first.
+func stepToward(x, y, tx, ty int) (int, int) {
+	dx, dy := tx-x, ty-y
+	if dx == 0 && dy == 0 {
+		return 0, 0
+	}
+	if iabs(dx) >= iabs(dy) {
+		return isign(dx), 0
+	}
+	return 0, isign(dy)
+}
+
+func cardinal(r uint64) (int, int) {
+	switch r % 4 {
+	case 0:
+		return 1, 0
+	case 1:
+		return -1, 0
+	case 2:
+		return 0, 1
+	}
+	return 0, -1
+}
+
+func chebyshev(x0, y0, x1, y1 int) int { return max(iabs(x1-x0), iabs(y1-y0)) }
+
+func iabs(v int) int {
+	if v < 0 {
+		return -v
+	}
+	return v
+}
+
+func isign(v int) int {
+	switch {
+	case v > 0:
+		return 1
+	case v < 0:
+		return -1
+	}
+	return 0
+}
+
+// aiRoll mixes the world seed, entity and decision count with splitmix64 so
+// each creature has an independent, reproducible stream of rolls.
+func aiRoll(seed int64, e ecs.Entity, step uint64) uint64 {
+	z := uint64(seed) ^ uint64(e)*0x9e3779b97f4a7c15 ^ step*0xbf58476d1ce4e5b9
+	z += 0x9e3779b97f4a7c15
+	z = (z ^ (z >> 30)) * 0xbf58476d1ce4e5b9
+	z = (z ^ (z >> 27)) * 0x94d049bb133111eb
+	return z ^ (z >> 31)
+}
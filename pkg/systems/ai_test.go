@@ -0,0 +1,114 @@
+package systems
+
+import (
+	"harvester/pkg/components"
+	"harvester/pkg/ecs"
+	"testing"
+)
+
+func spawnCreature(w *ecs.World, x, y float64, hostile bool) ecs.Entity {
+	e := spawnFoe(w, x, y, 6)
+	ecs.Add(w, e, Wildlife{Hostile: hostile})
+	ecs.Add(w, e, components.Melee{Accuracy: 100, MinDamage: 1, MaxDamage: 1})
+	ecs.Add(w, e, Brain{HomeX: int(x), HomeY: int(y), Perception: 6, Timid: !hostile})
+	return e
+}
+
+func stepAI(w *ecs.World, n int) {
+	for i := 0; i < n; i++ {
+		CreatureAI{}.Update(0.1, w)
+		IntentMovement{}.Update(0.1, w)
+		Combat{}.Update(0.1, w)
+	}
+}
+
+func TestHostileCreatureHuntsAndAttacks(t *testing.T) {
+	w, p := newTestWorld(1)
+	c := spawnCreature(w, 9, 5, true)
+	stepAI(w, 6)
+	if b, _ := ecs.Get[Brain](w, c); b.State != AIHunt {
+		t.Fatalf("expected hunt, got %v", b.State)
+	}
+	if h, _ := ecs.Get[components.Health](w, p); h.HP >= 10 {
+		t.Fatalf("hunter should have reached and hit the player, HP %d", h.HP)
+	}
+	if pos, _ := ecs.Get[components.Position](w, c); pos.X != 6 || pos.Y != 5 {
+		t.Errorf("hunter should stop next to the player, at %v,%v", pos.X, pos.Y)
+	}
+}
+
+func TestTimidCreatureFlees(t *testing.T) {
+	w, _ := newTestWorld(1)
+	c := spawnCreature(w, 7, 5, false)
+	stepAI(w, 3)
+	b, _ := ecs.Get[Brain](w, c)
+	pos, _ := ecs.Get[components.Position](w, c)
+	if b.State != AIFlee || pos.X <= 7 {
+		t.Fatalf("expected creature to flee east, state %v at x=%v", b.State, pos.X)
+	}
+}
+
+func TestGuardReturnsHome(t *testing.T) {
+	w, _ := newTestWorld(1)
+	g := spawnFoe(w, 30, 30, 10)
+	ecs.Add(w, g, Brain{Guard: true, HomeX: 20, HomeY: 30, Leash: 3, Perception: 4})
+	stepAI(w, 1)
+	if b, _ := ecs.Get[Brain](w, g); b.State != AIReturnHome {
+		t.Fatalf("guard outside leash should return home, got %v", b.State)
+	}
+	stepAI(w, 12)
+	if pos, _ := ecs.Get[components.Position](w, g); chebyshev(int(pos.X), int(pos.Y), 20, 30) > 3 {
+		t.Errorf("guard should be back within leash, at %v,%v", pos.X, pos.Y)
+	}
+}
+
+func TestAIIsDeterministic(t *testing.T) {
+	run := func() []components.Position {
+		w, _ := newTestWorld(7)
+		var cs []ecs.Entity
+		for i := 0; i < 5; i++ {
+			cs = append(cs, spawnCreature(w, float64(20+i*3), 20, false))
+		}
+		stepAI(w, 40)
+		var out []components.Position
+		for _, c := range cs {
+			p, _ := ecs.Get[components.Position](w, c)
+			out = append(out, p)
+		}
+		return out
+	}
+	a, b := run(), run()
+	for i := range a {
+		if a[i] != b[i] {
+			t.Fatalf("creature %d diverged: %+v vs %+v", i, a[i], b[i])
+		}
+	}
+}
+
+func TestPatrolsSetOutOnGameTimeAwayFromThePlayer(t *testing.T) {
+	w, _ := newTestWorld(1)
+	ecs.Add(w, 1, components.WorldInfo{Width: 40, Height: 40})
+	patrols := func() []components.Position {
+		var out []components.Position
+		ecs.View2Of[components.Position, Patrol](w).Each(func(t ecs.Tuple2[components.Position, Patrol]) { out = append(out, *t.A) })
+		return out
+	}
+	for i := 0; i < patrolEvery-1; i++ {
+		TradeRoutePatrols{}.Update(1, w)
+	}
+	if n := len(patrols()); n != 0 {
+		t.Fatalf("no patrol should set out before %v seconds, got %d", patrolEvery, n)
+	}
+	for i := 0; i < patrolEvery*(maxPatrols+2); i++ {
+		TradeRoutePatrols{}.Update(1, w)
+	}
+	got := patrols()
+	if len(got) != maxPatrols {
+		t.Fatalf("expected %d patrols, got %d", maxPatrols, len(got))
+	}
+	for _, p := range got {
+		if chebyshev(int(p.X), int(p.Y), 5, 5) < patrolDistance {
+			t.Fatalf("a patrol set out next to the player at %v,%v", p.X, p.Y)
+		}
+	}
+}
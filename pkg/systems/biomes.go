@@ -0,0 +1,81 @@
+package systems
+
+import (
+	"math"
+
+	"harvester/pkg/components"
+	"harvester/pkg/data"
+	"harvester/pkg/ecs"
+)
+
+// EventSurfaceHazard is emitted when a biome hazard strikes the player on a
+// planet, with the hazard id in Subject and the damage in Amount.
+const EventSurfaceHazard = "surface_hazard"
+
+// DepthLayerOf returns the depth layer of the planet landed on that holds
+// the current depth.
+func DepthLayerOf(ctx ecs.WorldContext) data.DepthLayer {
+	// the layer holding a depth does not depend on how deep the planet
+	// goes below it, so clipping the layers at the current depth will do
+	p := data.Planet{ID: ctx.PlanetID, Biome: data.BiomeType(ctx.BiomeType), MaxDepth: max(ctx.Depth, 1)}
+	return data.LayerAt(data.Generator(p.Biome).DepthLayers(p), ctx.Depth)
+}
+
+// hazardReach is how close the player must stand to a hazard's terrain for
+// it to strike.
+const hazardReach = 3
+
+// BiomeHazards strikes the player with the hazards of the depth layer they
+// are in, where they stand near the terrain each comes from: heat by lava,
+// cold by ice, old machinery by ruins. Each hazard rolls once a turn; damage
+// grows with the layer's DifficultyMod and is cut by the Shield upgrade but
+// not by armour.
+type BiomeHazards struct{}
+
+func (BiomeHazards) Update(dt float64, w *ecs.World) {
+	ctx := ecs.GetWorldContext(w)
+	if ctx.CurrentLayer == ecs.LayerSpace || ctx.GameOver {
+		return
+	}
+	player, pos, ok := findPlayer(w)
+	if !ok {
+		return
+	}
+	layer := DepthLayerOf(ctx)
+	if len(layer.Hazards) == 0 {
+		return
+	}
+	near := terrainNear(w, int(pos.X), int(pos.Y), hazardReach)
+	for _, id := range layer.Hazards {
+		hz, ok := data.Hazard(id)
+		if !ok || !near[hz.Near] || w.Rand().Float64() >= hz.Chance {
+			continue
+		}
+		h, ok := ecs.Get[components.Health](w, player)
+		if !ok {
+			return
+		}
+		dmg := Shielded(w, player, int(math.Round(float64(hz.Damage)*layer.DifficultyMod)))
+		if dmg == 0 {
+			continue
+		}
+		h.HP = max(0, h.HP-dmg)
+		ecs.Add(w, player, h)
+		itemEvent(w, ecs.Event{Kind: EventSurfaceHazard, Source: player, Subject: id, Amount: dmg}, "%s You take %d damage.", hz.Text, dmg)
+		if h.HP <= 0 {
+			EndRun(w, "killed by "+hz.Name)
+			return
+		}
+	}
+}
+
+// terrainNear returns the kinds of tile within reach of (x, y).
+func terrainNear(w *ecs.World, x, y, reach int) map[components.TileType]bool {
+	near := map[components.TileType]bool{}
+	ecs.View2Of[components.Tile, components.Position](w).Each(func(t ecs.Tuple2[components.Tile, components.Position]) {
+		if chebyshev(x, y, int(t.B.X), int(t.B.Y)) <= reach {
+			near[t.A.Type] = true
+		}
+	})
+	return near
+}
@@ -0,0 +1,163 @@
+package systems
+
+import (
+	"testing"
+
+	"harvester/pkg/components"
+	"harvester/pkg/data"
+	"harvester/pkg/ecs"
+)
+
+func TestBiomeGeneratorsBuildTheirOwnWorlds(t *testing.T) {
+	for _, biome := range []data.BiomeType{data.BiomeVolcanic, data.BiomeIce, data.BiomeAncientRuins} {
+		g := data.Generator(biome)
+		p := g.Generate(1001, 42)
+		if p.Biome != biome || p.Name == "" || p.MaxDepth <= 0 {
+			t.Fatalf("%s: bad planet %+v", g.Name(), p)
+		}
+		if again := g.Generate(1001, 42); again != p {
+			t.Fatalf("%s: the same seed should give the same planet", g.Name())
+		}
+		layers := g.DepthLayers(p)
+		if len(layers) == 0 || layers[0].MinDepth != 0 || layers[len(layers)-1].MaxDepth != p.MaxDepth {
+			t.Fatalf("%s: layers should run from the surface to the bottom: %+v", g.Name(), layers)
+		}
+		for _, l := range layers {
+			if len(l.UniqueFeatures) == 0 || len(l.Hazards) == 0 {
+				t.Fatalf("%s: every layer should have features and hazards: %+v", g.Name(), l)
+			}
+			for _, id := range l.Hazards {
+				if _, ok := data.Hazard(id); !ok {
+					t.Fatalf("%s: unknown hazard %q", g.Name(), id)
+				}
+			}
+		}
+		escape := false
+		for _, q := range data.QuestsFor(p.ID, biome) {
+			escape = escape || q.EscapeReward
+		}
+		if !escape {
+			t.Fatalf("%s: planets need an escape quest", g.Name())
+		}
+	}
+}
+
+func TestRandomPlanetsRollEveryWildBiome(t *testing.T) {
+	seen := map[data.BiomeType]bool{}
+	for seed := int64(0); seed < 30; seed++ {
+		seen[data.RandomPlanet(1000, seed).Biome] = true
+	}
+	if len(seen) != 3 || seen[data.BiomeToftForest] {
+		t.Fatalf("expected volcanic, ice and ruin worlds, got %v", seen)
+	}
+}
+
+func landOn(t *testing.T, biome data.BiomeType) (*ecs.World, ecs.Entity) {
+	t.Helper()
+	w, p := newTestWorld(1)
+	ecs.Add(w, 1, components.WorldInfo{Width: 200, Height: 80})
+	ctx := ecs.GetWorldContext(w)
+	ctx.PlanetID = 1234
+	ctx.BiomeType = int(biome)
+	ecs.SetWorldContext(w, ctx)
+	QuestSystem{}.Update(0, w)
+	return w, p
+}
+
+func TestLandingHandsOutTheBiomesQuests(t *testing.T) {
+	w, p := landOn(t, data.BiomeVolcanic)
+	if _, ok := activeQuest(w, p, "forge_heat_shield"); !ok {
+		t.Fatal("a volcanic world should offer the heat shield quest")
+	}
+	piles := 0
+	ecs.View1Of[components.Resource](w).Each(func(_ ecs.Entity, r *components.Resource) {
+		if r.Kind == "obsidian" {
+			piles++
+		}
+	})
+	if piles != 10 {
+		t.Fatalf("expected ten piles of volcanic glass to collect, got %d", piles)
+	}
+	if len(sortedWith[components.Workstation](w)) == 0 {
+		t.Fatal("the forge for the heat shield should be set up")
+	}
+
+	Launch{}.Update(0, w)
+	ecs.Add(w, p, EnterPlanet{})
+	Launch{}.Update(0, w)
+	if ecs.GetWorldContext(w).CurrentLayer == ecs.LayerSpace {
+		t.Fatal("the ship should be held until the escape quest is done")
+	}
+}
+
+func TestBiomeHazardsStrikeByDepth(t *testing.T) {
+	w, p := landOn(t, data.BiomeIce)
+	ctx := ecs.GetWorldContext(w)
+	ctx.Depth = 80
+	ecs.SetWorldContext(w, ctx)
+	if l := DepthLayerOf(ctx); l.MinDepth != 51 {
+		t.Fatalf("depth 80 should be in the deep layer, got %+v", l)
+	}
+	ecs.Add(w, p, components.Health{HP: 1000, Max: 1000})
+	ecs.Add(w, p, components.Position{X: -50, Y: -50})
+	for i := 0; i < 500; i++ {
+		BiomeHazards{}.Update(1, w)
+	}
+	if h, _ := ecs.Get[components.Health](w, p); h.HP != 1000 {
+		t.Fatalf("the ice should only hurt near ice, HP %d", h.HP)
+	}
+
+	ice := w.Create()
+	ecs.Add(w, ice, components.Position{X: -48, Y: -50})
+	ecs.Add(w, ice, components.Tile{Glyph: '≡', Type: components.TileIce})
+	seq := w.Emit(ecs.Event{})
+	for i := 0; i < 500; i++ {
+		BiomeHazards{}.Update(1, w)
+	}
+	if h, _ := ecs.Get[components.Health](w, p); h.HP >= 1000 {
+		t.Fatal("the deep ice should hurt over 500 turns")
+	}
+	events, _ := w.EventsSince(seq)
+	for _, ev := range events {
+		if ev.Kind == EventSurfaceHazard && ev.Subject != "blizzard" && ev.Subject != "ice_collapse" {
+			t.Fatalf("unexpected hazard %q in the deep ice", ev.Subject)
+		}
+	}
+
+	ecs.Add(w, p, components.Health{HP: 1, Max: 10})
+	for i := 0; i < 500 && !ecs.GetWorldContext(w).GameOver; i++ {
+		BiomeHazards{}.Update(1, w)
+	}
+	if ctx := ecs.GetWorldContext(w); ctx.GameOverReason != "killed by the blizzard" && ctx.GameOverReason != "killed by falling ice" {
+		t.Fatalf("expected a hazard death, got %q", ctx.GameOverReason)
+	}
+}
+
+func TestShieldTurnsAwaySurfaceHazards(t *testing.T) {
+	w, p := landOn(t, data.BiomeVolcanic)
+	ecs.Add(w, p, components.Position{X: -50, Y: -50})
+	lava := w.Create()
+	ecs.Add(w, lava, components.Position{X: -50, Y: -49})
+	ecs.Add(w, lava, components.Tile{Glyph: '≈', Type: components.TileLava})
+	ecs.Add(w, p, components.PlayerStats{Hull: 100, Shield: 3})
+	ecs.Add(w, p, components.Health{HP: 1000, Max: 1000})
+	for i := 0; i < 2000; i++ {
+		BiomeHazards{}.Update(1, w)
+	}
+	if h, _ := ecs.Get[components.Health](w, p); h.HP != 1000 {
+		t.Fatalf("Shield III should turn the surface heat away, HP %d", h.HP)
+	}
+	if ps, _ := ecs.Get[components.PlayerStats](w, p); ps.Hull != 100 {
+		t.Fatalf("surface hazards should not touch the hull, hull %d", ps.Hull)
+	}
+}
+
+func TestToftHasNoSurfaceHazards(t *testing.T) {
+	w, p := newTestWorld(1, mapSize(200, 80), ship(10, 50), onPlanet(data.BiomeToftForest, 0), questsOffered)
+	for i := 0; i < 500; i++ {
+		BiomeHazards{}.Update(1, w)
+	}
+	if h, _ := ecs.Get[components.Health](w, p); h.HP != h.Max {
+		t.Fatalf("Toft's surface should be safe, HP %d", h.HP)
+	}
+}
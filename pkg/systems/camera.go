@@ -15,6 +15,11 @@ func (c *CameraSystem) Update(dt float64, w *ecs.World) {
 	cam, _ := ecs.Get[components.Camera](w, c.Target)
 	cam.X = int(pos.X) - cam.Width/2
 	cam.Y = int(pos.Y) - cam.Height/2
+	if ecs.GetWorldContext(w).CurrentLayer == ecs.LayerSpace {
+		// space has no edges; the camera just follows the ship
+		ecs.Add(w, c.Target, cam)
+		return
+	}
 	wi, _ := ecs.Get[components.WorldInfo](w, 1)
 	if cam.X < 0 {
 		cam.X = 0
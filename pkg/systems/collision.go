@@ -0,0 +1,220 @@
+package systems
+
+import (
+	"math"
+
+	"harvester/pkg/components"
+	"harvester/pkg/ecs"
+	"harvester/pkg/pathfind"
+)
+
+// EventCollision is emitted when the ship strikes an asteroid or comet, with
+// the hull damage taken in Amount and the body struck in Target.
+const EventCollision = "collision"
+
+// Collision tuning. Speeds are in tiles per second.
+const (
+	// bumpSpeed is the impact speed below which a collision only nudges.
+	bumpSpeed = 3.0
+	// impactDamage is hull lost per tile/s of impact speed over bumpSpeed.
+	impactDamage = 0.5
+	// restitution is how much of the impact speed the ship bounces back with.
+	restitution = 0.5
+)
+
+func init() {
+	ecs.RegisterComponent[Comet]()
+}
+
+// Comet is a comet's course across the space map.
+type Comet struct{ VX, VY float64 }
+
+// SpawnComet places a comet at (x, y) on a course picked from its position,
+// so the same map always gets the same comets.
+func SpawnComet(w *ecs.World, x, y int) ecs.Entity {
+	h := uint32(x*73856093 ^ y*19349663)
+	e := w.Create()
+	ecs.Add(w, e, components.Position{X: float64(x), Y: float64(y)})
+	ecs.Add(w, e, components.Tile{Glyph: '⤳', Type: components.TileComet})
+	ecs.Add(w, e, components.Renderable{Glyph: '⤳', TileType: components.TileComet})
+	ecs.Add(w, e, Comet{VX: 2 + float64(h%4), VY: float64(int(h>>4%5) - 2)})
+	ecs.Add(w, e, SpaceBody{})
+	return e
+}
+
+// CometDrift moves comets along their course. A comet loops around inside
+// the sector that made it, so it is never orphaned when sectors stream out;
+// a comet outside any sector wraps at the WorldInfo edges.
+type CometDrift struct{}
+
+func (CometDrift) Update(dt float64, w *ecs.World) {
+	wi, _ := ecs.Get[components.WorldInfo](w, 1)
+	ecs.View2Of[Comet, components.Position](w).Each(func(t ecs.Tuple2[Comet, components.Position]) {
+		t.B.X += t.A.VX * dt
+		t.B.Y += t.A.VY * dt
+		if m, ok := ecs.Get[SectorMember](w, t.E); ok {
+			ox, oy := float64(m.Sector.X*sectorW), float64(m.Sector.Y*sectorH)
+			t.B.X = ox + wrap(t.B.X-ox, sectorW)
+			t.B.Y = oy + wrap(t.B.Y-oy, sectorH)
+		} else if wi.Width > 0 && wi.Height > 0 {
+			t.B.X = wrap(t.B.X, float64(wi.Width))
+			t.B.Y = wrap(t.B.Y, float64(wi.Height))
+		}
+		ecs.Add(w, t.E, *t.B)
+	})
+}
+
+func wrap(v, size float64) float64 {
+	v = math.Mod(v, size)
+	if v < 0 {
+		v += size
+	}
+	return v
+}
+
+// obstacleIndex maps each cell holding an asteroid or comet to that body.
+type obstacleIndex map[pathfind.Point]ecs.Entity
+
+// spaceObstacles indexes the asteroids and comets ships can hit.
+func spaceObstacles(w *ecs.World) obstacleIndex {
+	idx := obstacleIndex{}
+	ecs.View2Of[components.Tile, components.Position](w).Each(func(t ecs.Tuple2[components.Tile, components.Position]) {
+		if t.A.Type != components.TileAsteroid && t.A.Type != components.TileComet {
+			return
+		}
+		idx[pathfind.Point{X: int(math.Floor(t.B.X)), Y: int(math.Floor(t.B.Y))}] = t.E
+	})
+	return idx
+}
+
+// collideShip sweeps ship e's move from prev to pos through the obstacle
+// cells. On the first hit the ship is stopped at the edge of the cell,
+// bounced off it and damaged by the impact speed; if the hull gives out
+// the run ends.
+func collideShip(w *ecs.World, e ecs.Entity, idx obstacleIndex, prev, pos components.Position, vel components.Velocity) (components.Position, components.Velocity) {
+	if len(idx) == 0 {
+		return pos, vel
+	}
+	var (
+		hit    ecs.Entity
+		nx, ny float64
+	)
+	sweepCells(prev, pos, func(cx, cy int, enterX, enterY float64) bool {
+		body, ok := idx[pathfind.Point{X: cx, Y: cy}]
+		if !ok {
+			return false
+		}
+		hit, nx, ny = body, enterX, enterY
+		return true
+	})
+	if hit == 0 {
+		return pos, vel
+	}
+	var ov components.Velocity
+	if c, ok := ecs.Get[Comet](w, hit); ok {
+		ov = components.Velocity{VX: c.VX, VY: c.VY}
+	}
+	rvx, rvy := vel.VX-ov.VX, vel.VY-ov.VY
+	if nx == 0 && ny == 0 {
+		// the body moved onto the ship: shove the ship out ahead of it
+		nx, ny = normalize(-rvx, -rvy)
+		if nx == 0 && ny == 0 {
+			ny = -1
+		}
+		pos.X, pos.Y = prev.X+nx, prev.Y+ny
+	} else {
+		pos = prev
+	}
+	speed := math.Hypot(rvx, rvy)
+	if along := rvx*nx + rvy*ny; along < 0 {
+		// reflect the approach off the cell face: knockback
+		rvx -= (1 + restitution) * along * nx
+		rvy -= (1 + restitution) * along * ny
+	}
+	vel.VX, vel.VY = ov.VX+rvx, ov.VY+rvy
+
+	damage := 0
+	if speed > bumpSpeed {
+		damage = HazardDamage(w, e, int(math.Round((speed-bumpSpeed)*impactDamage)))
+	}
+	name := "an asteroid"
+	if _, ok := ecs.Get[Comet](w, hit); ok {
+		name = "a comet"
+	}
+	if damage > 0 {
+		itemEvent(w, ecs.Event{Kind: EventCollision, Source: e, Target: hit, Amount: damage}, "You slam into %s! Hull -%d.", name, damage)
+	} else {
+		itemEvent(w, ecs.Event{Kind: EventCollision, Source: e, Target: hit}, "You bump into %s.", name)
+	}
+	if ps, ok := ecs.Get[components.PlayerStats](w, e); ok && ps.Hull <= 0 {
+		EndRun(w, "ship destroyed in a collision")
+	}
+	return pos, vel
+}
+
+// sweepCells walks the grid cells the segment a-b passes through, in order,
+// calling visit with each cell and the outward normal of the face crossed to
+// enter it (zero for the starting cell) until visit returns true.
+func sweepCells(a, b components.Position, visit func(cx, cy int, nx, ny float64) bool) {
+	cx, cy := int(math.Floor(a.X)), int(math.Floor(a.Y))
+	ex, ey := int(math.Floor(b.X)), int(math.Floor(b.Y))
+	if visit(cx, cy, 0, 0) {
+		return
+	}
+	dx, dy := b.X-a.X, b.Y-a.Y
+	stepX, stepY := 1, 1
+	if dx < 0 {
+		stepX = -1
+	}
+	if dy < 0 {
+		stepY = -1
+	}
+	// parametric distance to the next vertical and horizontal cell face
+	tMaxX, tMaxY := math.Inf(1), math.Inf(1)
+	tDeltaX, tDeltaY := math.Inf(1), math.Inf(1)
+	if dx != 0 {
+		next := float64(cx)
+		if stepX > 0 {
+			next++
+		}
+		tMaxX = (next - a.X) / dx
+		tDeltaX = math.Abs(1 / dx)
+	}
+	if dy != 0 {
+		next := float64(cy)
+		if stepY > 0 {
+			next++
+		}
+		tMaxY = (next - a.Y) / dy
+		tDeltaY = math.Abs(1 / dy)
+	}
+	for cx != ex || cy != ey {
+		var nx, ny float64
+		if tMaxX < tMaxY {
+			if tMaxX > 1 {
+				return
+			}
+			cx += stepX
+			tMaxX += tDeltaX
+			nx = float64(-stepX)
+		} else {
+			if tMaxY > 1 {
+				return
+			}
+			cy += stepY
+			tMaxY += tDeltaY
+			ny = float64(-stepY)
+		}
+		if visit(cx, cy, nx, ny) {
+			return
+		}
+	}
+}
+
+func normalize(x, y float64) (float64, float64) {
+	l := math.Hypot(x, y)
+	if l == 0 {
+		return 0, 0
+	}
+	return x / l, y / l
+}
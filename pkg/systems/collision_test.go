@@ -0,0 +1,113 @@
+package systems
+
+import (
+	"testing"
+
+	"harvester/pkg/components"
+	"harvester/pkg/ecs"
+)
+
+func spawnAsteroid(w *ecs.World, x, y float64) ecs.Entity {
+	e := w.Create()
+	ecs.Add(w, e, components.Position{X: x, Y: y})
+	ecs.Add(w, e, components.Tile{Glyph: '·', Type: components.TileAsteroid})
+	return e
+}
+
+// ram flies the ship east into the asteroid in the next cell at speed and
+// returns the ship after one frame.
+func ram(t *testing.T, speed float64, shield int) (components.PlayerStats, components.Position, components.Velocity) {
+	t.Helper()
+	w, p := newTestWorld(1, inSpace, ship(100, 100), mapSize(200, 80))
+	ps, _ := ecs.Get[components.PlayerStats](w, p)
+	ps.Shield = shield
+	ecs.Add(w, p, ps)
+	ecs.Add(w, p, components.Position{X: 5.5, Y: 5.5})
+	ecs.Add(w, p, components.Velocity{VX: speed})
+	spawnAsteroid(w, 6, 5)
+	SpaceMovement{}.Update(1.0/60, w)
+	ps, _ = ecs.Get[components.PlayerStats](w, p)
+	pos, _ := ecs.Get[components.Position](w, p)
+	v, _ := ecs.Get[components.Velocity](w, p)
+	return ps, pos, v
+}
+
+func TestFastShipCannotTunnelThroughAsteroid(t *testing.T) {
+	// at 150 tiles/s one step covers 2.5 tiles, well past the asteroid
+	ps, pos, v := ram(t, 150, 0)
+	if pos.X >= 6 {
+		t.Fatalf("the ship should stop short of the asteroid, at %+v", pos)
+	}
+	if v.VX >= 0 {
+		t.Fatalf("the impact should knock the ship back, velocity %+v", v)
+	}
+	if ps.Hull >= 100 {
+		t.Fatal("a fast impact should damage the hull")
+	}
+}
+
+func TestImpactDamageScalesWithSpeedAndShield(t *testing.T) {
+	slow, _, _ := ram(t, 60, 0)
+	fast, _, _ := ram(t, 120, 0)
+	shielded, _, _ := ram(t, 120, 3)
+	if fast.Hull >= slow.Hull {
+		t.Fatalf("faster impacts should hurt more: %d vs %d", fast.Hull, slow.Hull)
+	}
+	if shielded.Hull <= fast.Hull {
+		t.Fatalf("a shield should soften the impact: %d vs %d", shielded.Hull, fast.Hull)
+	}
+}
+
+func TestGentleBumpDoesNoDamage(t *testing.T) {
+	w, p := newTestWorld(1, inSpace, ship(100, 100), mapSize(200, 80))
+	ecs.Add(w, p, components.Position{X: 6.9, Y: 5.5})
+	ecs.Add(w, p, components.Velocity{VX: bumpSpeed / 2})
+	spawnAsteroid(w, 7, 5)
+	for i := 0; i < 10; i++ {
+		SpaceMovement{}.Update(1.0/60, w)
+	}
+	if ps, _ := ecs.Get[components.PlayerStats](w, p); ps.Hull != 100 {
+		t.Fatalf("a gentle bump should not hurt, hull at %d", ps.Hull)
+	}
+	if pos, _ := ecs.Get[components.Position](w, p); pos.X >= 7 {
+		t.Fatalf("the ship should not pass into the asteroid, at %+v", pos)
+	}
+}
+
+func TestCometsDriftAndWrap(t *testing.T) {
+	w, _ := newTestWorld(1, inSpace, ship(100, 100), mapSize(200, 80))
+	c := w.Create()
+	ecs.Add(w, c, components.Position{X: 199, Y: 40})
+	ecs.Add(w, c, Comet{VX: 4, VY: 0})
+	CometDrift{}.Update(0.5, w)
+	if pos, _ := ecs.Get[components.Position](w, c); pos.X != 1 || pos.Y != 40 {
+		t.Fatalf("expected the comet to wrap to the west edge, at %+v", pos)
+	}
+}
+
+func TestCometShovesShip(t *testing.T) {
+	w, p := newTestWorld(1, inSpace, ship(100, 100), mapSize(200, 80))
+	ecs.Add(w, p, components.Position{X: 5.5, Y: 5.5})
+	c := w.Create()
+	ecs.Add(w, c, components.Position{X: 5, Y: 5})
+	ecs.Add(w, c, components.Tile{Glyph: '⤳', Type: components.TileComet})
+	ecs.Add(w, c, Comet{VX: 6})
+	SpaceMovement{}.Update(1.0/60, w)
+	if v, _ := ecs.Get[components.Velocity](w, p); v.VX <= 0 {
+		t.Fatalf("the comet should carry the ship along, velocity %+v", v)
+	}
+}
+
+func TestCollisionCanDestroyShip(t *testing.T) {
+	w, p := newTestWorld(1, inSpace, ship(100, 100), mapSize(200, 80))
+	ps, _ := ecs.Get[components.PlayerStats](w, p)
+	ps.Hull = 5
+	ecs.Add(w, p, ps)
+	ecs.Add(w, p, components.Position{X: 5.5, Y: 5.5})
+	ecs.Add(w, p, components.Velocity{VX: 120})
+	spawnAsteroid(w, 7, 5)
+	SpaceMovement{}.Update(1.0/60, w)
+	if ctx := ecs.GetWorldContext(w); !ctx.GameOver || ctx.GameOverReason != "ship destroyed in a collision" {
+		t.Fatalf("expected a collision game over, got %+v", ctx)
+	}
+}
@@ -1,27 +1,161 @@
 package systems
 
 import (
+	"fmt"
+	"sort"
+	"strings"
+
 	"harvester/pkg/components"
 	"harvester/pkg/ecs"
 )
 
+// Event kinds emitted by combat.
+const (
+	EventHit  = "hit"
+	EventMiss = "miss"
+	EventKill = "kill"
+)
+
+// corpseDecay is how long, in seconds, a corpse stays on the map.
+const corpseDecay = 30.0
+
+func init() {
+	ecs.RegisterComponent[DecayTimer]()
+}
+
+// AttackIntent asks Combat to resolve one melee attack against Target on the
+// next update. Movement systems add it on a bump instead of moving.
+type AttackIntent struct{ Target ecs.Entity }
+
+// Combat resolves queued attacks and pending Damage. Rolls come from the
+// world RNG so a fight replays identically from a save.
 type Combat struct{}
 
 func (Combat) Update(dt float64, w *ecs.World) {
-	// Demo: if Left pressed and adjacent enemy, apply damage
-	ecs.View2Of[components.Input, components.Position](w).Each(func(t ecs.Tuple2[components.Input, components.Position]) {
-		if !t.A.Left {
-			return
+	ecs.View1Of[components.Melee](w).Each(func(e ecs.Entity, m *components.Melee) {
+		if m.Recover > 0 {
+			m.Recover -= dt
+			ecs.Add(w, e, *m)
+		}
+	})
+	// resolve in entity order so rolls from the shared RNG are reproducible
+	var attackers []ecs.Entity
+	ecs.View1Of[AttackIntent](w).Each(func(e ecs.Entity, _ *AttackIntent) { attackers = append(attackers, e) })
+	sort.Slice(attackers, func(i, j int) bool { return attackers[i] < attackers[j] })
+	for _, e := range attackers {
+		in, ok := ecs.Get[AttackIntent](w, e)
+		ecs.Remove[AttackIntent](w, e)
+		if ok {
+			Attack(w, e, in.Target)
 		}
-		px, py := int(t.B.X)-1, int(t.B.Y)
-		ecs.View2Of[components.Position, components.Health](w).Each(func(u ecs.Tuple2[components.Position, components.Health]) {
-			if int(u.A.X) == px && int(u.A.Y) == py {
-				u.B.HP -= 10
-				if u.B.HP < 0 {
-					u.B.HP = 0
-				}
-				ecs.Add(w, u.E, *u.B)
-			}
-		})
+	}
+	ecs.View1Of[components.Damage](w).Each(func(e ecs.Entity, d *components.Damage) {
+		ecs.Remove[components.Damage](w, e)
+		InflictDamage(w, 0, e, d.Amount)
 	})
 }
+
+// Attack makes one melee swing from attacker at target. It does nothing if
+// the attacker is still recovering or either side cannot fight.
+func Attack(w *ecs.World, attacker, target ecs.Entity) {
+	m, ok := ecs.Get[components.Melee](w, attacker)
+	if !ok || m.Recover > 0 {
+		return
+	}
+	if _, ok := ecs.Get[components.Health](w, target); !ok {
+		return
+	}
+	m.Recover = m.Cooldown
+	ecs.Add(w, attacker, m)
+
+	r := w.Rand()
+	if r.Intn(100) >= m.Accuracy {
+		w.Emit(ecs.Event{Kind: EventMiss, Source: attacker, Target: target,
+			Text: fmt.Sprintf("%s %s %s.", capitalize(nameOf(w, attacker)), verb(w, attacker, "miss", "misses"), nameOf(w, target))})
+		return
+	}
+	dmg := m.MinDamage
+	if m.MaxDamage > m.MinDamage {
+		dmg += r.Intn(m.MaxDamage - m.MinDamage + 1)
+	}
+	InflictDamage(w, attacker, target, dmg)
+}
+
+// InflictDamage applies amount to target after shield and armour, emits a hit
+// event and handles death. source may be 0 for environmental damage.
+func InflictDamage(w *ecs.World, source, target ecs.Entity, amount int) {
+	h, ok := ecs.Get[components.Health](w, target)
+	if !ok || amount <= 0 {
+		return
+	}
+	if sh, ok := ecs.Get[components.Shield](w, target); ok && sh.Current > 0 {
+		absorbed := min(sh.Current, amount)
+		sh.Current -= absorbed
+		amount -= absorbed
+		ecs.Add(w, target, sh)
+	}
+	if ar, ok := ecs.Get[components.Armor](w, target); ok {
+		amount = max(0, amount-ar.Value)
+	}
+	h.HP = max(0, h.HP-amount)
+	ecs.Add(w, target, h)
+
+	text := fmt.Sprintf("%s %s %s for %d.", capitalize(nameOf(w, source)), verb(w, source, "hit", "hits"), nameOf(w, target), amount)
+	if amount == 0 {
+		text = fmt.Sprintf("%s %s %s, but it is absorbed.", capitalize(nameOf(w, source)), verb(w, source, "hit", "hits"), nameOf(w, target))
+	}
+	w.Emit(ecs.Event{Kind: EventHit, Source: source, Target: target, Amount: amount, Text: text})
+
+	if h.HP <= 0 {
+		kill(w, source, target)
+	}
+}
+
+// kill ends the player's run, or replaces any other entity with a corpse that
+// fades out through DecaySystem.
+func kill(w *ecs.World, source, target ecs.Entity) {
+	if _, isPlayer := ecs.Get[components.Player](w, target); isPlayer {
+		EndRun(w, "killed by "+nameOf(w, source))
+		return
+	}
+	w.Emit(ecs.Event{Kind: EventKill, Source: source, Target: target, Subject: strings.TrimPrefix(nameOf(w, target), "the "),
+		Text: fmt.Sprintf("%s %s %s.", capitalize(nameOf(w, source)), verb(w, source, "kill", "kills"), nameOf(w, target))})
+	if pos, ok := ecs.Get[components.Position](w, target); ok {
+		c := w.Create()
+		ecs.Add(w, c, pos)
+		ecs.Add(w, c, components.Renderable{Glyph: '%', TileType: components.TileForest})
+		ecs.Add(w, c, components.Name{Text: "the remains of " + nameOf(w, target)})
+		ecs.Add(w, c, components.Transparency{Alpha: 1, BlendMode: components.BlendNormal})
+		ecs.Add(w, c, DecayTimer{Duration: corpseDecay})
+	}
+	w.Destroy(target)
+}
+
+// nameOf returns how messages refer to e: "you" for the player.
+func nameOf(w *ecs.World, e ecs.Entity) string {
+	if e == 0 {
+		return "something"
+	}
+	if _, ok := ecs.Get[components.Player](w, e); ok {
+		return "you"
+	}
+	if n, ok := ecs.Get[components.Name](w, e); ok && n.Text != "" {
+		return n.Text
+	}
+	return "something"
+}
+
+// verb picks the second-person form for the player and third-person otherwise.
+func verb(w *ecs.World, e ecs.Entity, you, other string) string {
+	if _, ok := ecs.Get[components.Player](w, e); ok {
+		return you
+	}
+	return other
+}
+
+func capitalize(s string) string {
+	if s == "" || s[0] < 'a' || s[0] > 'z' {
+		return s
+	}
+	return string(s[0]-'a'+'A') + s[1:]
+}
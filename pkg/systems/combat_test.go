@@ -0,0 +1,92 @@
+package systems
+
+import (
+	"harvester/pkg/components"
+	"harvester/pkg/ecs"
+	"testing"
+)
+
+func spawnFoe(w *ecs.World, x, y float64, hp int) ecs.Entity {
+	e := w.Create()
+	ecs.Add(w, e, components.Position{X: x, Y: y})
+	ecs.Add(w, e, components.Health{HP: hp, Max: hp})
+	ecs.Add(w, e, components.Name{Text: "the wolf"})
+	return e
+}
+
+func TestBumpAttackKillsAndLeavesCorpse(t *testing.T) {
+	w, p := newTestWorld(1)
+	foe := spawnFoe(w, 6, 5, 5)
+	SetPlayerInput(w, p, "right")
+	for i := 0; i < 2; i++ {
+		SurfaceMovement{}.Update(0.1, w)
+		Combat{}.Update(0.1, w)
+	}
+	if pos, _ := ecs.Get[components.Position](w, p); pos.X != 5 {
+		t.Fatalf("bumping should attack, not move; player at %v", pos.X)
+	}
+	if _, alive := ecs.Get[components.Health](w, foe); alive {
+		t.Fatal("two 3-damage hits should kill a 5 HP foe")
+	}
+	corpses := 0
+	ecs.View2Of[components.Position, DecayTimer](w).Each(func(t ecs.Tuple2[components.Position, DecayTimer]) {
+		if t.A.X == 6 && t.A.Y == 5 {
+			corpses++
+		}
+	})
+	if corpses != 1 {
+		t.Fatalf("expected one decaying corpse, got %d", corpses)
+	}
+	events, _ := w.EventsSince(0)
+	kinds := map[string]int{}
+	for _, ev := range events {
+		kinds[ev.Kind]++
+	}
+	if kinds[EventHit] != 2 || kinds[EventKill] != 1 {
+		t.Errorf("expected 2 hit events and 1 kill event, got %v", kinds)
+	}
+}
+
+func TestShieldAndArmorMitigate(t *testing.T) {
+	w, p := newTestWorld(1)
+	foe := spawnFoe(w, 6, 5, 10)
+	ecs.Add(w, foe, components.Shield{Current: 4, Max: 4})
+	ecs.Add(w, foe, components.Armor{Value: 2})
+	InflictDamage(w, p, foe, 7) // 4 to shield, 3 - 2 armour = 1
+	h, _ := ecs.Get[components.Health](w, foe)
+	sh, _ := ecs.Get[components.Shield](w, foe)
+	if h.HP != 9 || sh.Current != 0 {
+		t.Fatalf("expected HP 9 and empty shield, got HP %d shield %d", h.HP, sh.Current)
+	}
+}
+
+func TestCombatRollsAreSeeded(t *testing.T) {
+	run := func() []int {
+		w, p := newTestWorld(42)
+		ecs.Add(w, p, components.Melee{Accuracy: 50, MinDamage: 1, MaxDamage: 6})
+		foe := spawnFoe(w, 6, 5, 1000)
+		var out []int
+		for i := 0; i < 20; i++ {
+			Attack(w, p, foe)
+			h, _ := ecs.Get[components.Health](w, foe)
+			out = append(out, h.HP)
+		}
+		return out
+	}
+	a, b := run(), run()
+	for i := range a {
+		if a[i] != b[i] {
+			t.Fatalf("same seed should give the same fight, diverged at swing %d", i)
+		}
+	}
+}
+
+func TestPlayerDeathEndsRun(t *testing.T) {
+	w, p := newTestWorld(1)
+	foe := spawnFoe(w, 6, 5, 5)
+	InflictDamage(w, foe, p, 50)
+	ctx := ecs.GetWorldContext(w)
+	if !ctx.GameOver || ctx.GameOverReason != "killed by the wolf" {
+		t.Fatalf("expected run to end, got %+v", ctx)
+	}
+}
@@ -0,0 +1,145 @@
+package systems
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"harvester/pkg/components"
+	"harvester/pkg/data"
+	"harvester/pkg/ecs"
+)
+
+// EventCraft is emitted for each successful craft, with the recipe id in
+// Subject.
+const EventCraft = "craft"
+
+// Reasons a recipe cannot be crafted. Craft wraps them with the details.
+var (
+	ErrUnknownRecipe = errors.New("unknown recipe")
+	ErrMissingInputs = errors.New("missing ingredients")
+	ErrMissingTools  = errors.New("missing tools")
+	ErrNoStation     = errors.New("no workstation in reach")
+	ErrNoModule      = errors.New("ship module not installed")
+	ErrNoRoom        = errors.New("no room for the results")
+)
+
+// CraftIntent asks Crafting to make Recipe on the next update.
+type CraftIntent struct{ Recipe string }
+
+// Crafting resolves craft intents in entity order.
+type Crafting struct{}
+
+func (Crafting) Update(dt float64, w *ecs.World) {
+	for _, e := range sortedWith[CraftIntent](w) {
+		in, _ := ecs.Get[CraftIntent](w, e)
+		ecs.Remove[CraftIntent](w, e)
+		if err := Craft(w, e, in.Recipe); err != nil {
+			itemEvent(w, ecs.Event{Kind: EventItem, Source: e, Subject: in.Recipe}, "%s", capitalize(err.Error())+".")
+		}
+	}
+}
+
+// CanCraft reports why e cannot make r right now, or nil if it can.
+func CanCraft(w *ecs.World, e ecs.Entity, r data.Recipe) error {
+	_, err := craftResult(w, e, r)
+	return err
+}
+
+// Craft makes one batch of recipe id. Every requirement is checked against a
+// copy of the inventory first, so either all inputs are consumed and all
+// outputs added, or nothing changes.
+func Craft(w *ecs.World, e ecs.Entity, id string) error {
+	r, ok := data.LookupRecipe(id)
+	if !ok {
+		return fmt.Errorf("%w: %s", ErrUnknownRecipe, id)
+	}
+	after, err := craftResult(w, e, r)
+	if err != nil {
+		return err
+	}
+	// inputs that were equipped and are now gone come off first
+	before, _ := ecs.Get[components.Inventory](w, e)
+	for _, in := range r.Inputs {
+		if after.Count(in.Item) == 0 {
+			unequip(w, e, &before, in.Item)
+		}
+	}
+	after.Equipped = before.Equipped
+	ecs.Add(w, e, after)
+	itemEvent(w, ecs.Event{Kind: EventCraft, Source: e, Subject: r.ID, Amount: 1}, "You craft: %s.", describeQty(r.Outputs))
+	return nil
+}
+
+// craftResult returns e's inventory as it would be after crafting r.
+func craftResult(w *ecs.World, e ecs.Entity, r data.Recipe) (components.Inventory, error) {
+	inv, _ := ecs.Get[components.Inventory](w, e)
+	if missing := missingItems(inv, r.Inputs); len(missing) > 0 {
+		return inv, fmt.Errorf("%w: %s", ErrMissingInputs, describeQty(missing))
+	}
+	var tools []data.ItemQty
+	for _, t := range r.Tools {
+		if inv.Count(t) == 0 {
+			tools = append(tools, data.ItemQty{Item: t, Count: 1})
+		}
+	}
+	if len(tools) > 0 {
+		return inv, fmt.Errorf("%w: %s", ErrMissingTools, describeQty(tools))
+	}
+	if r.Station != "" && !stationInReach(w, e, r.Station) {
+		return inv, fmt.Errorf("%w: needs a %s", ErrNoStation, r.Station)
+	}
+	if r.ModuleTier > 0 && UpgradeTier(w, e, r.Module) < r.ModuleTier {
+		return inv, fmt.Errorf("%w: needs %s tier %d", ErrNoModule, upgradeName(r.Module), r.ModuleTier)
+	}
+
+	after := components.Inventory{Stacks: append([]components.ItemStack(nil), inv.Stacks...)}
+	for _, in := range r.Inputs {
+		stackRemove(&after, in.Item, in.Count)
+	}
+	hold, limited := CarryCapacity(w, e)
+	for _, out := range r.Outputs {
+		def, _ := data.Item(out.Item)
+		if roomFor(after, def, hold, limited) < out.Count {
+			return inv, fmt.Errorf("%w: %s", ErrNoRoom, describeQty(r.Outputs))
+		}
+		stackAdd(&after, def, out.Count)
+	}
+	return after, nil
+}
+
+// stationInReach reports whether a workstation of kind is within one tile.
+func stationInReach(w *ecs.World, e ecs.Entity, kind string) bool {
+	pos, ok := ecs.Get[components.Position](w, e)
+	if !ok {
+		return false
+	}
+	found := false
+	ecs.View2Of[components.Workstation, components.Position](w).Each(func(t ecs.Tuple2[components.Workstation, components.Position]) {
+		if t.A.Kind == kind && chebyshev(int(pos.X), int(pos.Y), int(t.B.X), int(t.B.Y)) <= 1 {
+			found = true
+		}
+	})
+	return found
+}
+
+// missingItems returns what inv lacks of need.
+func missingItems(inv components.Inventory, need []data.ItemQty) []data.ItemQty {
+	var missing []data.ItemQty
+	for _, q := range need {
+		if have := inv.Count(q.Item); have < q.Count {
+			missing = append(missing, data.ItemQty{Item: q.Item, Count: q.Count - have})
+		}
+	}
+	return missing
+}
+
+// describeQty renders counts as "2 iron ore, 1 timber".
+func describeQty(qs []data.ItemQty) string {
+	parts := make([]string, len(qs))
+	for i, q := range qs {
+		def, _ := data.Item(q.Item)
+		parts[i] = fmt.Sprintf("%d %s", q.Count, def.Name)
+	}
+	return strings.Join(parts, ", ")
+}
@@ -0,0 +1,108 @@
+package systems
+
+import (
+	"errors"
+	"harvester/pkg/components"
+	"harvester/pkg/data"
+	"harvester/pkg/ecs"
+	"testing"
+)
+
+func addWorkstation(w *ecs.World, x, y float64, kind string) {
+	e := w.Create()
+	ecs.Add(w, e, components.Position{X: x, Y: y})
+	ecs.Add(w, e, components.Workstation{Kind: kind})
+}
+
+func TestCraftConsumesInputsAndKeepsTools(t *testing.T) {
+	w, p := newTestWorld(1)
+	AddItem(w, p, "wood", 3)
+	AddItem(w, p, "obsidian", 1)
+	AddItem(w, p, "knife", 1)
+	ecs.Add(w, p, CraftIntent{Recipe: "spear"})
+	Crafting{}.Update(0, w)
+	inv, _ := ecs.Get[components.Inventory](w, p)
+	if inv.Count("spear") != 1 || inv.Count("wood") != 1 || inv.Count("obsidian") != 0 || inv.Count("knife") != 1 {
+		t.Fatalf("unexpected inventory %+v", inv.Stacks)
+	}
+	evs, _ := w.EventsSince(0)
+	last := evs[len(evs)-1]
+	if last.Kind != EventCraft || last.Subject != "spear" {
+		t.Fatalf("expected craft event, got %+v", last)
+	}
+}
+
+func TestCraftRequirements(t *testing.T) {
+	w, p := newTestWorld(1)
+	AddItem(w, p, "ore", 3)
+	AddItem(w, p, "wood", 1)
+	if err := Craft(w, p, "pickaxe"); !errors.Is(err, ErrNoStation) {
+		t.Fatalf("expected missing station, got %v", err)
+	}
+	addWorkstation(w, 6, 6, data.StationWorkbench)
+	if err := Craft(w, p, "pickaxe"); err != nil {
+		t.Fatalf("pickaxe next to a workbench: %v", err)
+	}
+	if err := Craft(w, p, "pickaxe"); !errors.Is(err, ErrMissingInputs) {
+		t.Fatalf("expected missing inputs, got %v", err)
+	}
+	AddItem(w, p, "energy", 4)
+	AddItem(w, p, "ore", 1)
+	if err := Craft(w, p, "fuel_cell"); !errors.Is(err, ErrNoModule) {
+		t.Fatalf("expected missing module, got %v", err)
+	}
+	ecs.Add(w, p, components.PlayerStats{Drive: 1})
+	if err := Craft(w, p, "fuel_cell"); err != nil {
+		t.Fatalf("fuel cell with Drive I: %v", err)
+	}
+	if err := Craft(w, p, "nope"); !errors.Is(err, ErrUnknownRecipe) {
+		t.Fatalf("expected unknown recipe, got %v", err)
+	}
+}
+
+func TestCraftLeavesInventoryAloneOnFailure(t *testing.T) {
+	w, p := newTestWorld(1)
+	addWorkstation(w, 5, 6, data.StationForge)
+	AddItem(w, p, "obsidian", 10)
+	AddItem(w, p, "scrap", 3)
+	AddItem(w, p, "pickaxe", 1)
+	before, _ := ecs.Get[components.Inventory](w, p)
+	if err := Craft(w, p, "heat_shield"); !errors.Is(err, ErrMissingInputs) {
+		t.Fatalf("expected missing inputs, got %v", err)
+	}
+	after, _ := ecs.Get[components.Inventory](w, p)
+	for _, item := range []string{"obsidian", "scrap", "pickaxe", "heat_shield"} {
+		if before.Count(item) != after.Count(item) {
+			t.Errorf("%s changed from %d to %d", item, before.Count(item), after.Count(item))
+		}
+	}
+	AddItem(w, p, "scrap", 1)
+	if err := Craft(w, p, "heat_shield"); err != nil {
+		t.Fatalf("with every input: %v", err)
+	}
+	inv, _ := ecs.Get[components.Inventory](w, p)
+	if inv.Count("heat_shield") != 1 || inv.Count("pickaxe") != 1 || inv.Count("obsidian") != 0 {
+		t.Fatalf("unexpected inventory %+v", inv.Stacks)
+	}
+}
+
+func TestCraftingKeepsEquippedTools(t *testing.T) {
+	w, p := newTestWorld(1)
+	addWorkstation(w, 5, 4, data.StationForge)
+	AddItem(w, p, "knife", 1)
+	ToggleEquip(w, p, "knife")
+	base, _ := ecs.Get[components.Melee](w, p)
+	AddItem(w, p, "wood", 2)
+	AddItem(w, p, "obsidian", 1)
+	// the knife is a tool here, so it stays equipped
+	if err := Craft(w, p, "spear"); err != nil {
+		t.Fatal(err)
+	}
+	if m, _ := ecs.Get[components.Melee](w, p); m != base {
+		t.Fatalf("tool use should not change melee, %+v", m)
+	}
+	inv, _ := ecs.Get[components.Inventory](w, p)
+	if inv.Equipped["weapon"] != "knife" {
+		t.Fatal("knife should still be equipped")
+	}
+}
@@ -0,0 +1,347 @@
+package systems
+
+import (
+	"fmt"
+	"math"
+
+	"harvester/pkg/components"
+	"harvester/pkg/data"
+	"harvester/pkg/ecs"
+)
+
+// Below the surface every level is a dungeon carved out of solid rock:
+// rooms joined by corridors, or caves grown by a cellular automaton, now and
+// then with a vault themed on the biome. Levels are joined by stairs, and a
+// flight of stairs goes further down the harder the depth layer is.
+
+func init() {
+	ecs.RegisterComponent[Stairs]()
+}
+
+// EventStairs is emitted when the player takes the stairs, with the depth
+// arrived at in Amount.
+const EventStairs = "stairs"
+
+// Stairs lead from one level to another. To is the depth they arrive at.
+type Stairs struct {
+	Down bool
+	To   int
+}
+
+const (
+	// stairDrop is how far down one flight of stairs goes in a layer of
+	// DifficultyMod 1.
+	stairDrop = 5
+	// stairsAway is how far the stairs down try to be from where the player
+	// arrives.
+	stairsAway  = 30
+	vaultChance = 0.3
+)
+
+var rock = terrainCell{glyph: '█', kind: components.TileMountain}
+
+// cavesChance is how likely a level of each biome is to be caves rather
+// than rooms and corridors.
+var cavesChance = map[data.BiomeType]float64{
+	data.BiomeToftForest:   0.5,
+	data.BiomeVolcanic:     0.7,
+	data.BiomeIce:          0.6,
+	data.BiomeAncientRuins: 0.2,
+}
+
+// vaultTheme is what a biome's vaults are ringed with and hold.
+type vaultTheme struct {
+	moat   terrainCell
+	loot   string
+	amount int
+}
+
+var vaultThemes = map[data.BiomeType]vaultTheme{
+	data.BiomeToftForest:   {moat: water, loot: "repair_kit", amount: 2},
+	data.BiomeVolcanic:     {moat: lava, loot: "heat_crystal", amount: 4},
+	data.BiomeIce:          {moat: ice, loot: "ice_essence", amount: 4},
+	data.BiomeAncientRuins: {moat: ruinWall, loot: "ancient_circuit", amount: 6},
+}
+
+// GenerateDungeon lays out the current deep level with stairs up and, above
+// the planet's bottom, stairs down. The bottom level always has a vault.
+// Like the surface, the same world seed, planet and depth always give the
+// same level, and every open cell can be reached from the stairs.
+func GenerateDungeon(w *ecs.World, ctx ecs.WorldContext, wi components.WorldInfo) {
+	g := newTerrainGen(levelSeed(w.Seed(), ctx.PlanetID, ctx.Depth), wi)
+	biome := data.BiomeType(ctx.BiomeType)
+	bottom := landedPlanet(w, ctx).MaxDepth
+	if g.r.Float64() < cavesChance[biome] {
+		g.caves()
+	} else {
+		g.rooms()
+	}
+	vx, vy, vault := 0, 0, false
+	if ctx.Depth >= bottom || g.r.Float64() < vaultChance {
+		vx, vy, vault = g.vault(vaultThemes[biome])
+	}
+	g.connect(minArea, &rock)
+	g.spawn(w)
+
+	label, sizes := g.regions()
+	if len(sizes) == 0 {
+		return
+	}
+	main := largest(sizes)
+	ux, uy, _ := g.pick(label, main, -1, -1, 0)
+	spawnStairs(w, ux, uy, prevDepth(ctx, ctx.Depth, bottom))
+	if ctx.Depth < bottom {
+		if x, y, ok := g.pick(label, main, ux, uy, stairsAway); ok {
+			spawnStairs(w, x, y, nextDepth(ctx, ctx.Depth, bottom))
+		}
+	}
+	// the loot is the level's, not its terrain's, so it is only laid out on
+	// the first visit and otherwise comes back from the LevelCache
+	if vault && !LevelCached(w, ctx.PlanetID, ctx.Depth) {
+		th := vaultThemes[biome]
+		SpawnPile(w, vx, vy, th.loot, th.amount)
+	}
+}
+
+// rooms carves up to a dozen rooms that do not overlap, each joined to the
+// one before by a corridor.
+func (g *terrainGen) rooms() {
+	g.fill(rock)
+	type room struct{ x, y, w, h int }
+	var placed []room
+	for try := 0; try < 200 && len(placed) < 12; try++ {
+		rm := room{w: 6 + g.r.Intn(10), h: 4 + g.r.Intn(5)}
+		rm.x = 1 + g.r.Intn(max(1, g.width-rm.w-2))
+		rm.y = 1 + g.r.Intn(max(1, g.height-rm.h-2))
+		clear := true
+		for _, o := range placed {
+			if rm.x <= o.x+o.w && o.x <= rm.x+rm.w && rm.y <= o.y+o.h && o.y <= rm.y+rm.h {
+				clear = false
+				break
+			}
+		}
+		if !clear {
+			continue
+		}
+		for y := rm.y; y < rm.y+rm.h; y++ {
+			for x := rm.x; x < rm.x+rm.w; x++ {
+				g.set(x, y, openGround)
+			}
+		}
+		if n := len(placed); n > 0 {
+			o := placed[n-1]
+			g.corridor(o.x+o.w/2, o.y+o.h/2, rm.x+rm.w/2, rm.y+rm.h/2)
+		}
+		placed = append(placed, rm)
+	}
+}
+
+// corridor carves an L-shaped passage between two points, turning either
+// way at random.
+func (g *terrainGen) corridor(x0, y0, x1, y1 int) {
+	cx, cy := x1, y0
+	if g.r.Intn(2) == 0 {
+		cx, cy = x0, y1
+	}
+	for _, leg := range [2][4]int{{x0, y0, cx, cy}, {cx, cy, x1, y1}} {
+		x, y := leg[0], leg[1]
+		for {
+			g.set(x, y, openGround)
+			if x == leg[2] && y == leg[3] {
+				break
+			}
+			x += sign(leg[2] - x)
+			y += sign(leg[3] - y)
+		}
+	}
+}
+
+// caves seeds the map with rock and smooths it for five rounds: a cell
+// becomes rock when five or more of the nine cells around and on it are
+// rock, counting the map's edge as rock.
+func (g *terrainGen) caves() {
+	for i := range g.cells {
+		g.cells[i] = openGround
+		if g.r.Float64() < 0.45 {
+			g.cells[i] = rock
+		}
+	}
+	for round := 0; round < 5; round++ {
+		next := make([]terrainCell, len(g.cells))
+		for y := 0; y < g.height; y++ {
+			for x := 0; x < g.width; x++ {
+				walls := 0
+				for dy := -1; dy <= 1; dy++ {
+					for dx := -1; dx <= 1; dx++ {
+						if !g.in(x+dx, y+dy) || g.at(x+dx, y+dy) == rock {
+							walls++
+						}
+					}
+				}
+				next[y*g.width+x] = openGround
+				if walls >= 5 {
+					next[y*g.width+x] = rock
+				}
+			}
+		}
+		g.cells = next
+	}
+}
+
+// vault walls off a room ringed with the theme's moat and returns its middle,
+// where the loot goes. connect later breaks a way in.
+func (g *terrainGen) vault(th vaultTheme) (int, int, bool) {
+	const vw, vh = 13, 9
+	if g.width < vw+2 || g.height < vh+2 {
+		return 0, 0, false
+	}
+	x0, y0 := 1+g.r.Intn(g.width-vw-1), 1+g.r.Intn(g.height-vh-1)
+	for y := y0; y < y0+vh; y++ {
+		for x := x0; x < x0+vw; x++ {
+			ring := min(x-x0, y-y0, x0+vw-1-x, y0+vh-1-y)
+			switch ring {
+			case 0:
+				g.set(x, y, th.moat)
+			case 1:
+				g.set(x, y, ruinWall)
+			default:
+				g.set(x, y, openGround)
+			}
+		}
+	}
+	return x0 + vw/2, y0 + vh/2, true
+}
+
+func (g *terrainGen) fill(c terrainCell) {
+	for i := range g.cells {
+		g.cells[i] = c
+	}
+}
+
+func sign(n int) int {
+	switch {
+	case n > 0:
+		return 1
+	case n < 0:
+		return -1
+	}
+	return 0
+}
+
+// spawnStairs places stairs leading to depth to; they lead down if to is
+// deeper than the level they are on, which is always true of the surface.
+func spawnStairs(w *ecs.World, x, y, to int) ecs.Entity {
+	down := to > ecs.GetWorldContext(w).Depth
+	glyph, name := '<', "stairs up"
+	if down {
+		glyph, name = '>', "stairs down"
+	}
+	e := w.Create()
+	ecs.Add(w, e, components.Position{X: float64(x), Y: float64(y)})
+	ecs.Add(w, e, components.Renderable{Glyph: glyph, TileType: components.TileStructure})
+	ecs.Add(w, e, components.Name{Text: name})
+	ecs.Add(w, e, Stairs{Down: down, To: to})
+	ecs.Add(w, e, TerrainTile{})
+	return e
+}
+
+// landedPlanet returns the planet the player is on: its card in the space
+// layer, or failing that its biome generator's roll for it.
+func landedPlanet(w *ecs.World, ctx ecs.WorldContext) data.Planet {
+	var (
+		p     data.Planet
+		found bool
+	)
+	ecs.View1Of[PlanetCard](w).Each(func(_ ecs.Entity, c *PlanetCard) {
+		if !found && c.Planet.ID == ctx.PlanetID {
+			p, found = c.Planet, true
+		}
+	})
+	if !found {
+		p = data.Generator(data.BiomeType(ctx.BiomeType)).Generate(ctx.PlanetID, w.Seed())
+	}
+	return p
+}
+
+// nextDepth is the depth of the level below depth: one flight of stairs,
+// longer in harder layers, but never past the bottom.
+func nextDepth(ctx ecs.WorldContext, depth, bottom int) int {
+	ctx.Depth = depth
+	flight := max(1, int(math.Round(stairDrop*DepthLayerOf(ctx).DifficultyMod)))
+	return min(depth+flight, bottom)
+}
+
+// prevDepth is the depth of the level above depth, found by walking the
+// flights down from the surface.
+func prevDepth(ctx ecs.WorldContext, depth, bottom int) int {
+	up := 0
+	for d := 0; d < depth && d < bottom; d = nextDepth(ctx, d, bottom) {
+		up = d
+	}
+	return up
+}
+
+// stairsAt returns the stairs on tile (x, y).
+func stairsAt(w *ecs.World, x, y int) (Stairs, bool) {
+	var (
+		st    Stairs
+		found bool
+	)
+	ecs.View2Of[Stairs, components.Position](w).Each(func(t ecs.Tuple2[Stairs, components.Position]) {
+		if !found && int(t.B.X) == x && int(t.B.Y) == y {
+			st, found = *t.A, true
+		}
+	})
+	return st, found
+}
+
+// arriveByStairs puts the player on the stairs leading back to depth from.
+func arriveByStairs(w *ecs.World, from int) {
+	player, _, ok := findPlayer(w)
+	if !ok {
+		return
+	}
+	ecs.View2Of[Stairs, components.Position](w).Each(func(t ecs.Tuple2[Stairs, components.Position]) {
+		if t.A.To == from {
+			ecs.Add(w, player, *t.B)
+		}
+	})
+}
+
+// Stairways takes the player up or down the stairs they stand on when they
+// press '>'. It runs before Launch, which would otherwise take the key
+// press, and TerrainGen then builds the level arrived at.
+type Stairways struct{}
+
+func (Stairways) Update(dt float64, w *ecs.World) {
+	ctx := ecs.GetWorldContext(w)
+	if ctx.CurrentLayer == ecs.LayerSpace || ctx.GameOver {
+		return
+	}
+	player, pos, ok := findPlayer(w)
+	if !ok {
+		return
+	}
+	if _, pressed := ecs.Get[EnterPlanet](w, player); !pressed {
+		return
+	}
+	st, found := stairsAt(w, int(pos.X), int(pos.Y))
+	if !found {
+		return
+	}
+	ecs.Remove[EnterPlanet](w, player)
+	ctx.Depth = st.To
+	ctx.CurrentLayer = ecs.LayerPlanetDeep
+	text := fmt.Sprintf("You climb up to depth %d.", st.To)
+	switch {
+	case st.Down:
+		text = fmt.Sprintf("You climb down to depth %d.", st.To)
+	case st.To == 0:
+		ctx.CurrentLayer = ecs.LayerPlanetSurface
+		text = "You climb back up to the surface."
+	}
+	ecs.SetWorldContext(w, ctx)
+	ecs.Remove[AutoTravel](w, player)
+	ecs.Add(w, player, components.Input{})
+	w.Emit(ecs.Event{Kind: EventStairs, Source: player, Amount: st.To, Text: text})
+}
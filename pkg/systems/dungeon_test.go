@@ -0,0 +1,223 @@
+package systems
+
+import (
+	"testing"
+
+	"harvester/pkg/components"
+	"harvester/pkg/data"
+	"harvester/pkg/ecs"
+	"harvester/pkg/pathfind"
+)
+
+// stairsOn returns the stairs of the current level going down or up.
+func stairsOn(w *ecs.World, down bool) (Stairs, components.Position, bool) {
+	var (
+		st    Stairs
+		pos   components.Position
+		found bool
+	)
+	ecs.View2Of[Stairs, components.Position](w).Each(func(t ecs.Tuple2[Stairs, components.Position]) {
+		if t.A.Down == down {
+			st, pos, found = *t.A, *t.B, true
+		}
+	})
+	return st, pos, found
+}
+
+// takeStairs walks the player onto the stairs and presses '>'.
+func takeStairs(t *testing.T, w *ecs.World, p ecs.Entity, down bool) Stairs {
+	t.Helper()
+	st, pos, ok := stairsOn(w, down)
+	if !ok {
+		t.Fatalf("no stairs (down %v) at depth %d", down, ecs.GetWorldContext(w).Depth)
+	}
+	ecs.Add(w, p, pos)
+	ecs.Add(w, p, EnterPlanet{})
+	Stairways{}.Update(0, w)
+	TerrainGen{}.Update(0, w)
+	return st
+}
+
+func reachable(w *ecs.World, from, to components.Position) bool {
+	_, ok := pathfind.AStar(Navigation(w).Grid, pathfind.Point{X: int(from.X), Y: int(from.Y)}, pathfind.Point{X: int(to.X), Y: int(to.Y)})
+	return ok
+}
+
+func TestStairsLeadDownAndBack(t *testing.T) {
+	w, p := newTestWorld(1, mapSize(200, 80), playerAt(100, 40), onPlanet(data.BiomeVolcanic, 0))
+	TerrainGen{}.Update(0, w)
+	surface := terrainGlyphs(w)
+	_, down, ok := stairsOn(w, true)
+	if !ok {
+		t.Fatal("the surface should have stairs down")
+	}
+	if !reachable(w, components.Position{X: 100, Y: 40}, down) {
+		t.Fatal("the stairs down should be reachable from the landing site")
+	}
+
+	st := takeStairs(t, w, p, true)
+	ctx := ecs.GetWorldContext(w)
+	if ctx.CurrentLayer != ecs.LayerPlanetDeep || ctx.Depth != st.To || st.To != stairDrop {
+		t.Fatalf("expected to be in the deep layer at depth %d, got layer %v depth %d", st.To, ctx.CurrentLayer, ctx.Depth)
+	}
+	pos, _ := ecs.Get[components.Position](w, p)
+	if up, ok := stairsAt(w, int(pos.X), int(pos.Y)); !ok || up.Down || up.To != 0 {
+		t.Fatalf("the player should arrive on the stairs back up, got %+v", up)
+	}
+	_, next, ok := stairsOn(w, true)
+	if !ok || !reachable(w, pos, next) {
+		t.Fatal("the stairs down should be reachable from the stairs up")
+	}
+
+	takeStairs(t, w, p, false)
+	if ctx := ecs.GetWorldContext(w); ctx.CurrentLayer != ecs.LayerPlanetSurface || ctx.Depth != 0 {
+		t.Fatalf("climbing up should return to the surface, got layer %v depth %d", ctx.CurrentLayer, ctx.Depth)
+	}
+	pos, _ = ecs.Get[components.Position](w, p)
+	if pos != down {
+		t.Fatalf("the player should come up on the stairs down, at %+v not %+v", pos, down)
+	}
+	for g, n := range terrainGlyphs(w) {
+		if surface[g] != n {
+			t.Fatalf("the surface should be the same on return: %q %d vs %d", g, n, surface[g])
+		}
+	}
+}
+
+func TestLevelsKeepTheirFog(t *testing.T) {
+	w, p := newTestWorld(1, mapSize(200, 80), playerAt(100, 40), onPlanet(data.BiomeVolcanic, 0))
+	TerrainGen{}.Update(0, w)
+	Vision{}.Update(0, w)
+	if !seen(w, p, 100, 40) {
+		t.Fatal("the landing site should be seen")
+	}
+
+	takeStairs(t, w, p, true)
+	if seen(w, p, 100, 40) {
+		t.Fatal("a new level should start unexplored")
+	}
+	Vision{}.Update(0, w)
+	below, _ := ecs.Get[components.Position](w, p)
+
+	takeStairs(t, w, p, false)
+	if !seen(w, p, 100, 40) {
+		t.Fatal("the surface should still be explored on return")
+	}
+	takeStairs(t, w, p, true)
+	if !seen(w, p, int(below.X), int(below.Y)) {
+		t.Fatal("the level below should still be explored on return")
+	}
+}
+
+// seen reports whether the player's fog memory has (x, y).
+func seen(w *ecs.World, p ecs.Entity, x, y int) bool {
+	mem, ok := ecs.Get[components.FogMemory](w, p)
+	return ok && mem.Has(x, y)
+}
+
+func TestFlightsLengthenWithDifficulty(t *testing.T) {
+	w, _ := newTestWorld(1, mapSize(200, 80), playerAt(100, 40), onPlanet(data.BiomeVolcanic, 0))
+	ctx := ecs.GetWorldContext(w)
+	bottom := landedPlanet(w, ctx).MaxDepth
+	if got := nextDepth(ctx, 0, bottom); got != 5 {
+		t.Fatalf("one flight from the surface should go 5 down, got %d", got)
+	}
+	if got := nextDepth(ctx, 30, bottom); got != 40 {
+		t.Fatalf("a flight in the shallow layer (x2) should go 10 down, got %d", got)
+	}
+	if got := nextDepth(ctx, 60, bottom); got != 80 {
+		t.Fatalf("a flight in the deep layer (x4) should go 20 down, got %d", got)
+	}
+	for d := 0; d < bottom; {
+		next := nextDepth(ctx, d, bottom)
+		if next <= d || next > bottom {
+			t.Fatalf("bad flight from %d to %d", d, next)
+		}
+		if up := prevDepth(ctx, next, bottom); up != d {
+			t.Fatalf("the stairs up from %d should lead to %d, got %d", next, d, up)
+		}
+		d = next
+	}
+}
+
+func TestHoldingDownNoLongerDescends(t *testing.T) {
+	w, p := newTestWorld(1, mapSize(200, 80), playerAt(100, 40), onPlanet(data.BiomeToftForest, 0))
+	ecs.Add(w, p, components.Input{Down: true})
+	for i := 0; i < 10; i++ {
+		SurfaceMovement{}.Update(1, w)
+	}
+	if d := ecs.GetWorldContext(w).Depth; d != 0 {
+		t.Fatalf("walking south should not change depth, got %d", d)
+	}
+}
+
+func TestDungeonLevelsAreWalledAndConnected(t *testing.T) {
+	for _, biome := range []data.BiomeType{data.BiomeToftForest, data.BiomeVolcanic, data.BiomeIce, data.BiomeAncientRuins} {
+		w, _ := newTestWorld(1, mapSize(200, 80), playerAt(100, 40), onPlanet(biome, 0))
+		ctx := ecs.GetWorldContext(w)
+		ctx.CurrentLayer = ecs.LayerPlanetDeep
+		bottom := landedPlanet(w, ctx).MaxDepth
+		depth := stairDrop
+		for level := 0; level < 6 && depth < bottom; level++ {
+			ctx.Depth = depth
+			ecs.SetWorldContext(w, ctx)
+			TerrainGen{}.Update(0, w)
+			if terrainGlyphs(w)['█'] == 0 {
+				t.Fatalf("%v depth %d: a dungeon should be cut from rock", biome, depth)
+			}
+			_, up, _ := stairsOn(w, false)
+			_, down, ok := stairsOn(w, true)
+			if !ok || !reachable(w, up, down) {
+				t.Fatalf("%v depth %d: the stairs should be joined", biome, depth)
+			}
+			depth = nextDepth(ctx, depth, bottom)
+		}
+	}
+}
+
+func TestBottomLevelHoldsAVault(t *testing.T) {
+	for biome, th := range vaultThemes {
+		w, _ := newTestWorld(1, mapSize(200, 80), playerAt(100, 40), onPlanet(biome, 0))
+		ctx := ecs.GetWorldContext(w)
+		ctx.CurrentLayer = ecs.LayerPlanetDeep
+		ctx.Depth = landedPlanet(w, ctx).MaxDepth
+		ecs.SetWorldContext(w, ctx)
+		TerrainGen{}.Update(0, w)
+		if _, _, ok := stairsOn(w, true); ok {
+			t.Fatalf("%v: the bottom level should have no stairs down", biome)
+		}
+		_, up, _ := stairsOn(w, false)
+		found := false
+		ecs.View2Of[components.Resource, components.Position](w).Each(func(t2 ecs.Tuple2[components.Resource, components.Position]) {
+			if t2.A.Kind == th.loot && reachable(w, up, *t2.B) {
+				found = true
+			}
+		})
+		if !found {
+			t.Fatalf("%v: expected a reachable vault of %s", biome, th.loot)
+		}
+	}
+}
+
+func TestWallsBlockTheWay(t *testing.T) {
+	w, p := newTestWorld(1, mapSize(200, 80), playerAt(100, 40), onPlanet(data.BiomeAncientRuins, 0))
+	ctx := ecs.GetWorldContext(w)
+	ctx.CurrentLayer = ecs.LayerPlanetDeep
+	ctx.Depth = stairDrop
+	ecs.SetWorldContext(w, ctx)
+	TerrainGen{}.Update(0, w)
+	nav := Navigation(w)
+	var wall components.Position
+	ecs.View2Of[components.Tile, components.Position](w).Each(func(t2 ecs.Tuple2[components.Tile, components.Position]) {
+		if t2.A.Glyph == '█' && t2.B.X > 0 && !nav.Blocked(int(t2.B.X)-1, int(t2.B.Y)) {
+			wall = *t2.B
+		}
+	})
+	start := components.Position{X: wall.X - 1, Y: wall.Y}
+	ecs.Add(w, p, start)
+	ecs.Add(w, p, components.Input{Right: true})
+	SurfaceMovement{}.Update(1, w)
+	if pos, _ := ecs.Get[components.Position](w, p); pos != start {
+		t.Fatalf("rock at %+v should stop the player, now at %+v", wall, pos)
+	}
+}
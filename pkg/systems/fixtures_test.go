@@ -0,0 +1,75 @@
+package systems
+
+import (
+	"math/rand"
+
+	"harvester/pkg/components"
+	"harvester/pkg/data"
+	"harvester/pkg/ecs"
+)
+
+// fixture adjusts a world newTestWorld has just built.
+type fixture func(w *ecs.World, player ecs.Entity)
+
+// newTestWorld builds the world systems tests start from: a planet surface
+// seeded with seed, where the player stands at (5, 5) with 10 HP and always
+// hits for 3. The fixtures then set it up for the test, in order.
+func newTestWorld(seed int64, fixtures ...fixture) (*ecs.World, ecs.Entity) {
+	w := ecs.NewWorld(rand.New(rand.NewSource(seed)))
+	p := w.Create()
+	ecs.Add(w, p, components.Player{})
+	ecs.Add(w, p, components.Position{X: 5, Y: 5})
+	ecs.Add(w, p, components.Input{})
+	ecs.Add(w, p, components.Health{HP: 10, Max: 10})
+	ecs.Add(w, p, components.Melee{Accuracy: 100, MinDamage: 3, MaxDamage: 3})
+	ecs.SetWorldContext(w, ecs.WorldContext{CurrentLayer: ecs.LayerPlanetSurface})
+	for _, f := range fixtures {
+		f(w, p)
+	}
+	return w, p
+}
+
+// mapSize gives the world a width by height map.
+func mapSize(width, height int) fixture {
+	return func(w *ecs.World, _ ecs.Entity) {
+		ecs.Add(w, 1, components.WorldInfo{Width: width, Height: height})
+	}
+}
+
+// playerAt moves the player to (x, y).
+func playerAt(x, y float64) fixture {
+	return func(w *ecs.World, p ecs.Entity) {
+		ecs.Add(w, p, components.Position{X: x, Y: y})
+	}
+}
+
+// inSpace puts the player in space, in a ship that can fly.
+func inSpace(w *ecs.World, p ecs.Entity) {
+	ctx := ecs.GetWorldContext(w)
+	ctx.CurrentLayer = ecs.LayerSpace
+	ecs.SetWorldContext(w, ctx)
+	ecs.Add(w, p, components.SpaceFlightSprings{})
+	ecs.Add(w, p, components.Velocity{})
+}
+
+// ship gives the player fuel and hull, and the stock drive.
+func ship(fuel, hull int) fixture {
+	return func(w *ecs.World, p ecs.Entity) {
+		ecs.Add(w, p, components.PlayerStats{Fuel: fuel, Hull: hull, Drive: 1})
+	}
+}
+
+// onPlanet lands the player at depth on a planet of biome. Toft Forest is
+// planet 1, as in the game; the other biomes stand in as planet 1234.
+func onPlanet(biome data.BiomeType, depth int) fixture {
+	return func(w *ecs.World, _ ecs.Entity) {
+		ctx := ecs.GetWorldContext(w)
+		ctx.PlanetID = 1
+		if biome != data.BiomeToftForest {
+			ctx.PlanetID = 1234
+		}
+		ctx.BiomeType = int(biome)
+		ctx.Depth = depth
+		ecs.SetWorldContext(w, ctx)
+	}
+}
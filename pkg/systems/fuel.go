@@ -0,0 +1,173 @@
+package systems
+
+import (
+	"math"
+
+	"harvester/pkg/components"
+	"harvester/pkg/data"
+	"harvester/pkg/ecs"
+)
+
+// Fuel economy. PlayerStats.Fuel is the only tank: thrust burns it, scaled
+// by the Drive tier, and galaxy nodes refill it.
+const (
+	// fullBurn is fuel per second at full stock-drive thrust.
+	fullBurn = 2.0
+	// galaxyCharges is how many harvests a fresh galaxy node gives.
+	galaxyCharges = 3
+	// galaxyFuel is the fuel one harvest of a galaxy node pumps aboard.
+	galaxyFuel = 20
+	// driftSpeed is the speed below which a dry ship counts as dead in space.
+	driftSpeed = 0.5
+	// harvestReach is how close the ship must be to harvest a galaxy node.
+	harvestReach = 1
+)
+
+func init() {
+	ecs.RegisterComponent[GalaxyNode]()
+}
+
+// GalaxyNode tracks what is left to harvest from a galaxy tile. Tiles
+// without one are untouched and hold galaxyCharges.
+type GalaxyNode struct{ Charges int }
+
+// fuelBurn carries the fraction of a unit burnt but not yet taken from the
+// tank. It is not persisted; a save loses less than one unit.
+type fuelBurn struct{ Owed float64 }
+
+// FuelSystem burns fuel for thrust actually produced.
+type FuelSystem struct{}
+
+func (FuelSystem) Update(dt float64, w *ecs.World) {
+	ecs.View2Of[components.PlayerStats, components.SpaceFlightSprings](w).Each(func(t ecs.Tuple2[components.PlayerStats, components.SpaceFlightSprings]) {
+		thrust := math.Max(0, t.B.Thrust.Pos)
+		if thrust == 0 || t.A.Fuel <= 0 {
+			return
+		}
+		// thrust is measured against the stock drive so bigger drives
+		// burn more when pushed harder, offset by their efficiency
+		fb, _ := ecs.Get[fuelBurn](w, t.E)
+		fb.Owed += thrust / data.DriveThrust(1) * fullBurn * data.DriveBurn(t.A.Drive) * dt
+		if whole := int(fb.Owed); whole > 0 {
+			t.A.Fuel = max(0, t.A.Fuel-whole)
+			fb.Owed -= float64(whole)
+			ecs.Add(w, t.E, *t.A)
+		}
+		ecs.Add(w, t.E, fb)
+	})
+}
+
+// OutOfFuel reports whether e has a tank and it is empty.
+func OutOfFuel(w *ecs.World, e ecs.Entity) bool {
+	ps, ok := ecs.Get[components.PlayerStats](w, e)
+	return ok && ps.Fuel <= 0
+}
+
+// Refuel adds n fuel to e's tank, up to capacity, and returns how much went in.
+func Refuel(w *ecs.World, e ecs.Entity, n int) int {
+	ps, ok := ecs.Get[components.PlayerStats](w, e)
+	if !ok || n <= 0 {
+		return 0
+	}
+	added := min(n, max(0, data.FuelCapacity-ps.Fuel))
+	ps.Fuel += added
+	ecs.Add(w, e, ps)
+	return added
+}
+
+// GalaxyHarvest lets the ship harvest a galaxy node it is over or beside
+// for fuel, energy and data.
+type GalaxyHarvest struct{}
+
+func (GalaxyHarvest) Update(dt float64, w *ecs.World) {
+	ecs.View2Of[components.Action, components.Position](w).Each(func(t ecs.Tuple2[components.Action, components.Position]) {
+		if !t.A.Harvest {
+			return
+		}
+		ecs.Remove[components.Action](w, t.E)
+		node := galaxyNodeNear(w, int(t.B.X), int(t.B.Y), harvestReach)
+		if node == 0 {
+			itemEvent(w, ecs.Event{Kind: EventItem, Source: t.E}, "There is nothing here to harvest.")
+			return
+		}
+		gn, ok := ecs.Get[GalaxyNode](w, node)
+		if !ok {
+			gn.Charges = galaxyCharges
+		}
+		gn.Charges--
+		ecs.Add(w, node, gn)
+		if gn.Charges == 0 {
+			fadeGalaxyNode(w, node)
+		}
+		fuel := Refuel(w, t.E, galaxyFuel)
+		energy := AddItem(w, t.E, "energy", 2)
+		shards := AddItem(w, t.E, "data", 1)
+		itemEvent(w, ecs.Event{Kind: EventPickup, Source: t.E, Subject: "energy", Amount: energy},
+			"You harvest the galaxy: %d fuel, %d energy, %d data.", fuel, energy, shards)
+	})
+}
+
+// fadeGalaxyNode turns a spent galaxy node into an ordinary star.
+func fadeGalaxyNode(w *ecs.World, node ecs.Entity) {
+	tile, _ := ecs.Get[components.Tile](w, node)
+	tile.Type, tile.Glyph = components.TileStar, '·'
+	ecs.Add(w, node, tile)
+	if r, ok := ecs.Get[components.Renderable](w, node); ok {
+		r.TileType, r.Glyph = components.TileStar, '·'
+		ecs.Add(w, node, r)
+	}
+}
+
+// galaxyNodeNear returns the lowest-numbered harvestable galaxy tile within
+// reach of (x, y), or 0.
+func galaxyNodeNear(w *ecs.World, x, y, reach int) ecs.Entity {
+	var found ecs.Entity
+	ecs.View2Of[components.Tile, components.Position](w).Each(func(t ecs.Tuple2[components.Tile, components.Position]) {
+		if t.A.Type != components.TileGalaxyCore && t.A.Type != components.TileGalaxy {
+			return
+		}
+		if chebyshev(x, y, int(t.B.X), int(t.B.Y)) > reach || (found != 0 && t.E > found) {
+			return
+		}
+		if gn, ok := ecs.Get[GalaxyNode](w, t.E); ok && gn.Charges <= 0 {
+			return
+		}
+		found = t.E
+	})
+	return found
+}
+
+// planetNear reports whether a planet lies within reach of (x, y).
+func planetNear(w *ecs.World, x, y, reach int) bool {
+	near := false
+	ecs.View2Of[PlanetCard, components.Position](w).Each(func(t ecs.Tuple2[PlanetCard, components.Position]) {
+		if chebyshev(x, y, int(t.B.X), int(t.B.Y)) <= reach {
+			near = true
+		}
+	})
+	return near
+}
+
+// Stranding ends the run when the player's ship has run dry, drifted to a
+// stop and has no planet to land on, galaxy node to refuel from or station
+// to dock at.
+type Stranding struct{}
+
+func (Stranding) Update(dt float64, w *ecs.World) {
+	if ecs.GetWorldContext(w).GameOver {
+		return
+	}
+	player, pos, ok := findPlayer(w)
+	if !ok || !OutOfFuel(w, player) {
+		return
+	}
+	v, _ := ecs.Get[components.Velocity](w, player)
+	if math.Hypot(v.VX, v.VY) >= driftSpeed {
+		return
+	}
+	x, y := int(pos.X), int(pos.Y)
+	if planetNear(w, x, y, harvestReach) || galaxyNodeNear(w, x, y, harvestReach) != 0 || stationAt(w, x, y, harvestReach) != 0 {
+		return
+	}
+	EndRun(w, "stranded without fuel")
+}
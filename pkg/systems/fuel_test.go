@@ -0,0 +1,94 @@
+package systems
+
+import (
+	"testing"
+
+	"harvester/pkg/components"
+	"harvester/pkg/ecs"
+)
+
+func spawnGalaxy(w *ecs.World, x, y float64) ecs.Entity {
+	e := w.Create()
+	ecs.Add(w, e, components.Position{X: x, Y: y})
+	ecs.Add(w, e, components.Tile{Type: components.TileGalaxyCore, Glyph: '@'})
+	return e
+}
+
+func fuelOf(w *ecs.World, p ecs.Entity) int {
+	ps, _ := ecs.Get[components.PlayerStats](w, p)
+	return ps.Fuel
+}
+
+func TestFuelBurnsOnlyUnderThrust(t *testing.T) {
+	w, p := newTestWorld(1, inSpace, ship(50, 0))
+	ecs.Add(w, p, components.Velocity{VX: 30})
+	FuelSystem{}.Update(10, w)
+	if got := fuelOf(w, p); got != 50 {
+		t.Fatalf("coasting should not burn fuel, tank at %d", got)
+	}
+	ecs.Add(w, p, components.SpaceFlightSprings{Thrust: components.SpringState{Pos: 50}})
+	for i := 0; i < 20; i++ {
+		FuelSystem{}.Update(0.5, w)
+	}
+	// half thrust for ten seconds burns half of fullBurn per second
+	if got := fuelOf(w, p); got != 40 {
+		t.Fatalf("expected 10 fuel burnt at half thrust, tank at %d", got)
+	}
+}
+
+func TestGalaxyHarvestRefuelsUntilSpent(t *testing.T) {
+	w, p := newTestWorld(1, inSpace, ship(10, 0))
+	node := spawnGalaxy(w, 6, 5)
+	for i := 0; i < galaxyCharges; i++ {
+		ecs.Add(w, p, components.Action{Harvest: true})
+		GalaxyHarvest{}.Update(0, w)
+	}
+	if got := fuelOf(w, p); got != 10+galaxyCharges*galaxyFuel {
+		t.Fatalf("expected %d fuel after harvesting, got %d", 10+galaxyCharges*galaxyFuel, got)
+	}
+	if tile, _ := ecs.Get[components.Tile](w, node); tile.Type != components.TileStar {
+		t.Fatalf("a spent node should fade to a star, got %v", tile.Type)
+	}
+	ecs.Add(w, p, components.Action{Harvest: true})
+	GalaxyHarvest{}.Update(0, w)
+	if got := fuelOf(w, p); got != 10+galaxyCharges*galaxyFuel {
+		t.Fatalf("a spent node should give nothing, got %d fuel", got)
+	}
+	inv, _ := ecs.Get[components.Inventory](w, p)
+	if inv.Count("energy") == 0 || inv.Count("data") == 0 {
+		t.Fatalf("harvesting should yield energy and data, got %+v", inv.Stacks)
+	}
+}
+
+func TestRefuelCapsAtCapacity(t *testing.T) {
+	w, p := newTestWorld(1, inSpace, ship(90, 0))
+	if got := Refuel(w, p, 25); got != 10 || fuelOf(w, p) != 100 {
+		t.Fatalf("expected 10 to go in and a full tank, got %d and %d", got, fuelOf(w, p))
+	}
+}
+
+func TestDryShipHasNoControl(t *testing.T) {
+	w, p := newTestWorld(1, inSpace, ship(0, 0))
+	SetPlayerInput(w, p, "up")
+	InputSystem{}.Update(0.05, w)
+	spr, _ := ecs.Get[components.SpaceFlightSprings](w, p)
+	if spr.Thrust.Target != 0 {
+		t.Fatalf("a dry ship should not thrust, target %v", spr.Thrust.Target)
+	}
+}
+
+func TestStrandedDryShipEndsRun(t *testing.T) {
+	w, _ := newTestWorld(1, inSpace, ship(0, 0))
+	spawnGalaxy(w, 6, 6)
+	Stranding{}.Update(0, w)
+	if ecs.GetWorldContext(w).GameOver {
+		t.Fatal("a galaxy node in reach should keep the run alive")
+	}
+
+	w, _ = newTestWorld(1, inSpace, ship(0, 0))
+	spawnGalaxy(w, 20, 20)
+	Stranding{}.Update(0, w)
+	if ctx := ecs.GetWorldContext(w); !ctx.GameOver || ctx.GameOverReason != "stranded without fuel" {
+		t.Fatalf("expected a stranding game over, got %+v", ctx)
+	}
+}
@@ -0,0 +1,36 @@
+package systems
+
+import (
+	"harvester/pkg/components"
+	"harvester/pkg/ecs"
+)
+
+// EndRun marks the run as over. The first reason recorded wins so a later
+// system cannot overwrite how the player actually died.
+func EndRun(w *ecs.World, reason string) {
+	ctx := ecs.GetWorldContext(w)
+	if ctx.GameOver {
+		return
+	}
+	ctx.GameOver = true
+	ctx.GameOverReason = reason
+	ecs.SetWorldContext(w, ctx)
+}
+
+// PlayerDeath ends the run when the player's hull or health reaches zero.
+type PlayerDeath struct{}
+
+func (PlayerDeath) Update(dt float64, w *ecs.World) {
+	if ecs.GetWorldContext(w).GameOver {
+		return
+	}
+	ecs.View1Of[components.Player](w).Each(func(e ecs.Entity, _ *components.Player) {
+		if ps, ok := ecs.Get[components.PlayerStats](w, e); ok && ps.Hull <= 0 {
+			EndRun(w, "hull destroyed")
+			return
+		}
+		if h, ok := ecs.Get[components.Health](w, e); ok && h.Max > 0 && h.HP <= 0 {
+			EndRun(w, "killed")
+		}
+	})
+}
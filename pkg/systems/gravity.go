@@ -0,0 +1,135 @@
+package systems
+
+import (
+	"math"
+
+	"harvester/pkg/components"
+	"harvester/pkg/ecs"
+)
+
+// EventOrbit is emitted when the player's ship settles into a closed orbit,
+// with the planet card in Target.
+const EventOrbit = "orbit"
+
+// Space flight physics. Ships are stepped at a fixed timestep so that
+// orbits do not drift with the frame rate.
+const (
+	// physicsStep is the fixed timestep, matching the harmonica springs.
+	physicsStep = 1.0 / 60
+	// maxPhysicsSteps caps the catch-up after a long frame.
+	maxPhysicsSteps = 12
+	// planetMass is a planet's gravitational parameter (GM) in tiles³/s² at
+	// a GravityModifier of 1.
+	planetMass = 400.0
+	// soiRadius is how far out a planet's gravity dominates, in tiles.
+	soiRadius = 8.0
+	// planetRadius is the planet's surface; orbits must clear it.
+	planetRadius = 1.0
+	// landingSpeed is the fastest the ship may be moving relative to a
+	// planet and still set down on it.
+	landingSpeed = 4.0
+)
+
+func init() {
+	ecs.RegisterComponent[Gravity]()
+}
+
+// Gravity gives a space body mass. Mass is its gravitational parameter and
+// SOI the radius of its sphere of influence.
+type Gravity struct {
+	Mass float64
+	SOI  float64
+}
+
+// physicsClock carries time not yet stepped. It is not persisted.
+type physicsClock struct{ Acc float64 }
+
+// orbitState remembers which body the ship is orbiting so EventOrbit fires
+// once per orbit. It is not persisted.
+type orbitState struct {
+	Body     ecs.Entity
+	Achieved bool
+}
+
+// dominantBody returns the body whose sphere of influence holds (x, y),
+// the nearest if several do, or 0 in free space.
+func dominantBody(w *ecs.World, x, y float64) (ecs.Entity, Gravity, components.Position) {
+	var (
+		body ecs.Entity
+		g    Gravity
+		at   components.Position
+		best = math.Inf(1)
+	)
+	for _, e := range sortedWith[Gravity](w) {
+		gr, _ := ecs.Get[Gravity](w, e)
+		p, ok := ecs.Get[components.Position](w, e)
+		if !ok {
+			continue
+		}
+		if d := math.Hypot(p.X-x, p.Y-y); d <= gr.SOI && d < best {
+			body, g, at, best = e, gr, p, d
+		}
+	}
+	return body, g, at
+}
+
+// gravityAt returns the pull of the dominant body at (x, y). Inside the
+// surface the pull is softened so the ship is not flung out of the core.
+func gravityAt(w *ecs.World, x, y float64) (ax, ay float64, body ecs.Entity) {
+	body, g, at := dominantBody(w, x, y)
+	if body == 0 {
+		return 0, 0, 0
+	}
+	dx, dy := at.X-x, at.Y-y
+	d := math.Hypot(dx, dy)
+	if d == 0 {
+		return 0, 0, body
+	}
+	a := g.Mass / math.Max(d*d, planetRadius*planetRadius)
+	return a * dx / d, a * dy / d, body
+}
+
+// orbitAround reports whether a ship at pos with velocity vel is on a closed
+// orbit around body that clears the surface and stays inside the sphere of
+// influence.
+func orbitAround(w *ecs.World, body ecs.Entity, pos components.Position, vel components.Velocity) bool {
+	g, ok := ecs.Get[Gravity](w, body)
+	at, found := ecs.Get[components.Position](w, body)
+	if !ok || !found || g.Mass <= 0 {
+		return false
+	}
+	rx, ry := pos.X-at.X, pos.Y-at.Y
+	r := math.Hypot(rx, ry)
+	if r == 0 {
+		return false
+	}
+	energy := (vel.VX*vel.VX+vel.VY*vel.VY)/2 - g.Mass/r
+	if energy >= 0 {
+		return false
+	}
+	a := -g.Mass / (2 * energy)
+	h := rx*vel.VY - ry*vel.VX
+	e := math.Sqrt(math.Max(0, 1+2*energy*h*h/(g.Mass*g.Mass)))
+	return a*(1-e) > planetRadius && a*(1+e) < g.SOI
+}
+
+// trackOrbit updates e's orbit state after a step and announces a newly
+// achieved orbit.
+func trackOrbit(w *ecs.World, e, body ecs.Entity, pos components.Position, vel components.Velocity) {
+	st, _ := ecs.Get[orbitState](w, e)
+	achieved := body != 0 && orbitAround(w, body, pos, vel)
+	if achieved && (!st.Achieved || st.Body != body) {
+		name := "the planet"
+		if card, ok := ecs.Get[PlanetCard](w, body); ok && card.Planet.Name != "" {
+			name = card.Planet.Name
+		}
+		itemEvent(w, ecs.Event{Kind: EventOrbit, Source: e, Target: body}, "Orbit achieved around %s.", name)
+	}
+	ecs.Add(w, e, orbitState{Body: body, Achieved: achieved})
+}
+
+// InOrbit returns the body e is orbiting, if any.
+func InOrbit(w *ecs.World, e ecs.Entity) (ecs.Entity, bool) {
+	st, _ := ecs.Get[orbitState](w, e)
+	return st.Body, st.Achieved
+}
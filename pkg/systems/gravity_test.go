@@ -0,0 +1,121 @@
+package systems
+
+import (
+	"math"
+	"testing"
+
+	"harvester/pkg/components"
+	"harvester/pkg/ecs"
+)
+
+// addOrbitPlanet puts a planet with gravity at (50, 40).
+func addOrbitPlanet(w *ecs.World) ecs.Entity {
+	planet := w.Create()
+	ecs.Add(w, planet, components.Position{X: 50, Y: 40})
+	ecs.Add(w, planet, components.Renderable{Glyph: '1'})
+	ecs.Add(w, planet, PlanetCard{Index: 0})
+	ecs.Add(w, planet, Gravity{Mass: planetMass, SOI: soiRadius})
+	return planet
+}
+
+func TestCircularOrbitHolds(t *testing.T) {
+	w, p := newTestWorld(1, inSpace, ship(100, 100), mapSize(200, 80))
+	planet := addOrbitPlanet(w)
+	const r = 4.0
+	ecs.Add(w, p, components.Position{X: 50 + r, Y: 40})
+	ecs.Add(w, p, components.Velocity{VY: math.Sqrt(planetMass / r)})
+	seq := w.Emit(ecs.Event{})
+	for i := 0; i < 600; i++ {
+		SpaceMovement{}.Update(1.0/60, w)
+		pos, _ := ecs.Get[components.Position](w, p)
+		if d := math.Hypot(pos.X-50, pos.Y-40); d < r-0.5 || d > r+0.5 {
+			t.Fatalf("orbit decayed or escaped at step %d: radius %.2f", i, d)
+		}
+	}
+	if body, ok := InOrbit(w, p); !ok || body != planet {
+		t.Fatalf("expected to be in orbit around %d, got %d %v", planet, body, ok)
+	}
+	events, _ := w.EventsSince(seq)
+	orbits := 0
+	for _, ev := range events {
+		if ev.Kind == EventOrbit {
+			orbits++
+		}
+	}
+	if orbits != 1 {
+		t.Fatalf("expected one orbit announcement, got %d", orbits)
+	}
+}
+
+func TestFlybySlingshotsShip(t *testing.T) {
+	w, p := newTestWorld(1, inSpace, ship(100, 100), mapSize(200, 80))
+	addOrbitPlanet(w)
+	ecs.Add(w, p, components.Position{X: 43, Y: 38})
+	ecs.Add(w, p, components.Velocity{VX: 15})
+	for i := 0; i < 120; i++ {
+		SpaceMovement{}.Update(1.0/60, w)
+	}
+	v, _ := ecs.Get[components.Velocity](w, p)
+	if v.VY <= 0 {
+		t.Fatalf("the planet should bend the ship's course toward it, velocity %+v", v)
+	}
+	if _, ok := InOrbit(w, p); ok {
+		t.Fatal("a fast flyby is not an orbit")
+	}
+}
+
+func TestFixedTimestepIgnoresFrameRate(t *testing.T) {
+	run := func(frames int) components.Position {
+		w, p := newTestWorld(1, inSpace, ship(100, 100), mapSize(200, 80))
+		addOrbitPlanet(w)
+		ecs.Add(w, p, components.Position{X: 54, Y: 40})
+		ecs.Add(w, p, components.Velocity{VY: 10})
+		for i := 0; i < frames; i++ {
+			SpaceMovement{}.Update(2.0/float64(frames), w)
+		}
+		pos, _ := ecs.Get[components.Position](w, p)
+		return pos
+	}
+	a, b := run(120), run(40)
+	if math.Abs(a.X-b.X) > 1e-6 || math.Abs(a.Y-b.Y) > 1e-6 {
+		t.Fatalf("same time at different frame rates should agree: %+v vs %+v", a, b)
+	}
+}
+
+func TestLandingNeedsLowSpeed(t *testing.T) {
+	w, p := newTestWorld(1, inSpace, ship(100, 100), mapSize(200, 80))
+	addOrbitPlanet(w)
+	ecs.Add(w, p, components.Position{X: 50, Y: 40})
+	ecs.Add(w, p, components.Velocity{VX: landingSpeed + 2})
+	ecs.Add(w, p, EnterPlanet{})
+	PlanetApproachSystem{}.Update(0, w)
+	if ctx := ecs.GetWorldContext(w); ctx.CurrentLayer != ecs.LayerSpace {
+		t.Fatal("a fast ship should not land")
+	}
+	if _, pressed := ecs.Get[EnterPlanet](w, p); pressed {
+		t.Fatal("a refused landing should spend the key press")
+	}
+
+	ecs.Add(w, p, components.Velocity{VX: landingSpeed / 2})
+	ecs.Add(w, p, EnterPlanet{})
+	PlanetApproachSystem{}.Update(0, w)
+	if ctx := ecs.GetWorldContext(w); ctx.CurrentLayer != ecs.LayerPlanetSurface {
+		t.Fatal("a slow ship should land")
+	}
+}
+
+func TestPlanetCardsPersistInSpace(t *testing.T) {
+	w, _ := newTestWorld(1, inSpace, ship(100, 100), mapSize(200, 80))
+	planet := addOrbitPlanet(w)
+	LevelManager{}.Update(0, w)
+	if _, ok := ecs.Get[Gravity](w, planet); !ok {
+		t.Fatal("planet cards should survive in space")
+	}
+	ctx := ecs.GetWorldContext(w)
+	ctx.CurrentLayer = ecs.LayerPlanetSurface
+	ecs.SetWorldContext(w, ctx)
+	LevelManager{}.Update(0, w)
+	if _, ok := ecs.Get[Gravity](w, planet); ok {
+		t.Fatal("planet cards should be cleared off the space layer")
+	}
+}
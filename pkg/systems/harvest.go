@@ -2,17 +2,20 @@ package systems
 
 import (
 	"harvester/pkg/components"
+	"harvester/pkg/data"
 	"harvester/pkg/ecs"
 )
 
 type Harvest struct{}
 
 func (Harvest) Update(dt float64, w *ecs.World) {
-	// Triggered by Action.Harvest
+	// Triggered by Action.Harvest; the action is used up whether or not
+	// anything was there to pick up
 	ecs.View2Of[components.Action, components.Position](w).Each(func(t ecs.Tuple2[components.Action, components.Position]) {
 		if !t.A.Harvest {
 			return
 		}
+		ecs.Remove[components.Action](w, t.E)
 		// find resource at same position
 		target := ecs.Entity(0)
 		var res components.Resource
@@ -25,12 +28,27 @@ func (Harvest) Update(dt float64, w *ecs.World) {
 		if target == 0 {
 			return
 		}
-		inv, _ := ecs.Get[components.Inventory](w, t.E)
-		if inv.Items == nil {
-			inv.Items = make(map[string]int)
+		// whatever does not fit in the hold stays on the ground
+		got := AddItem(w, t.E, res.Kind, res.Amount)
+		def, _ := data.Item(res.Kind)
+		switch {
+		case got == 0:
+			itemEvent(w, ecs.Event{Kind: EventItem, Source: t.E, Subject: res.Kind}, "You have no room for the %s.", def.Name)
+			return
+		case got < res.Amount:
+			itemEvent(w, ecs.Event{Kind: EventPickup, Source: t.E, Subject: res.Kind, Amount: got}, "You pick up %d %s; the rest won't fit.", got, def.Name)
+		default:
+			itemEvent(w, ecs.Event{Kind: EventPickup, Source: t.E, Subject: res.Kind, Amount: got}, "You pick up %d %s.", got, def.Name)
+		}
+		if res.Amount -= got; res.Amount > 0 {
+			ecs.Add(w, target, res)
+			return
 		}
-		inv.Items[res.Kind] += res.Amount
-		ecs.Add(w, t.E, inv)
 		ecs.Remove[components.Resource](w, target)
+		if _, isPile := ecs.Get[components.Renderable](w, target); isPile {
+			if _, isTile := ecs.Get[components.Tile](w, target); !isTile {
+				w.Destroy(target)
+			}
+		}
 	})
 }
@@ -0,0 +1,239 @@
+package systems
+
+import (
+	"image/color"
+	"math"
+
+	"github.com/charmbracelet/lipgloss/v2"
+	"harvester/pkg/components"
+	"harvester/pkg/data"
+	"harvester/pkg/ecs"
+)
+
+// EventHazard is emitted when a space hazard acts on the player's ship, with
+// the hazard's data.TileKind in Amount.
+const EventHazard = "hazard"
+
+// Space hazard tuning, from the catalogue in docs/CONTENT.md.
+const (
+	// blackHolePull is the gravity constant: acceleration at one tile.
+	blackHolePull = 500.0
+	// blackHoleReach is how far a black hole's pull is felt, in tiles.
+	blackHoleReach = 8.0
+	// eventHorizon is the radius past which nothing but a warp blink gets out.
+	eventHorizon = 1.0
+	// horizonDamage is hull lost per second beyond the horizon.
+	horizonDamage = 10
+	// wormholeMouth is how close the ship must come to fall through.
+	wormholeMouth = 0.75
+	// nebulaDrag is the fraction of speed a nebula bleeds off per second.
+	nebulaDrag = 1.5
+	// nebulaSight is the ship's sensor range inside a nebula, before Sensors.
+	nebulaSight = 4
+	// anomalyReach is how close the ship must come to set off an anomaly.
+	anomalyReach = 1.0
+	// anomalyJump bounds how far an anomaly can throw the ship.
+	anomalyJump = 20
+	// hazardClearance keeps black holes and wormholes apart from other
+	// bodies when a sector is generated.
+	hazardClearance = 12.0
+)
+
+func init() {
+	ecs.RegisterComponent[Hazard]()
+}
+
+// Hazard is a space hazard from the data.TileKind catalogue. Radius is the
+// reach of its effect; Pair links the two mouths of a wormhole.
+type Hazard struct {
+	Kind   data.TileKind
+	Radius float64
+	Pair   ecs.Entity
+}
+
+// hazardState remembers what the ship is caught in between frames so that
+// events fire on entry and wormholes do not bounce. It is not persisted.
+type hazardState struct {
+	Wormhole ecs.Entity // mouth the ship came out of
+	Nebula   bool
+	Horizon  bool
+	Crush    float64 // seconds spent beyond the horizon since the last hit
+}
+
+// nebulaTint is the purple wash laid over nebula cells.
+var nebulaTint color.Color = lipgloss.Color("97")
+
+func spawnHazard(w *ecs.World, x, y float64, h Hazard) ecs.Entity {
+	e := w.Create()
+	ecs.Add(w, e, components.Position{X: x, Y: y})
+	ecs.Add(w, e, h)
+	ecs.Add(w, e, hazardLook(h.Kind))
+	ecs.Add(w, e, SpaceBody{})
+	return e
+}
+
+// hazardLook is how each hazard kind is drawn, using the shared
+// ColorModifier effects.
+func hazardLook(kind data.TileKind) components.Renderable {
+	switch kind {
+	case data.BlackHole:
+		return components.Renderable{Glyph: '●', TileType: components.TileBlackHole,
+			StyleMod: &components.ColorModifier{Special: components.EffectPulsing, PulseRate: 2}}
+	case data.Wormhole:
+		return components.Renderable{Glyph: '◎', TileType: components.TileWormhole,
+			StyleMod: &components.ColorModifier{Special: components.EffectPulsing, PulseRate: 5}}
+	case data.Nebula:
+		return components.Renderable{Glyph: '░', TileType: components.TileNebula,
+			StyleMod: &components.ColorModifier{TintColor: &nebulaTint}}
+	default:
+		return components.Renderable{Glyph: '?', TileType: components.TileAnomaly,
+			StyleMod: &components.ColorModifier{Special: components.EffectTwinkling}}
+	}
+}
+
+// hazardPull returns the acceleration black holes exert on a ship at (x, y)
+// and the fraction of its speed nebulae bleed off over dt.
+func hazardPull(w *ecs.World, x, y, dt float64) (ax, ay, drag float64) {
+	ecs.View2Of[Hazard, components.Position](w).Each(func(t ecs.Tuple2[Hazard, components.Position]) {
+		dx, dy := t.B.X-x, t.B.Y-y
+		d := math.Hypot(dx, dy)
+		switch t.A.Kind {
+		case data.BlackHole:
+			if d > t.A.Radius || d == 0 {
+				return
+			}
+			a := blackHolePull / math.Max(d*d, 1)
+			ax += a * dx / d
+			ay += a * dy / d
+		case data.Nebula:
+			if d <= t.A.Radius {
+				drag = math.Min(1, nebulaDrag*dt)
+			}
+		}
+	})
+	return ax, ay, drag
+}
+
+// applyHazards runs the positional hazards after the ship e has moved from
+// prev to pos: the event horizon, wormhole mouths and anomalies. The horizon
+// is checked along the whole move so a fast ship cannot skip over it. It
+// returns the ship's position and velocity after any of them has had its way.
+func applyHazards(w *ecs.World, e ecs.Entity, prev, pos components.Position, vel components.Velocity, dt float64) (components.Position, components.Velocity) {
+	st, _ := ecs.Get[hazardState](w, e)
+	var (
+		horizon, nebula, mouth, anomaly ecs.Entity
+		horizonPos                      components.Position
+	)
+	for _, h := range sortedWith[Hazard](w) {
+		hz, _ := ecs.Get[Hazard](w, h)
+		hp, _ := ecs.Get[components.Position](w, h)
+		d := math.Hypot(hp.X-pos.X, hp.Y-pos.Y)
+		switch {
+		case hz.Kind == data.BlackHole && segmentDist(prev, pos, hp) < eventHorizon && horizon == 0:
+			horizon, horizonPos = h, hp
+		case hz.Kind == data.Nebula && d <= hz.Radius:
+			nebula = h
+		case hz.Kind == data.Wormhole && d < hz.Radius && mouth == 0:
+			mouth = h
+		case hz.Kind == data.Anomaly && d < hz.Radius && anomaly == 0:
+			anomaly = h
+		}
+	}
+
+	if nebula != 0 && !st.Nebula {
+		hazardEvent(w, e, data.Nebula, "You drift into a nebula; sensors fade.")
+	}
+	st.Nebula = nebula != 0
+
+	if horizon != 0 {
+		// past the horizon the ship is held at the core and crushed
+		if !st.Horizon {
+			hazardEvent(w, e, data.BlackHole, "You cross the event horizon!")
+		}
+		pos.X, pos.Y = horizonPos.X, horizonPos.Y
+		vel = components.Velocity{}
+		st.Crush += dt
+		for ; st.Crush >= 1; st.Crush-- {
+			HazardDamage(w, e, horizonDamage)
+		}
+	}
+	st.Horizon = horizon != 0
+
+	switch {
+	case mouth != 0 && mouth != st.Wormhole:
+		hz, _ := ecs.Get[Hazard](w, mouth)
+		if exit, ok := ecs.Get[components.Position](w, hz.Pair); ok {
+			pos.X, pos.Y = exit.X, exit.Y
+			st.Wormhole = hz.Pair
+			hazardEvent(w, e, data.Wormhole, "The wormhole swallows you and spits you out elsewhere.")
+		}
+	case mouth == 0:
+		st.Wormhole = 0
+	}
+
+	if anomaly != 0 {
+		pos, vel = triggerAnomaly(w, e, anomaly, pos, vel)
+	}
+	ecs.Add(w, e, st)
+	return pos, vel
+}
+
+// triggerAnomaly rolls an anomaly's random event and spends the anomaly.
+func triggerAnomaly(w *ecs.World, e, anomaly ecs.Entity, pos components.Position, vel components.Velocity) (components.Position, components.Velocity) {
+	r := w.Rand()
+	switch r.Intn(4) {
+	case 0:
+		n := Refuel(w, e, 15)
+		hazardEvent(w, e, data.Anomaly, "The anomaly bathes the ship in energy: %d fuel.", n)
+	case 1:
+		lost := HazardDamage(w, e, 10)
+		hazardEvent(w, e, data.Anomaly, "The anomaly lashes the hull for %d.", lost)
+	case 2:
+		pos.X += float64(r.Intn(2*anomalyJump+1) - anomalyJump)
+		pos.Y += float64(r.Intn(2*anomalyJump+1) - anomalyJump)
+		vel = components.Velocity{}
+		hazardEvent(w, e, data.Anomaly, "The anomaly folds space and flings you away.")
+	default:
+		n := AddItem(w, e, "data", 3)
+		hazardEvent(w, e, data.Anomaly, "The anomaly leaves strange readings: %d data.", n)
+	}
+	w.Destroy(anomaly)
+	return pos, vel
+}
+
+// segmentDist is the distance from p to the segment a-b.
+func segmentDist(a, b, p components.Position) float64 {
+	dx, dy := b.X-a.X, b.Y-a.Y
+	l2 := dx*dx + dy*dy
+	if l2 == 0 {
+		return math.Hypot(p.X-a.X, p.Y-a.Y)
+	}
+	t := math.Max(0, math.Min(1, ((p.X-a.X)*dx+(p.Y-a.Y)*dy)/l2))
+	return math.Hypot(p.X-(a.X+t*dx), p.Y-(a.Y+t*dy))
+}
+
+// inNebula reports whether (x, y) lies in a nebula cell.
+func inNebula(w *ecs.World, x, y float64) bool {
+	found := false
+	ecs.View2Of[Hazard, components.Position](w).Each(func(t ecs.Tuple2[Hazard, components.Position]) {
+		if t.A.Kind == data.Nebula && math.Hypot(t.B.X-x, t.B.Y-y) <= t.A.Radius {
+			found = true
+		}
+	})
+	return found
+}
+
+// NebulaSight is the player's sensor range while inside a nebula, or 0 when
+// the sensors are clear.
+func NebulaSight(w *ecs.World) int {
+	player, pos, ok := findPlayer(w)
+	if !ok || !inNebula(w, pos.X, pos.Y) {
+		return 0
+	}
+	ps, _ := ecs.Get[components.PlayerStats](w, player)
+	return nebulaSight + sensorSight*ps.Sensors
+}
+
+func hazardEvent(w *ecs.World, e ecs.Entity, kind data.TileKind, format string, args ...any) {
+	itemEvent(w, ecs.Event{Kind: EventHazard, Source: e, Amount: int(kind)}, format, args...)
+}
@@ -0,0 +1,109 @@
+package systems
+
+import (
+	"math"
+	"testing"
+
+	"harvester/pkg/components"
+	"harvester/pkg/data"
+	"harvester/pkg/ecs"
+)
+
+func TestBlackHolePullsShipIn(t *testing.T) {
+	w, p := newTestWorld(1, inSpace, ship(100, 100), mapSize(200, 80))
+	spawnHazard(w, 10, 5, Hazard{Kind: data.BlackHole, Radius: blackHoleReach})
+	SpaceMovement{}.Update(0.1, w)
+	v, _ := ecs.Get[components.Velocity](w, p)
+	if v.VX <= 0 || math.Abs(v.VY) > 1e-9 {
+		t.Fatalf("expected a pull toward the black hole, velocity %+v", v)
+	}
+
+	// out of reach there is no pull
+	w, p = newTestWorld(1, inSpace, ship(100, 100), mapSize(200, 80))
+	spawnHazard(w, 50, 5, Hazard{Kind: data.BlackHole, Radius: blackHoleReach})
+	SpaceMovement{}.Update(0.1, w)
+	if v, _ := ecs.Get[components.Velocity](w, p); v.VX != 0 {
+		t.Fatalf("expected no pull out of reach, velocity %+v", v)
+	}
+}
+
+func TestEventHorizonHoldsAndCrushes(t *testing.T) {
+	hullAfter := func(shield int) (int, components.Position) {
+		w, p := newTestWorld(1, inSpace, ship(100, 100), mapSize(200, 80))
+		ps, _ := ecs.Get[components.PlayerStats](w, p)
+		ps.Shield = shield
+		ecs.Add(w, p, ps)
+		spawnHazard(w, 5.5, 5, Hazard{Kind: data.BlackHole, Radius: blackHoleReach})
+		for i := 0; i < 35; i++ {
+			SpaceMovement{}.Update(0.1, w)
+		}
+		ps, _ = ecs.Get[components.PlayerStats](w, p)
+		pos, _ := ecs.Get[components.Position](w, p)
+		return ps.Hull, pos
+	}
+	hull, pos := hullAfter(0)
+	if pos.X != 5.5 || pos.Y != 5 {
+		t.Fatalf("the ship should be held at the core, at %+v", pos)
+	}
+	if hull != 100-3*horizonDamage {
+		t.Fatalf("expected three seconds of crushing, hull at %d", hull)
+	}
+	if shielded, _ := hullAfter(3); shielded <= hull {
+		t.Fatalf("a shield should soften the crush: %d vs %d", shielded, hull)
+	}
+}
+
+func TestWormholeTeleportsWithoutBouncing(t *testing.T) {
+	w, p := newTestWorld(1, inSpace, ship(100, 100), mapSize(200, 80))
+	a := spawnHazard(w, 5, 5, Hazard{Kind: data.Wormhole, Radius: wormholeMouth})
+	b := spawnHazard(w, 100, 40, Hazard{Kind: data.Wormhole, Radius: wormholeMouth, Pair: a})
+	ecs.Add(w, a, Hazard{Kind: data.Wormhole, Radius: wormholeMouth, Pair: b})
+	SpaceMovement{}.Update(0.05, w)
+	if pos, _ := ecs.Get[components.Position](w, p); pos.X != 100 || pos.Y != 40 {
+		t.Fatalf("expected to come out of the far mouth, at %+v", pos)
+	}
+	SpaceMovement{}.Update(0.05, w)
+	if pos, _ := ecs.Get[components.Position](w, p); pos.X != 100 || pos.Y != 40 {
+		t.Fatalf("the ship should not bounce straight back, at %+v", pos)
+	}
+}
+
+func TestNebulaDragsAndDampensSensors(t *testing.T) {
+	speed := func(nebula bool) float64 {
+		w, p := newTestWorld(1, inSpace, ship(100, 100), mapSize(200, 80))
+		if nebula {
+			spawnHazard(w, 5, 5, Hazard{Kind: data.Nebula, Radius: 0.5})
+		}
+		ecs.Add(w, p, components.Velocity{VX: 10})
+		SpaceMovement{}.Update(0.1, w)
+		v, _ := ecs.Get[components.Velocity](w, p)
+		return v.VX
+	}
+	if in, out := speed(true), speed(false); in >= out {
+		t.Fatalf("a nebula should slow the ship: %v vs %v", in, out)
+	}
+
+	w, _ := newTestWorld(1, inSpace, ship(100, 100), mapSize(200, 80))
+	if vis := VisibilityOf(w); vis(150, 70) != VisVisible {
+		t.Fatal("clear space should be fully visible")
+	}
+	spawnHazard(w, 5, 5, Hazard{Kind: data.Nebula, Radius: 0.5})
+	vis := VisibilityOf(w)
+	if vis(5+nebulaSight, 5) != VisVisible || vis(150, 70) != VisRemembered {
+		t.Fatal("inside a nebula only nearby space should be in view")
+	}
+}
+
+func TestAnomalyFiresOnce(t *testing.T) {
+	w, p := newTestWorld(1, inSpace, ship(100, 100), mapSize(200, 80))
+	an := spawnHazard(w, 5, 5, Hazard{Kind: data.Anomaly, Radius: anomalyReach})
+	seq := w.Emit(ecs.Event{})
+	SpaceMovement{}.Update(0.05, w)
+	if _, ok := ecs.Get[Hazard](w, an); ok {
+		t.Fatal("an anomaly should be spent once triggered")
+	}
+	events, _ := w.EventsSince(seq)
+	if len(events) != 1 || events[0].Kind != EventHazard || events[0].Source != p {
+		t.Fatalf("expected one hazard event, got %+v", events)
+	}
+}
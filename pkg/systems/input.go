@@ -2,6 +2,7 @@ package systems
 
 import (
 	"harvester/pkg/components"
+	"harvester/pkg/data"
 	"harvester/pkg/debug"
 	"harvester/pkg/ecs"
 )
@@ -20,9 +21,12 @@ func (InputSystem) Update(dt float64, w *ecs.World) {
 	isSpace := ctx.CurrentLayer == ecs.LayerSpace
 	if isSpace {
 		ecs.View1Of[components.Input](w).Each(func(e ecs.Entity, in *components.Input) {
+			if OutOfFuel(w, e) {
+				return
+			}
 			spr, _ := ecs.Get[components.SpaceFlightSprings](w, e)
 			if in.Up {
-				spr.Thrust.Target = 100
+				spr.Thrust.Target = data.DriveThrust(UpgradeTier(w, e, data.Drive))
 			} else {
 				spr.Thrust.Target = 0
 			}
@@ -61,6 +65,9 @@ func (InputSystem) Update(dt float64, w *ecs.World) {
 func SetPlayerInput(w *ecs.World, e ecs.Entity, dir string) {
 	debug.Debugf("input", "Player input: %s for entity %d", dir, e)
 	in, _ := ecs.Get[components.Input](w, e)
+	if dir != "clear" && dir != "scan" {
+		ecs.Remove[AutoTravel](w, e)
+	}
 	switch dir {
 	case "left":
 		in.Left, in.Right, in.Up, in.Down = true, false, false, false
@@ -74,6 +81,15 @@ func SetPlayerInput(w *ecs.World, e ecs.Entity, dir string) {
 		debug.Info("input", "Player entering planet")
 		in.Left, in.Right, in.Up, in.Down = false, false, false, false
 		ecs.Add(w, e, EnterPlanet{})
+	case "scan":
+		ecs.Add(w, e, ScanRequest{})
+	case "warp":
+		ecs.Add(w, e, WarpIntent{})
+	case "harvest":
+		ecs.Add(w, e, components.Action{Harvest: true})
+	case "wait":
+		in = components.Input{}
+		ecs.Add(w, e, WaitIntent{})
 	case "clear":
 		// no-op retain last state
 	default:
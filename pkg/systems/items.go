@@ -0,0 +1,370 @@
+package systems
+
+import (
+	"fmt"
+	"math"
+	"sort"
+
+	"harvester/pkg/components"
+	"harvester/pkg/data"
+	"harvester/pkg/ecs"
+	"harvester/pkg/pathfind"
+)
+
+// Event kinds for items. EventPickup carries the item id in Subject and the
+// count in Amount; EventItem covers drops, throws, use and equipment.
+const (
+	EventItem   = "item"
+	EventPickup = "pickup"
+)
+
+// throwRange is how far, in tiles, a thrown item can fly.
+const throwRange = 6
+
+// UseIntent consumes one Item, or toggles it if it is equipment.
+type UseIntent struct{ Item string }
+
+// DropIntent puts Count of Item on the ground under the holder.
+type DropIntent struct {
+	Item  string
+	Count int
+}
+
+// ThrowIntent throws one Item in direction (DX, DY).
+type ThrowIntent struct {
+	Item   string
+	DX, DY int
+}
+
+// EquipIntent equips Item in its slot, or unequips it if already worn.
+type EquipIntent struct{ Item string }
+
+// Items resolves item intents in entity order and emits a message for each.
+type Items struct{}
+
+func (Items) Update(dt float64, w *ecs.World) {
+	for _, e := range sortedWith[UseIntent](w) {
+		in, _ := ecs.Get[UseIntent](w, e)
+		ecs.Remove[UseIntent](w, e)
+		UseItem(w, e, in.Item)
+	}
+	for _, e := range sortedWith[EquipIntent](w) {
+		in, _ := ecs.Get[EquipIntent](w, e)
+		ecs.Remove[EquipIntent](w, e)
+		ToggleEquip(w, e, in.Item)
+	}
+	for _, e := range sortedWith[DropIntent](w) {
+		in, _ := ecs.Get[DropIntent](w, e)
+		ecs.Remove[DropIntent](w, e)
+		DropItem(w, e, in.Item, in.Count)
+	}
+	for _, e := range sortedWith[ThrowIntent](w) {
+		in, _ := ecs.Get[ThrowIntent](w, e)
+		ecs.Remove[ThrowIntent](w, e)
+		ThrowItem(w, e, in.Item, in.DX, in.DY)
+	}
+}
+
+// sortedWith lists the entities holding a T in entity order.
+func sortedWith[T any](w *ecs.World) []ecs.Entity {
+	var es []ecs.Entity
+	ecs.View1Of[T](w).Each(func(e ecs.Entity, _ *T) { es = append(es, e) })
+	sort.Slice(es, func(i, j int) bool { return es[i] < es[j] })
+	return es
+}
+
+// CarryCapacity returns the limits on what e can carry. Only the player is
+// limited, by the hold of their Cargo tier.
+func CarryCapacity(w *ecs.World, e ecs.Entity) (data.CargoHold, bool) {
+	if _, ok := ecs.Get[components.Player](w, e); !ok {
+		return data.CargoHold{}, false
+	}
+	ps, _ := ecs.Get[components.PlayerStats](w, e)
+	return data.CargoCapacity(ps.Cargo), true
+}
+
+// InventoryLoad returns the total weight and volume of inv.
+func InventoryLoad(inv components.Inventory) (weight, volume float64) {
+	for _, s := range inv.Stacks {
+		def, _ := data.Item(s.Item)
+		weight += def.Weight * float64(s.Count)
+		volume += def.Volume * float64(s.Count)
+	}
+	return weight, volume
+}
+
+// AddItem puts up to n of item into e's inventory and returns how many fit.
+// Existing stacks are topped up before new ones are opened.
+func AddItem(w *ecs.World, e ecs.Entity, item string, n int) int {
+	inv, _ := ecs.Get[components.Inventory](w, e)
+	def, _ := data.Item(item)
+	hold, limited := CarryCapacity(w, e)
+	n = min(n, roomFor(inv, def, hold, limited))
+	if n <= 0 {
+		return 0
+	}
+	stackAdd(&inv, def, n)
+	ecs.Add(w, e, inv)
+	return n
+}
+
+// RemoveItem takes up to n of item out of e's inventory, emptying the newest
+// stacks first, and returns how many were removed. Equipment is unequipped
+// when the last one goes.
+func RemoveItem(w *ecs.World, e ecs.Entity, item string, n int) int {
+	inv, ok := ecs.Get[components.Inventory](w, e)
+	if !ok || n <= 0 {
+		return 0
+	}
+	if inv.Count(item) <= n {
+		unequip(w, e, &inv, item)
+	}
+	removed := stackRemove(&inv, item, n)
+	ecs.Add(w, e, inv)
+	return removed
+}
+
+// roomFor returns how many units of def still fit in inv under hold.
+func roomFor(inv components.Inventory, def data.ItemDef, hold data.CargoHold, limited bool) int {
+	if !limited {
+		return math.MaxInt
+	}
+	weight, volume := InventoryLoad(inv)
+	return min(fitting(hold.Weight-weight, def.Weight), fitting(hold.Volume-volume, def.Volume))
+}
+
+// fitting returns how many units of size each fit in room.
+func fitting(room, each float64) int {
+	if each <= 0 {
+		return math.MaxInt
+	}
+	if room <= 0 {
+		return 0
+	}
+	return int(math.Floor(room/each + 1e-9))
+}
+
+// stackAdd adds n of def to inv, topping up stacks before opening new ones.
+func stackAdd(inv *components.Inventory, def data.ItemDef, n int) {
+	for i := range inv.Stacks {
+		if n == 0 {
+			return
+		}
+		s := &inv.Stacks[i]
+		if s.Item != def.ID || s.Count >= def.MaxStack {
+			continue
+		}
+		add := min(n, def.MaxStack-s.Count)
+		s.Count += add
+		n -= add
+	}
+	for n > 0 {
+		add := min(n, def.MaxStack)
+		inv.Stacks = append(inv.Stacks, components.ItemStack{Item: def.ID, Count: add})
+		n -= add
+	}
+}
+
+// stackRemove takes up to n of item from inv, newest stacks first, and
+// returns how many it took.
+func stackRemove(inv *components.Inventory, item string, n int) int {
+	removed := 0
+	for i := len(inv.Stacks) - 1; i >= 0 && removed < n; i-- {
+		s := &inv.Stacks[i]
+		if s.Item != item {
+			continue
+		}
+		take := min(n-removed, s.Count)
+		s.Count -= take
+		removed += take
+		if s.Count == 0 {
+			inv.Stacks = append(inv.Stacks[:i], inv.Stacks[i+1:]...)
+		}
+	}
+	return removed
+}
+
+// UseItem applies one consumable's effect to e, or toggles equipment.
+func UseItem(w *ecs.World, e ecs.Entity, item string) bool {
+	inv, _ := ecs.Get[components.Inventory](w, e)
+	def, _ := data.Item(item)
+	if inv.Count(item) == 0 {
+		itemEvent(w, ecs.Event{Kind: EventItem, Source: e, Subject: item}, "You have no %s.", def.Name)
+		return false
+	}
+	if def.Slot != data.SlotNone {
+		return ToggleEquip(w, e, item)
+	}
+	if def.Category != data.ItemConsumable {
+		itemEvent(w, ecs.Event{Kind: EventItem, Source: e, Subject: item}, "You can't use the %s.", def.Name)
+		return false
+	}
+	RemoveItem(w, e, item, 1)
+	if def.Use.Heal > 0 {
+		if h, ok := ecs.Get[components.Health](w, e); ok {
+			h.HP = min(h.Max, h.HP+def.Use.Heal)
+			ecs.Add(w, e, h)
+		}
+	}
+	if def.Use.Hull > 0 {
+		if ps, ok := ecs.Get[components.PlayerStats](w, e); ok {
+			ps.Hull = min(data.HullCapacity, ps.Hull+def.Use.Hull)
+			ecs.Add(w, e, ps)
+		}
+	}
+	if def.Use.Fuel > 0 {
+		Refuel(w, e, def.Use.Fuel)
+	}
+	itemEvent(w, ecs.Event{Kind: EventItem, Source: e, Subject: item}, "You use the %s.", def.Name)
+	return true
+}
+
+// ToggleEquip equips item in its slot, replacing whatever was there, or
+// takes it off if it is already equipped. Bonuses go straight onto e's Melee
+// and Armor.
+func ToggleEquip(w *ecs.World, e ecs.Entity, item string) bool {
+	inv, _ := ecs.Get[components.Inventory](w, e)
+	def, _ := data.Item(item)
+	if def.Slot == data.SlotNone || inv.Count(item) == 0 {
+		itemEvent(w, ecs.Event{Kind: EventItem, Source: e, Subject: item}, "You can't equip the %s.", def.Name)
+		return false
+	}
+	slot := def.Slot.String()
+	if inv.Equipped[slot] == item {
+		unequip(w, e, &inv, item)
+		ecs.Add(w, e, inv)
+		itemEvent(w, ecs.Event{Kind: EventItem, Source: e, Subject: item}, "You take off the %s.", def.Name)
+		return true
+	}
+	if old := inv.Equipped[slot]; old != "" {
+		unequip(w, e, &inv, old)
+	}
+	if inv.Equipped == nil {
+		inv.Equipped = make(map[string]string)
+	}
+	inv.Equipped[slot] = item
+	applyBonus(w, e, def, 1)
+	ecs.Add(w, e, inv)
+	itemEvent(w, ecs.Event{Kind: EventItem, Source: e, Subject: item}, "You equip the %s.", def.Name)
+	return true
+}
+
+// unequip removes item from whichever slot holds it and takes its bonus back.
+func unequip(w *ecs.World, e ecs.Entity, inv *components.Inventory, item string) {
+	def, _ := data.Item(item)
+	slot := def.Slot.String()
+	if inv.Equipped[slot] != item {
+		return
+	}
+	delete(inv.Equipped, slot)
+	applyBonus(w, e, def, -1)
+}
+
+func applyBonus(w *ecs.World, e ecs.Entity, def data.ItemDef, sign int) {
+	if def.Damage != 0 {
+		if m, ok := ecs.Get[components.Melee](w, e); ok {
+			m.MinDamage += sign * def.Damage
+			m.MaxDamage += sign * def.Damage
+			ecs.Add(w, e, m)
+		}
+	}
+	if def.Armor != 0 {
+		a, _ := ecs.Get[components.Armor](w, e)
+		a.Value += sign * def.Armor
+		ecs.Add(w, e, a)
+	}
+}
+
+// DropItem moves up to n of item from e's inventory to a pile on e's tile.
+func DropItem(w *ecs.World, e ecs.Entity, item string, n int) int {
+	pos, ok := ecs.Get[components.Position](w, e)
+	if !ok {
+		return 0
+	}
+	n = RemoveItem(w, e, item, n)
+	if n == 0 {
+		return 0
+	}
+	SpawnPile(w, int(pos.X), int(pos.Y), item, n)
+	def, _ := data.Item(item)
+	itemEvent(w, ecs.Event{Kind: EventItem, Source: e, Subject: item, Amount: -n}, "You drop %d %s.", n, def.Name)
+	return n
+}
+
+// ThrowItem throws one item from e along (dx, dy). It hits the first
+// creature in its path, stops short of walls and lands as a pile;
+// consumables break on landing.
+func ThrowItem(w *ecs.World, e ecs.Entity, item string, dx, dy int) bool {
+	pos, ok := ecs.Get[components.Position](w, e)
+	if !ok || (dx == 0 && dy == 0) || RemoveItem(w, e, item, 1) == 0 {
+		return false
+	}
+	def, _ := data.Item(item)
+	nav := Navigation(w)
+	x, y := int(pos.X), int(pos.Y)
+	var hit ecs.Entity
+	for i := 0; i < throwRange; i++ {
+		nx, ny := x+isign(dx), y+isign(dy)
+		if nav.Grid.Width() > 0 && (nav.Grid.Cost(nx, ny) == pathfind.Impassable || nav.Opaque(nx, ny)) {
+			break
+		}
+		x, y = nx, ny
+		if hit = fighterAt(w, x, y); hit != 0 && hit != e {
+			break
+		}
+		hit = 0
+	}
+	itemEvent(w, ecs.Event{Kind: EventItem, Source: e, Subject: item}, "You throw the %s.", def.Name)
+	if hit != 0 {
+		InflictDamage(w, e, hit, max(1, def.ThrowDamage))
+	}
+	if def.Category != data.ItemConsumable {
+		SpawnPile(w, x, y, item, 1)
+	}
+	return true
+}
+
+// SpawnPile leaves n of item on the ground at (x, y), merging with a pile of
+// the same kind already there. Piles are Resources, so Harvest picks them up.
+func SpawnPile(w *ecs.World, x, y int, item string, n int) ecs.Entity {
+	var pile ecs.Entity
+	ecs.View2Of[components.Resource, components.Position](w).Each(func(t ecs.Tuple2[components.Resource, components.Position]) {
+		if pile == 0 && t.A.Kind == item && int(t.B.X) == x && int(t.B.Y) == y {
+			pile = t.E
+		}
+	})
+	if pile != 0 {
+		r, _ := ecs.Get[components.Resource](w, pile)
+		r.Amount += n
+		ecs.Add(w, pile, r)
+		return pile
+	}
+	def, _ := data.Item(item)
+	pile = w.Create()
+	ecs.Add(w, pile, components.Position{X: float64(x), Y: float64(y)})
+	ecs.Add(w, pile, components.Resource{Kind: item, Amount: n})
+	ecs.Add(w, pile, components.Renderable{Glyph: pileGlyph(def.Category), TileType: components.TileComet})
+	ecs.Add(w, pile, components.Name{Text: def.Name})
+	return pile
+}
+
+func pileGlyph(c data.ItemCategory) rune {
+	switch c {
+	case data.ItemConsumable:
+		return '!'
+	case data.ItemEquipment:
+		return '('
+	case data.ItemQuest:
+		return '?'
+	}
+	return ','
+}
+
+// itemEvent emits ev with a message; only the player's actions are logged.
+func itemEvent(w *ecs.World, ev ecs.Event, format string, args ...any) {
+	if _, ok := ecs.Get[components.Player](w, ev.Source); !ok {
+		return
+	}
+	ev.Text = fmt.Sprintf(format, args...)
+	w.Emit(ev)
+}
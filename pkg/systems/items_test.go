@@ -0,0 +1,120 @@
+package systems
+
+import (
+	"harvester/pkg/components"
+	"harvester/pkg/data"
+	"harvester/pkg/ecs"
+	"testing"
+)
+
+func TestAddItemSplitsStacks(t *testing.T) {
+	w, p := newTestWorld(1)
+	ecs.Add(w, p, components.PlayerStats{Cargo: 3})
+	def, _ := data.Item("herbs")
+	if got := AddItem(w, p, "herbs", def.MaxStack+5); got != def.MaxStack+5 {
+		t.Fatalf("added %d", got)
+	}
+	inv, _ := ecs.Get[components.Inventory](w, p)
+	if len(inv.Stacks) != 2 || inv.Stacks[0].Count != def.MaxStack || inv.Stacks[1].Count != 5 {
+		t.Fatalf("stacks %+v", inv.Stacks)
+	}
+	if RemoveItem(w, p, "herbs", 6) != 6 {
+		t.Fatal("remove should take all requested")
+	}
+	inv, _ = ecs.Get[components.Inventory](w, p)
+	if len(inv.Stacks) != 1 || inv.Count("herbs") != def.MaxStack-1 {
+		t.Fatalf("newest stack should empty first, stacks %+v", inv.Stacks)
+	}
+}
+
+func TestCargoTierLimitsWeight(t *testing.T) {
+	w, p := newTestWorld(1)
+	ore, _ := data.Item("ore")
+	hold := data.CargoCapacity(0)
+	want := int(hold.Weight / ore.Weight)
+	if got := AddItem(w, p, "ore", 1000); got != want {
+		t.Fatalf("stock hold took %d ore, want %d", got, want)
+	}
+	if AddItem(w, p, "ore", 1) != 0 {
+		t.Fatal("a full hold should refuse more")
+	}
+	ecs.Add(w, p, components.PlayerStats{Cargo: 1})
+	if AddItem(w, p, "ore", 1) != 1 {
+		t.Fatal("a bigger hold should take more")
+	}
+}
+
+func TestEquipAndUse(t *testing.T) {
+	w, p := newTestWorld(1)
+	AddItem(w, p, "knife", 1)
+	AddItem(w, p, "herbs", 1)
+	base, _ := ecs.Get[components.Melee](w, p)
+
+	ecs.Add(w, p, EquipIntent{Item: "knife"})
+	Items{}.Update(0, w)
+	m, _ := ecs.Get[components.Melee](w, p)
+	inv, _ := ecs.Get[components.Inventory](w, p)
+	if inv.Equipped["weapon"] != "knife" || m.MaxDamage != base.MaxDamage+2 {
+		t.Fatalf("knife should be equipped with its bonus, melee %+v", m)
+	}
+	DropItem(w, p, "knife", 1)
+	if m, _ := ecs.Get[components.Melee](w, p); m != base {
+		t.Fatalf("dropping the knife should remove its bonus, melee %+v", m)
+	}
+
+	ecs.Add(w, p, components.Health{HP: 2, Max: 10})
+	ecs.Add(w, p, UseIntent{Item: "herbs"})
+	Items{}.Update(0, w)
+	if h, _ := ecs.Get[components.Health](w, p); h.HP != 8 {
+		t.Fatalf("herbs should heal 6, HP %d", h.HP)
+	}
+	if inv, _ := ecs.Get[components.Inventory](w, p); inv.Count("herbs") != 0 {
+		t.Fatal("herbs should be used up")
+	}
+
+	ecs.Add(w, p, components.PlayerStats{Hull: data.HullCapacity - 5})
+	AddItem(w, p, "repair_kit", 1)
+	ecs.Add(w, p, UseIntent{Item: "repair_kit"})
+	Items{}.Update(0, w)
+	if ps, _ := ecs.Get[components.PlayerStats](w, p); ps.Hull != data.HullCapacity {
+		t.Fatalf("a repair should stop at a whole hull, hull %d", ps.Hull)
+	}
+}
+
+func TestDropAndPickUp(t *testing.T) {
+	w, p := newTestWorld(1)
+	AddItem(w, p, "ore", 5)
+	ecs.Add(w, p, DropIntent{Item: "ore", Count: 3})
+	Items{}.Update(0, w)
+	if inv, _ := ecs.Get[components.Inventory](w, p); inv.Count("ore") != 2 {
+		t.Fatalf("ore left %d", inv.Count("ore"))
+	}
+	SetPlayerInput(w, p, "harvest")
+	Harvest{}.Update(0, w)
+	if inv, _ := ecs.Get[components.Inventory](w, p); inv.Count("ore") != 5 {
+		t.Fatalf("picked back up to %d", inv.Count("ore"))
+	}
+	piles := 0
+	ecs.View1Of[components.Resource](w).Each(func(ecs.Entity, *components.Resource) { piles++ })
+	if piles != 0 {
+		t.Fatal("an emptied pile should be removed")
+	}
+}
+
+func TestThrowHitsFirstCreature(t *testing.T) {
+	w, p := newTestWorld(1)
+	AddItem(w, p, "spear", 1)
+	foe := spawnFoe(w, 8, 5, 20)
+	ecs.Add(w, p, ThrowIntent{Item: "spear", DX: 1})
+	Items{}.Update(0, w)
+	if h, _ := ecs.Get[components.Health](w, foe); h.HP != 14 {
+		t.Fatalf("spear should deal 6, HP %d", h.HP)
+	}
+	var landed components.Position
+	ecs.View2Of[components.Resource, components.Position](w).Each(func(t ecs.Tuple2[components.Resource, components.Position]) {
+		landed = *t.B
+	})
+	if landed.X != 8 || landed.Y != 5 {
+		t.Fatalf("spear should land under the target, at %v,%v", landed.X, landed.Y)
+	}
+}
@@ -0,0 +1,141 @@
+package systems
+
+import (
+	"harvester/pkg/components"
+	"harvester/pkg/data"
+	"harvester/pkg/ecs"
+)
+
+// Event kinds emitted by Launch. EventLaunch carries the planet left behind
+// in Amount; EventGrounded explains why the ship cannot lift off yet.
+const (
+	EventLaunch   = "launch"
+	EventGrounded = "grounded"
+)
+
+func init() {
+	ecs.RegisterComponent[LandingSite]()
+	ecs.RegisterComponent[SpaceBody]()
+	ecs.RegisterComponent[Departure]()
+}
+
+// Departure is where the player's ship left space to land. The surface map
+// has its own coordinates, so lifting off puts the ship back here.
+type Departure struct{ X, Y float64 }
+
+// LandingSite is the grounded ship on a planet.
+type LandingSite struct{ PlanetID int }
+
+// SpaceBody marks scenery that belongs to the space layer. Everything else
+// with a position is planet-side and is cleared away on launch.
+type SpaceBody struct{}
+
+// Launch sets the ship down where the player arrives on a planet and lifts
+// off when the player presses enter aboard it with the planet's escape quest
+// done, if the planet has one. A planet already left once holds the ship no
+// more.
+type Launch struct{}
+
+func (Launch) Update(dt float64, w *ecs.World) {
+	ctx := ecs.GetWorldContext(w)
+	if ctx.CurrentLayer == ecs.LayerSpace {
+		return
+	}
+	player, pos, ok := findPlayer(w)
+	if !ok {
+		return
+	}
+	sx, sy, found := landingSite(w, ctx.PlanetID)
+	if !found && ctx.CurrentLayer == ecs.LayerPlanetSurface {
+		site := w.Create()
+		ecs.Add(w, site, components.Position{X: float64(int(pos.X)), Y: float64(int(pos.Y))})
+		ecs.Add(w, site, components.Renderable{Glyph: 'Δ', TileType: components.TilePlanet})
+		ecs.Add(w, site, components.Name{Text: "your ship"})
+		ecs.Add(w, site, LandingSite{PlanetID: ctx.PlanetID})
+		sx, sy, found = int(pos.X), int(pos.Y), true
+	}
+	if _, pressed := ecs.Get[EnterPlanet](w, player); !pressed {
+		return
+	}
+	ecs.Remove[EnterPlanet](w, player)
+	switch {
+	case ctx.CurrentLayer != ecs.LayerPlanetSurface || !found || int(pos.X) != sx || int(pos.Y) != sy:
+		grounded(w, player, "You need to be aboard your ship to launch.")
+	case hasEscapeQuest(ctx) && !ctx.PlanetCompleted(ctx.PlanetID) && !ctx.QuestProgress.RoyalCharterComplete:
+		grounded(w, player, "The ship is grounded until you finish this planet's escape quest.")
+	default:
+		LiftOff(w, player)
+	}
+}
+
+// LiftOff takes the player from the planet back to space. The player keeps
+// inventory, stats and equipment; the planet is recorded as completed, the
+// level is stashed in the LevelCache, everything planet-side is cleared from
+// the map and the ship is put back where it left space.
+func LiftOff(w *ecs.World, player ecs.Entity) {
+	ctx := ecs.GetWorldContext(w)
+	if ctx.CurrentLayer == ecs.LayerSpace {
+		return
+	}
+	planet := ctx.PlanetID
+	// keep the level for a return visit; its terrain is cleared below
+	StashLevel(w, planet, ctx.Depth)
+	if !ctx.PlanetCompleted(planet) {
+		ctx.CompletedPlanets = append(ctx.CompletedPlanets, planet)
+	}
+	ctx.CurrentLayer = ecs.LayerSpace
+	ctx.Depth = 0
+	ctx.QuestProgress.RoyalCharterComplete = false
+	ctx.QuestProgress.ContractsCollected = 0
+	ecs.SetWorldContext(w, ctx)
+
+	var planetSide []ecs.Entity
+	ecs.View1Of[components.Position](w).Each(func(e ecs.Entity, _ *components.Position) {
+		if e == player {
+			return
+		}
+		if _, ok := ecs.Get[SpaceBody](w, e); !ok {
+			planetSide = append(planetSide, e)
+		}
+	})
+	for _, e := range planetSide {
+		w.Destroy(e)
+	}
+	if d, ok := ecs.Get[Departure](w, player); ok {
+		ecs.Add(w, player, components.Position{X: d.X, Y: d.Y})
+		ecs.Remove[Departure](w, player)
+	}
+	// the next landing generates its terrain afresh
+	ecs.Remove[TerrainLevel](w, 1)
+	ecs.Add(w, player, components.Velocity{})
+	ecs.Add(w, player, components.Input{})
+	ecs.Remove[AutoTravel](w, player)
+	w.Emit(ecs.Event{Kind: EventLaunch, Source: player, Amount: planet,
+		Text: "The ship lifts off and climbs back into space."})
+}
+
+// hasEscapeQuest reports whether the planet landed on holds the ship until
+// a quest is done.
+func hasEscapeQuest(ctx ecs.WorldContext) bool {
+	for _, q := range data.QuestsFor(ctx.PlanetID, data.BiomeType(ctx.BiomeType)) {
+		if q.EscapeReward {
+			return true
+		}
+	}
+	return false
+}
+
+// landingSite returns where the ship is grounded on planet.
+func landingSite(w *ecs.World, planet int) (int, int, bool) {
+	x, y, found := 0, 0, false
+	ecs.View2Of[LandingSite, components.Position](w).Each(func(t ecs.Tuple2[LandingSite, components.Position]) {
+		if t.A.PlanetID == planet && !found {
+			x, y, found = int(t.B.X), int(t.B.Y), true
+		}
+	})
+	return x, y, found
+}
+
+func grounded(w *ecs.World, player ecs.Entity, text string) {
+	w.Emit(ecs.Event{Kind: EventGrounded, Source: player, Text: text})
+}
@@ -0,0 +1,84 @@
+package systems
+
+import (
+	"testing"
+
+	"harvester/pkg/components"
+	"harvester/pkg/ecs"
+)
+
+func TestLaunchNeedsEscapeQuestAndShip(t *testing.T) {
+	w, p := newTestWorld(1)
+	ctx := ecs.GetWorldContext(w)
+	ctx.PlanetID = 1
+	ecs.SetWorldContext(w, ctx)
+	star := w.Create()
+	ecs.Add(w, star, components.Position{X: 20, Y: 20})
+	ecs.Add(w, star, SpaceBody{})
+	ecs.Add(w, p, components.PlayerStats{Hull: 70, Drive: 2})
+	AddItem(w, p, "herbs", 3)
+
+	Launch{}.Update(0, w)
+	if _, _, ok := landingSite(w, 1); !ok {
+		t.Fatal("arriving should ground the ship under the player")
+	}
+	ecs.Add(w, p, EnterPlanet{})
+	Launch{}.Update(0, w)
+	if ecs.GetWorldContext(w).CurrentLayer != ecs.LayerPlanetSurface {
+		t.Fatal("launch must wait for the escape quest")
+	}
+
+	ctx = ecs.GetWorldContext(w)
+	ctx.QuestProgress.RoyalCharterComplete = true
+	ecs.SetWorldContext(w, ctx)
+	ecs.Add(w, p, components.Position{X: 8, Y: 5})
+	ecs.Add(w, p, EnterPlanet{})
+	Launch{}.Update(0, w)
+	if ecs.GetWorldContext(w).CurrentLayer != ecs.LayerPlanetSurface {
+		t.Fatal("launch must happen aboard the ship")
+	}
+
+	foe := spawnFoe(w, 9, 9, 5)
+	ecs.Add(w, p, components.Position{X: 5, Y: 5})
+	ecs.Add(w, p, EnterPlanet{})
+	Launch{}.Update(0, w)
+	ctx = ecs.GetWorldContext(w)
+	if ctx.CurrentLayer != ecs.LayerSpace || !ctx.PlanetCompleted(1) || ctx.QuestProgress.RoyalCharterComplete {
+		t.Fatalf("expected launch to space with planet 1 completed, got %+v", ctx)
+	}
+	if _, ok := ecs.Get[components.Position](w, foe); ok {
+		t.Error("planet-side entities should be cleared")
+	}
+	if _, ok := ecs.Get[components.Position](w, star); !ok {
+		t.Error("space scenery should survive a launch")
+	}
+	if _, _, ok := landingSite(w, 1); ok {
+		t.Error("the landing site goes with the ship")
+	}
+	ps, _ := ecs.Get[components.PlayerStats](w, p)
+	inv, _ := ecs.Get[components.Inventory](w, p)
+	if ps.Hull != 70 || ps.Drive != 2 || inv.Count("herbs") != 3 {
+		t.Fatalf("player should keep hull, upgrades and cargo: %+v %+v", ps, inv.Stacks)
+	}
+}
+
+func TestRelandingDoesNotGroundTheShip(t *testing.T) {
+	w, p := newTestWorld(1)
+	ctx := ecs.GetWorldContext(w)
+	ctx.PlanetID = 1
+	ctx.QuestProgress.RoyalCharterComplete = true
+	ecs.SetWorldContext(w, ctx)
+	for visit := 0; visit < 2; visit++ {
+		ctx = ecs.GetWorldContext(w)
+		ctx.CurrentLayer = ecs.LayerPlanetSurface
+		ctx.PlanetID = 1
+		ecs.SetWorldContext(w, ctx)
+		ecs.Add(w, p, components.Position{X: 5, Y: 5})
+		Launch{}.Update(0, w)
+		ecs.Add(w, p, EnterPlanet{})
+		Launch{}.Update(0, w)
+		if ecs.GetWorldContext(w).CurrentLayer != ecs.LayerSpace {
+			t.Fatalf("visit %d: the ship should lift off from a planet whose escape quest is done", visit+1)
+		}
+	}
+}
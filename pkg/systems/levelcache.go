@@ -0,0 +1,191 @@
+package systems
+
+import (
+	"harvester/pkg/components"
+	"harvester/pkg/debug"
+	"harvester/pkg/ecs"
+)
+
+// Levels keep what happened on them. When the player leaves a level, by
+// stairs or by lifting off, everything on it but its terrain is written to a
+// snapshot in the LevelCache and cleared away, along with the player's fog of
+// war of it; the terrain is rebuilt from the level's seed on return and the
+// snapshot put back on top.
+
+func init() {
+	ecs.RegisterComponent[LevelCache]()
+	ecs.RegisterComponent[PlanetClock]()
+}
+
+// maxCachedLevels is how many levels are remembered. Past it the level left
+// longest ago is forgotten and will be generated afresh, so very deep runs
+// do not grow the save file without bound.
+const maxCachedLevels = 32
+
+// Time away catches up on restored levels: creatures heal a hit point every
+// healSeconds and wander home after homeSeconds.
+const (
+	healSeconds = 10.0
+	homeSeconds = 30.0
+)
+
+// LevelCache holds the levels the player has left, the one left longest ago
+// first. It lives on entity 1 and is saved with the game.
+type LevelCache struct {
+	Levels []LevelSnapshot
+}
+
+// LevelSnapshot is what was on a level when the player left it and what
+// they had seen of it. LeftAt is the PlanetClock at the time.
+type LevelSnapshot struct {
+	PlanetID, Depth int
+	LeftAt          float64
+	Entities        []ecs.EntityState
+	Fog             *components.FogMemory
+}
+
+// PlanetClock counts the seconds of turns taken on planets. It lives on
+// entity 1 and is advanced by TurnScheduler.
+type PlanetClock struct{ Seconds float64 }
+
+func advanceClock(w *ecs.World, seconds float64) {
+	c, _ := ecs.Get[PlanetClock](w, 1)
+	c.Seconds += seconds
+	ecs.Add(w, 1, c)
+}
+
+// levelContents returns everything on the map that belongs to the level:
+// all positioned entities but the player, space scenery and terrain.
+func levelContents(w *ecs.World) []ecs.Entity {
+	player, _, _ := findPlayer(w)
+	var out []ecs.Entity
+	ecs.View1Of[components.Position](w).Each(func(e ecs.Entity, _ *components.Position) {
+		if e == player {
+			return
+		}
+		if _, ok := ecs.Get[SpaceBody](w, e); ok {
+			return
+		}
+		if _, ok := ecs.Get[TerrainTile](w, e); ok {
+			return
+		}
+		out = append(out, e)
+	})
+	return out
+}
+
+// StashLevel snapshots the contents of the level of planet at depth into
+// the cache and removes them from the map, and takes the player's fog of it
+// with them. Entities that fail to encode are lost with a warning rather
+// than holding the player on the level.
+func StashLevel(w *ecs.World, planet, depth int) {
+	cache, _ := ecs.Get[LevelCache](w, 1)
+	clock, _ := ecs.Get[PlanetClock](w, 1)
+	snap := LevelSnapshot{PlanetID: planet, Depth: depth, LeftAt: clock.Seconds}
+	if player, _, ok := findPlayer(w); ok {
+		if mem, ok := ecs.Get[components.FogMemory](w, player); ok && mem.PlanetID == planet && mem.Depth == depth {
+			snap.Fog = &mem
+		}
+		ecs.Remove[components.FogMemory](w, player)
+	}
+	for _, e := range levelContents(w) {
+		s, err := ecs.SaveEntity(w, e)
+		if err != nil {
+			debug.Warnf("levels", "dropping entity %d from planet %d depth %d: %v", e, planet, depth, err)
+		} else {
+			snap.Entities = append(snap.Entities, s)
+		}
+		w.Destroy(e)
+	}
+	cache.Levels = append(dropLevel(cache.Levels, planet, depth), snap)
+	if n := len(cache.Levels) - maxCachedLevels; n > 0 {
+		cache.Levels = append([]LevelSnapshot(nil), cache.Levels[n:]...)
+	}
+	ecs.Add(w, 1, cache)
+}
+
+// RestoreLevel puts back the snapshot of planet at depth and the player's
+// fog of it, if one is cached, and takes it out of the cache. Unless frozen, the level first catches up
+// on the time the player was away. It reports whether there was one.
+func RestoreLevel(w *ecs.World, planet, depth int, frozen bool) bool {
+	cache, _ := ecs.Get[LevelCache](w, 1)
+	snap, ok := cachedLevel(cache, planet, depth)
+	if !ok {
+		return false
+	}
+	cache.Levels = dropLevel(cache.Levels, planet, depth)
+	ecs.Add(w, 1, cache)
+	if player, _, ok := findPlayer(w); ok && snap.Fog != nil {
+		ecs.Add(w, player, *snap.Fog)
+	}
+	var restored []ecs.Entity
+	for _, s := range snap.Entities {
+		e, err := ecs.RestoreEntity(w, s)
+		if err != nil {
+			debug.Warnf("levels", "dropping an entity of planet %d depth %d: %v", planet, depth, err)
+			continue
+		}
+		// whatever a creature was after may be gone or renumbered
+		if b, ok := ecs.Get[Brain](w, e); ok {
+			b.Target, b.State = 0, AIIdle
+			ecs.Add(w, e, b)
+		}
+		restored = append(restored, e)
+	}
+	if !frozen {
+		clock, _ := ecs.Get[PlanetClock](w, 1)
+		catchUp(w, restored, clock.Seconds-snap.LeftAt)
+	}
+	return true
+}
+
+// LevelCached reports whether planet at depth has a snapshot.
+func LevelCached(w *ecs.World, planet, depth int) bool {
+	cache, _ := ecs.Get[LevelCache](w, 1)
+	_, ok := cachedLevel(cache, planet, depth)
+	return ok
+}
+
+func cachedLevel(cache LevelCache, planet, depth int) (LevelSnapshot, bool) {
+	for _, l := range cache.Levels {
+		if l.PlanetID == planet && l.Depth == depth {
+			return l, true
+		}
+	}
+	return LevelSnapshot{}, false
+}
+
+func dropLevel(levels []LevelSnapshot, planet, depth int) []LevelSnapshot {
+	out := levels[:0:0]
+	for _, l := range levels {
+		if l.PlanetID != planet || l.Depth != depth {
+			out = append(out, l)
+		}
+	}
+	return out
+}
+
+// catchUp moves restored entities on by elapsed seconds: remains decay,
+// creatures heal and, after long enough, are back home.
+func catchUp(w *ecs.World, entities []ecs.Entity, elapsed float64) {
+	if elapsed <= 0 {
+		return
+	}
+	for _, e := range entities {
+		if t, ok := ecs.Get[DecayTimer](w, e); ok {
+			t.Elapsed += elapsed
+			if t.Elapsed >= t.Duration {
+				w.Destroy(e)
+				continue
+			}
+			ecs.Add(w, e, t)
+		}
+		if h, ok := ecs.Get[components.Health](w, e); ok && h.HP < h.Max {
+			h.HP = min(h.Max, h.HP+int(elapsed/healSeconds))
+			ecs.Add(w, e, h)
+		}
+		if b, ok := ecs.Get[Brain](w, e); ok && elapsed >= homeSeconds {
+			ecs.Add(w, e, components.Position{X: float64(b.HomeX), Y: float64(b.HomeY)})
+		}
+	}
+}
@@ -0,0 +1,149 @@
+package systems
+
+import (
+	"encoding/json"
+	"math/rand"
+	"testing"
+
+	"harvester/pkg/components"
+	"harvester/pkg/data"
+	"harvester/pkg/ecs"
+)
+
+// pileAt returns the amount of item lying on (x, y).
+func pileAt(w *ecs.World, x, y int, item string) int {
+	n := 0
+	ecs.View2Of[components.Resource, components.Position](w).Each(func(t ecs.Tuple2[components.Resource, components.Position]) {
+		if t.A.Kind == item && int(t.B.X) == x && int(t.B.Y) == y {
+			n += t.A.Amount
+		}
+	})
+	return n
+}
+
+func TestLevelsKeepWhatHappenedOnThem(t *testing.T) {
+	w, p := newTestWorld(1, mapSize(200, 80), playerAt(100, 40), onPlanet(data.BiomeIce, 0))
+	TerrainGen{}.Update(0, w)
+	SpawnPile(w, 90, 40, "wood", 3)
+
+	takeStairs(t, w, p, true)
+	if pileAt(w, 90, 40, "wood") != 0 {
+		t.Fatal("the surface's pile should not follow the player down")
+	}
+	SpawnPile(w, 10, 10, "ore", 2)
+
+	takeStairs(t, w, p, false)
+	if got := pileAt(w, 90, 40, "wood"); got != 3 {
+		t.Fatalf("the pile dropped on the surface should be back, got %d", got)
+	}
+	if pileAt(w, 10, 10, "ore") != 0 {
+		t.Fatal("the pile left below should stay below")
+	}
+	takeStairs(t, w, p, true)
+	if got := pileAt(w, 10, 10, "ore"); got != 2 {
+		t.Fatalf("the pile left below should be there on return, got %d", got)
+	}
+}
+
+func TestVaultLootIsNotRestocked(t *testing.T) {
+	w, _ := newTestWorld(1, mapSize(200, 80), playerAt(100, 40), onPlanet(data.BiomeVolcanic, 0))
+	ctx := ecs.GetWorldContext(w)
+	bottom := landedPlanet(w, ctx).MaxDepth
+	goTo := func(depth int) {
+		ctx.CurrentLayer = ecs.LayerPlanetDeep
+		ctx.Depth = depth
+		ecs.SetWorldContext(w, ctx)
+		TerrainGen{}.Update(0, w)
+	}
+	loot := func() []ecs.Entity {
+		var out []ecs.Entity
+		ecs.View1Of[components.Resource](w).Each(func(e ecs.Entity, r *components.Resource) {
+			if r.Kind == "heat_crystal" {
+				out = append(out, e)
+			}
+		})
+		return out
+	}
+	goTo(bottom)
+	piles := loot()
+	if len(piles) != 1 {
+		t.Fatalf("expected the vault's loot, got %d piles", len(piles))
+	}
+	w.Destroy(piles[0]) // picked up
+	goTo(prevDepth(ctx, bottom, bottom))
+	goTo(bottom)
+	if n := len(loot()); n != 0 {
+		t.Fatalf("an emptied vault should stay empty, got %d piles", n)
+	}
+}
+
+func TestTimeAwayCatchesUp(t *testing.T) {
+	for _, frozen := range []bool{false, true} {
+		w, _ := newTestWorld(1)
+		wolf := spawnCreature(w, 20, 20, true)
+		ecs.Add(w, wolf, components.Health{HP: 1, Max: 10})
+		ecs.Add(w, wolf, components.Position{X: 30, Y: 25})
+		StashLevel(w, 7, 15)
+		advanceClock(w, 120)
+		if !RestoreLevel(w, 7, 15, frozen) {
+			t.Fatal("the level should be cached")
+		}
+		var h components.Health
+		var pos components.Position
+		ecs.View2Of[Brain, components.Health](w).Each(func(t ecs.Tuple2[Brain, components.Health]) {
+			h = *t.B
+			pos, _ = ecs.Get[components.Position](w, t.E)
+		})
+		switch {
+		case !frozen && (h.HP != 10 || pos.X != 20 || pos.Y != 20):
+			t.Fatalf("two minutes away should heal the wolf and send it home: %+v at %+v", h, pos)
+		case frozen && (h.HP != 1 || pos.X != 30):
+			t.Fatalf("a frozen level should come back as left: %+v at %+v", h, pos)
+		}
+		if LevelCached(w, 7, 15) {
+			t.Fatal("a restored level should leave the cache")
+		}
+	}
+}
+
+func TestLevelCacheForgetsTheOldestLevels(t *testing.T) {
+	w, _ := newTestWorld(1)
+	for depth := 0; depth < maxCachedLevels+5; depth++ {
+		SpawnPile(w, 3, 3, "ore", 1)
+		StashLevel(w, 7, depth)
+	}
+	cache, _ := ecs.Get[LevelCache](w, 1)
+	if len(cache.Levels) != maxCachedLevels {
+		t.Fatalf("expected %d cached levels, got %d", maxCachedLevels, len(cache.Levels))
+	}
+	if LevelCached(w, 7, 4) || !LevelCached(w, 7, 5) {
+		t.Fatal("the levels left longest ago should go first")
+	}
+}
+
+func TestLevelCacheIsSaved(t *testing.T) {
+	w, p := newTestWorld(1)
+	SpawnPile(w, 4, 4, "herbs", 5)
+	mem := components.NewFogMemory(7, int(ecs.LayerPlanetDeep), 15, 10, 10)
+	mem.Mark(3, 3)
+	ecs.Add(w, p, mem)
+	StashLevel(w, 7, 15)
+	if _, ok := ecs.Get[components.FogMemory](w, p); ok {
+		t.Fatal("the fog of a level left should go with it")
+	}
+	s, err := ecs.Save(w, json.Marshal)
+	if err != nil {
+		t.Fatal(err)
+	}
+	w2 := ecs.NewWorld(rand.New(rand.NewSource(2)))
+	if _, err := ecs.Load(w2, s, json.Unmarshal); err != nil {
+		t.Fatal(err)
+	}
+	ecs.Add(w2, p, components.Player{})
+	if !RestoreLevel(w2, 7, 15, false) || pileAt(w2, 4, 4, "herbs") != 5 {
+		t.Fatal("the cached level should come back from the save")
+	}
+	if mem, _ := ecs.Get[components.FogMemory](w2, p); !mem.Has(3, 3) || mem.Depth != 15 {
+		t.Fatalf("the level's fog should come back with it, got %+v", mem)
+	}
+}
@@ -9,16 +9,26 @@ type LevelManager struct{}
 
 func (LevelManager) Update(dt float64, w *ecs.World) {
 	ctx := ecs.GetWorldContext(w)
-	_ = ctx
-	// destroy space visuals: stars, planet cards
+	// destroy space visuals: stars, and planet cards once off the space
+	// layer; in space the cards stay put since their gravity is simulated
 	toDestroy := make([]ecs.Entity, 0, 128)
+	visual := func(e ecs.Entity, glyph rune) bool {
+		if glyph == '*' {
+			return true
+		}
+		if glyph < '1' || glyph > '3' {
+			return false
+		}
+		_, card := ecs.Get[PlanetCard](w, e)
+		return !card || ctx.CurrentLayer != ecs.LayerSpace
+	}
 	ecs.View2Of[components.Tile, components.Position](w).Each(func(t ecs.Tuple2[components.Tile, components.Position]) {
-		if t.A.Glyph == '*' || (t.A.Glyph >= '1' && t.A.Glyph <= '3') {
+		if visual(t.E, t.A.Glyph) {
 			toDestroy = append(toDestroy, t.E)
 		}
 	})
 	ecs.View2Of[components.Renderable, components.Position](w).Each(func(t ecs.Tuple2[components.Renderable, components.Position]) {
-		if t.A.Glyph == '*' || (t.A.Glyph >= '1' && t.A.Glyph <= '3') {
+		if visual(t.E, t.A.Glyph) {
 			toDestroy = append(toDestroy, t.E)
 		}
 	})
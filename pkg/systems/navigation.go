@@ -0,0 +1,216 @@
+package systems
+
+import (
+	"harvester/pkg/components"
+	"harvester/pkg/data"
+	"harvester/pkg/ecs"
+	"harvester/pkg/pathfind"
+)
+
+// riverCost mirrors SurfaceMovement, where a river halves the player's
+// speed; weather adds its drag on top.
+const riverCost = 2.0
+
+// NavCache holds the surface cost grid, the sight-blocking tiles and shared
+// flow fields. It lives on entity 1 and is rebuilt when the terrain or
+// weather changes; it is not persisted.
+type NavCache struct {
+	Grid    *pathfind.CostGrid
+	Flows   *pathfind.FlowCache
+	Version uint64
+	opaque  []bool
+	tiles   uint64
+	weather uint64
+	level   TerrainLevel
+	w, h    int
+}
+
+// Navigation returns the current surface grid and flow cache, rebuilding the
+// grid when the level, the set of tiles, the weather or the map size has
+// changed.
+func Navigation(w *ecs.World) *NavCache {
+	nc, ok := ecs.Get[*NavCache](w, 1)
+	if !ok || nc == nil {
+		nc = &NavCache{Flows: pathfind.NewFlowCache(16)}
+		ecs.Add(w, 1, nc)
+	}
+	wi, _ := ecs.Get[components.WorldInfo](w, 1)
+	we := surfaceWeather(w)
+	level, _ := ecs.Get[TerrainLevel](w, 1)
+	tiles := ecs.Changes[components.Tile](w)
+	if nc.Grid != nil && nc.tiles == tiles && nc.weather == we.Version && nc.level == level && nc.w == wi.Width && nc.h == wi.Height {
+		return nc
+	}
+	nc.Grid, nc.opaque = buildSurfaceGrid(w, wi, we)
+	nc.tiles, nc.weather, nc.level, nc.w, nc.h = tiles, we.Version, level, wi.Width, wi.Height
+	nc.Version++
+	return nc
+}
+
+// Opaque reports whether the tile at (x, y) blocks line of sight.
+func (nc *NavCache) Opaque(x, y int) bool {
+	if x < 0 || y < 0 || x >= nc.w || y >= nc.h {
+		return true
+	}
+	return nc.opaque[y*nc.w+x]
+}
+
+// InvalidateNavigation makes the next Navigation call rebuild the grid, for
+// when tiles change type without any coming or going.
+func InvalidateNavigation(w *ecs.World) {
+	if nc, ok := ecs.Get[*NavCache](w, 1); ok && nc != nil {
+		nc.Grid = nil
+	}
+}
+
+// Blocked reports whether the tile at (x, y) is on the map and cannot be
+// walked onto.
+func (nc *NavCache) Blocked(x, y int) bool {
+	if x < 0 || y < 0 || x >= nc.w || y >= nc.h {
+		return false
+	}
+	return nc.Grid.Cost(x, y) == pathfind.Impassable
+}
+
+// buildSurfaceGrid turns tiles into entry costs and sight blockers: mountains,
+// lava and structures block movement, mountains, structures and forest block
+// sight, rivers cost double and weather drags on everything under it.
+func buildSurfaceGrid(w *ecs.World, wi components.WorldInfo, we components.Weather) (*pathfind.CostGrid, []bool) {
+	g := pathfind.NewCostGrid(wi.Width, wi.Height)
+	opaque := make([]bool, wi.Width*wi.Height)
+	ecs.View2Of[components.Position, components.Tile](w).Each(func(t ecs.Tuple2[components.Position, components.Tile]) {
+		x, y := int(t.A.X), int(t.A.Y)
+		switch t.B.Type {
+		case components.TileMountain, components.TileLava, components.TileStructure:
+			g.Set(x, y, pathfind.Impassable)
+		}
+		switch t.B.Type {
+		case components.TileMountain, components.TileForest, components.TileStructure:
+			if x >= 0 && y >= 0 && x < wi.Width && y < wi.Height {
+				opaque[y*wi.Width+x] = true
+			}
+		}
+	})
+	ecs.View2Of[components.Position, components.RiverTag](w).Each(func(t ecs.Tuple2[components.Position, components.RiverTag]) {
+		x, y := int(t.A.X), int(t.A.Y)
+		if c := g.Cost(x, y); c != pathfind.Impassable {
+			g.Set(x, y, c*riverCost)
+		}
+	})
+	if len(we.Fronts) > 0 {
+		for y := 0; y < wi.Height; y++ {
+			for x := 0; x < wi.Width; x++ {
+				kind, in := we.At(float64(x), float64(y))
+				if c := g.Cost(x, y); in > 0 && c != pathfind.Impassable {
+					g.Set(x, y, c*(1+data.EffectOf(kind).Drag*in))
+				}
+			}
+		}
+	}
+	return g, opaque
+}
+
+// AutoTravel walks the player along Path one tile at a time. Manual input
+// through SetPlayerInput cancels it.
+type AutoTravel struct{ Path []pathfind.Point }
+
+// TravelTo plans a route for e to (x, y) and starts auto-travel. It reports
+// false when the destination cannot be reached.
+func TravelTo(w *ecs.World, e ecs.Entity, x, y int) bool {
+	pos, ok := ecs.Get[components.Position](w, e)
+	if !ok {
+		return false
+	}
+	path, ok := pathfind.AStar(Navigation(w).Grid, pathfind.Point{X: int(pos.X), Y: int(pos.Y)}, pathfind.Point{X: x, Y: y})
+	if !ok || len(path) == 0 {
+		return false
+	}
+	ecs.Add(w, e, AutoTravel{Path: path})
+	return true
+}
+
+// TravelToResource starts auto-travel for e to the resource it can reach
+// soonest among those the player has seen. It reports false when there is
+// none it can reach.
+func TravelToResource(w *ecs.World, e ecs.Entity) bool {
+	pos, ok := ecs.Get[components.Position](w, e)
+	if !ok {
+		return false
+	}
+	vis := VisibilityOf(w)
+	var goals []pathfind.Point
+	ecs.View2Of[components.Resource, components.Position](w).Each(func(t ecs.Tuple2[components.Resource, components.Position]) {
+		if x, y := int(t.B.X), int(t.B.Y); vis(x, y) != VisUnknown {
+			goals = append(goals, pathfind.Point{X: x, Y: y})
+		}
+	})
+	m := pathfind.NewDijkstraMap(Navigation(w).Grid, goals...)
+	x, y := int(pos.X), int(pos.Y)
+	if m.Distance(x, y) == pathfind.Impassable {
+		return false
+	}
+	var path []pathfind.Point
+	for {
+		dx, dy, ok := m.Toward(x, y)
+		if !ok {
+			break
+		}
+		x, y = x+dx, y+dy
+		path = append(path, pathfind.Point{X: x, Y: y})
+	}
+	if len(path) > 0 {
+		ecs.Add(w, e, AutoTravel{Path: path})
+	}
+	return true
+}
+
+// AutoTravelSystem steers travelling entities by setting their Input toward
+// the next waypoint; SurfaceMovement then does the actual move, so bumping
+// into a creature still attacks it.
+type AutoTravelSystem struct{}
+
+func (AutoTravelSystem) Update(dt float64, w *ecs.World) {
+	if l := ecs.GetWorldContext(w).CurrentLayer; l != ecs.LayerPlanetSurface && l != ecs.LayerPlanetDeep {
+		return
+	}
+	ecs.View2Of[AutoTravel, components.Position](w).Each(func(t ecs.Tuple2[AutoTravel, components.Position]) {
+		x, y := int(t.B.X), int(t.B.Y)
+		path := t.A.Path
+		for len(path) > 0 && path[0].X == x && path[0].Y == y {
+			path = path[1:]
+		}
+		in := components.Input{}
+		if len(path) == 0 {
+			ecs.Remove[AutoTravel](w, t.E)
+			ecs.Add(w, t.E, in)
+			return
+		}
+		dx, dy := path[0].X-x, path[0].Y-y
+		in.Left, in.Right, in.Up, in.Down = dx < 0, dx > 0, dy < 0, dy > 0
+		ecs.Add(w, t.E, in)
+		ecs.Add(w, t.E, AutoTravel{Path: path})
+	})
+}
+
+// stepVia picks a flow-field step toward goal for an agent at (x, y), falling
+// back to a straight-line step when the field offers none.
+func stepVia(nc *NavCache, x, y int, goal pathfind.Point) (int, int) {
+	if nc.Grid.Width() > 0 {
+		if dx, dy, ok := nc.Flows.Get(nc.Grid, nc.Version, goal).Toward(x, y); ok {
+			return dx, dy
+		}
+	}
+	return stepToward(x, y, goal.X, goal.Y)
+}
+
+// fleeVia picks a flow-field step away from threat, falling back to stepping
+// directly away.
+func fleeVia(nc *NavCache, x, y int, threat pathfind.Point) (int, int) {
+	if nc.Grid.Width() > 0 {
+		if dx, dy, ok := nc.Flows.Get(nc.Grid, nc.Version, threat).Away(x, y); ok {
+			return dx, dy
+		}
+	}
+	dx, dy := stepToward(x, y, threat.X, threat.Y)
+	return -dx, -dy
+}
@@ -0,0 +1,134 @@
+package systems
+
+import (
+	"harvester/pkg/components"
+	"harvester/pkg/ecs"
+	"harvester/pkg/pathfind"
+	"testing"
+)
+
+func addTile(w *ecs.World, x, y float64, t components.TileType) ecs.Entity {
+	e := w.Create()
+	ecs.Add(w, e, components.Position{X: x, Y: y})
+	ecs.Add(w, e, components.Tile{Type: t})
+	return e
+}
+
+func TestNavigationGridCosts(t *testing.T) {
+	w, _ := newTestWorld(1)
+	ecs.Add(w, 1, components.WorldInfo{Width: 10, Height: 10})
+	m := addTile(w, 2, 2, components.TileMountain)
+	r := addTile(w, 3, 3, components.TileRiver)
+	ecs.Add(w, r, components.RiverTag{})
+
+	nc := Navigation(w)
+	if c := nc.Grid.Cost(2, 2); c != pathfind.Impassable {
+		t.Errorf("mountain should block, cost %v", c)
+	}
+	if c := nc.Grid.Cost(3, 3); c != riverCost {
+		t.Errorf("river cost %v, want %v", c, riverCost)
+	}
+	v := nc.Version
+	if Navigation(w).Version != v {
+		t.Fatal("unchanged terrain should reuse the grid")
+	}
+
+	weatherOver(w, components.WeatherRain, 0, 0)
+	nc = Navigation(w)
+	if nc.Version == v {
+		t.Fatal("rain should rebuild the grid")
+	}
+	if c := nc.Grid.Cost(0, 0); c != 2 {
+		t.Errorf("rain cost %v, want 2", c)
+	}
+	if c := nc.Grid.Cost(9, 9); c >= 2 || c <= 1 {
+		t.Errorf("rain should thin out away from the front's middle, cost %v", c)
+	}
+
+	w.Destroy(m)
+	if c := Navigation(w).Grid.Cost(2, 2); c == pathfind.Impassable {
+		t.Error("a mountain taken away should no longer block")
+	}
+}
+
+func TestAutoTravelRoutesAroundWall(t *testing.T) {
+	w, p := newTestWorld(1)
+	ecs.Add(w, 1, components.WorldInfo{Width: 12, Height: 12})
+	for y := 0; y < 9; y++ {
+		addTile(w, 7, float64(y), components.TileMountain)
+	}
+	if !TravelTo(w, p, 9, 5) {
+		t.Fatal("expected a route around the wall")
+	}
+	for i := 0; i < 40; i++ {
+		AutoTravelSystem{}.Update(0.1, w)
+		SurfaceMovement{}.Update(0.1, w)
+	}
+	pos, _ := ecs.Get[components.Position](w, p)
+	if pos.X != 9 || pos.Y != 5 {
+		t.Fatalf("player at %v,%v, want 9,5", pos.X, pos.Y)
+	}
+	if _, ok := ecs.Get[AutoTravel](w, p); ok {
+		t.Error("auto-travel should end on arrival")
+	}
+}
+
+func TestManualInputCancelsAutoTravel(t *testing.T) {
+	w, p := newTestWorld(1)
+	ecs.Add(w, 1, components.WorldInfo{Width: 12, Height: 12})
+	if !TravelTo(w, p, 10, 5) {
+		t.Fatal("expected a route")
+	}
+	SetPlayerInput(w, p, "up")
+	if _, ok := ecs.Get[AutoTravel](w, p); ok {
+		t.Fatal("manual input should cancel auto-travel")
+	}
+}
+
+func TestTravelToTheNearestResource(t *testing.T) {
+	w, p := newTestWorld(1)
+	ecs.Add(w, 1, components.WorldInfo{Width: 12, Height: 12})
+	for y := 0; y < 9; y++ {
+		addTile(w, 7, float64(y), components.TileMountain)
+	}
+	ore := func(x, y float64) {
+		e := w.Create()
+		ecs.Add(w, e, components.Position{X: x, Y: y})
+		ecs.Add(w, e, components.Resource{Kind: "ore", Amount: 1})
+	}
+	ore(9, 5) // nearer as the crow flies, but behind the wall
+	ore(2, 1)
+	if !TravelToResource(w, p) {
+		t.Fatal("expected a route to the ore")
+	}
+	for i := 0; i < 40; i++ {
+		AutoTravelSystem{}.Update(0.1, w)
+		SurfaceMovement{}.Update(0.1, w)
+	}
+	if pos, _ := ecs.Get[components.Position](w, p); pos.X != 2 || pos.Y != 1 {
+		t.Fatalf("player at %v,%v, want the ore at 2,1", pos.X, pos.Y)
+	}
+
+	w, p = newTestWorld(1)
+	ecs.Add(w, 1, components.WorldInfo{Width: 12, Height: 12})
+	if TravelToResource(w, p) {
+		t.Fatal("there is no ore to travel to")
+	}
+}
+
+func TestSpawnsAvoidBlockedTiles(t *testing.T) {
+	w, _ := newTestWorld(1)
+	ecs.Add(w, 1, components.WorldInfo{Width: 20, Height: 20})
+	for y := 0; y < 20; y++ {
+		for x := 0; x < 20; x++ {
+			if x != 15 || y != 15 {
+				addTile(w, float64(x), float64(y), components.TileMountain)
+			}
+		}
+	}
+	for i := 0; i < 200; i++ {
+		if x, y, ok := spawnSpot(w, components.WorldInfo{Width: 20, Height: 20}, 0); ok && (x != 15 || y != 15) {
+			t.Fatalf("spawned on a mountain at %d,%d", x, y)
+		}
+	}
+}
@@ -1,30 +1,360 @@
 package systems
 
 import (
+	"fmt"
+	"strings"
+
 	"harvester/pkg/components"
+	"harvester/pkg/data"
 	"harvester/pkg/ecs"
 )
 
+// Event kinds emitted by the quest engine. EventTalk comes from bumping into
+// a Speaker; Subject holds the speaker or quest id.
+const (
+	EventTalk  = "talk"
+	EventQuest = "quest"
+)
+
+func init() {
+	ecs.RegisterComponent[QuestLog]()
+	ecs.RegisterComponent[Speaker]()
+}
+
+// QuestLog is the player's active and finished quests. Planets lists the
+// planets whose quests have been handed out, so each is offered once.
+type QuestLog struct {
+	Active    []components.Quest
+	Completed []string
+	Planets   []int
+}
+
+func (l QuestLog) offered(planet int) bool {
+	for _, p := range l.Planets {
+		if p == planet {
+			return true
+		}
+	}
+	return false
+}
+
+// Speaker marks an NPC the player can talk to by walking into it.
+type Speaker struct{ ID string }
+
+// questCursor remembers the last event the quest engine has read. It is not
+// persisted: a world loaded from a save starts a fresh event log, which is
+// read from the beginning.
+type questCursor struct{ Seq uint64 }
+
+// QuestSystem hands out each planet's quests on arrival, advances objectives
+// from world events and the player's position, and pays out rewards.
 type QuestSystem struct{}
 
 func (QuestSystem) Update(dt float64, w *ecs.World) {
 	ctx := ecs.GetWorldContext(w)
-	if ctx.CurrentLayer != ecs.LayerPlanetSurface {
+	if ctx.CurrentLayer == ecs.LayerSpace {
 		return
 	}
-	var playerInv components.Inventory
-	found := false
-	ecs.View2Of[components.Player, components.Inventory](w).Each(func(t ecs.Tuple2[components.Player, components.Inventory]) {
-		playerInv = *t.B
-		found = true
-	})
-	if !found {
+	player, pos, ok := findPlayer(w)
+	if !ok {
 		return
 	}
-	collected := playerInv.Items["trade_contract"]
-	ctx.QuestProgress.ContractsCollected = collected
-	if collected >= ctx.QuestProgress.ContractsNeeded {
-		ctx.QuestProgress.RoyalCharterComplete = true
+	log, _ := ecs.Get[QuestLog](w, player)
+	fresh := !log.offered(ctx.PlanetID)
+	cur, seen := ecs.Get[questCursor](w, player)
+	if !seen && fresh {
+		// only what happens after the quests are handed out counts
+		cur.Seq = ^uint64(0)
+	}
+	events, next := w.EventsSince(cur.Seq)
+	ecs.Add(w, player, questCursor{Seq: next})
+
+	if fresh {
+		log.Planets = append(log.Planets, ctx.PlanetID)
+		for _, q := range data.QuestsFor(ctx.PlanetID, data.BiomeType(ctx.BiomeType)) {
+			placeQuestSites(w, &q)
+			log.Active = append(log.Active, q)
+			questEvent(w, player, q.ID, "New quest: %s", q.Description)
+		}
+	}
+	active := log.Active[:0]
+	for _, q := range log.Active {
+		if q.PlanetID == ctx.PlanetID {
+			advanceQuest(w, player, &q, events, ctx, int(pos.X), int(pos.Y))
+		}
+		if !q.Done() {
+			active = append(active, q)
+			continue
+		}
+		log.Completed = append(log.Completed, q.ID)
+		questEvent(w, player, q.ID, "Quest complete: %s", q.Description)
+		for _, r := range q.Rewards {
+			grantReward(w, player, r)
+		}
+		if q.EscapeReward {
+			ctx.QuestProgress.RoyalCharterComplete = true
+			questEvent(w, player, q.ID, "Your ship is cleared for launch. Return to the landing site.")
+		}
 	}
+	log.Active = active
+	ecs.Add(w, player, log)
+	syncCharter(&ctx, log)
 	ecs.SetWorldContext(w, ctx)
 }
+
+// advanceQuest applies events and the player's current state to q's open
+// objectives.
+func advanceQuest(w *ecs.World, player ecs.Entity, q *components.Quest, events []ecs.Event, ctx ecs.WorldContext, px, py int) {
+	for i := range q.Objectives {
+		o := &q.Objectives[i]
+		if o.Completed {
+			continue
+		}
+		switch o.Type {
+		case components.ObjectiveDepth:
+			o.Progress = min(ctx.Depth, o.Count)
+		case components.ObjectiveVisit:
+			if x, y, ok := questSpot(o.Location); ok && ctx.CurrentLayer == ecs.LayerPlanetSurface && chebyshev(px, py, x, y) <= 1 {
+				o.Progress = o.Count
+			}
+		default:
+			for _, ev := range events {
+				if counts(*o, ev, player) {
+					o.Progress += max(1, ev.Amount)
+				}
+			}
+		}
+		if o.Progress >= o.Count {
+			o.Progress = o.Count
+			o.Completed = true
+			if len(q.Objectives) > 1 {
+				questEvent(w, player, q.ID, "Objective complete: %s.", describeObjective(*o))
+			}
+		}
+	}
+}
+
+// counts reports whether ev advances objective o.
+func counts(o components.QuestObjective, ev ecs.Event, player ecs.Entity) bool {
+	if ev.Source != player {
+		return false
+	}
+	switch o.Type {
+	case components.ObjectiveCollect:
+		return ev.Kind == EventPickup && ev.Subject == o.Target
+	case components.ObjectiveKill:
+		return ev.Kind == EventKill && (o.Target == "" || ev.Subject == o.Target)
+	case components.ObjectiveTalk:
+		return ev.Kind == EventTalk && ev.Subject == o.Target
+	case components.ObjectiveCraft:
+		return ev.Kind == EventCraft && ev.Subject == o.Target
+	}
+	return false
+}
+
+// placeQuestSites sets up what q's objectives need on the map: scattered
+// items to collect, speakers, visit markers and crafting stations. Every
+// site goes on a tile the player can walk to from where they stand; a fixed
+// location that falls on rock or out of reach is moved to the nearest tile
+// that is not, and the objective's Location follows it.
+func placeQuestSites(w *ecs.World, q *components.Quest) {
+	wi, _ := ecs.Get[components.WorldInfo](w, 1)
+	r := ecs.RandFromSeed(w.Seed() ^ int64(q.PlanetID)*7919)
+	_, pos, _ := findPlayer(w)
+	reach := reachableFrom(Navigation(w), int(pos.X), int(pos.Y))
+	for i := range q.Objectives {
+		o := &q.Objectives[i]
+		x, y, hasSpot := questSpot(o.Location)
+		if nx, ny, ok := reach.nearest(x, y); hasSpot && ok {
+			x, y = nx, ny
+			o.Location = fmt.Sprintf("%d,%d", x, y)
+		}
+		switch o.Type {
+		case components.ObjectiveCollect:
+			if _, known := data.Item(o.Target); !known || wi.Width <= 0 {
+				continue
+			}
+			for n := 0; n < o.Count; n++ {
+				if x, y, ok := reach.nearest(r.Intn(wi.Width), r.Intn(wi.Height)); ok {
+					SpawnPile(w, x, y, o.Target, 1)
+				}
+			}
+		case components.ObjectiveTalk:
+			if hasSpot {
+				e := w.Create()
+				ecs.Add(w, e, components.Position{X: float64(x), Y: float64(y)})
+				ecs.Add(w, e, components.Renderable{Glyph: '&', TileType: components.TileGalaxy})
+				ecs.Add(w, e, components.Name{Text: "the " + strings.ReplaceAll(o.Target, "_", " ")})
+				ecs.Add(w, e, Speaker{ID: o.Target})
+			}
+		case components.ObjectiveVisit:
+			if hasSpot {
+				e := w.Create()
+				ecs.Add(w, e, components.Position{X: float64(x), Y: float64(y)})
+				ecs.Add(w, e, components.Renderable{Glyph: '⚑', TileType: components.TileGalaxy})
+				ecs.Add(w, e, components.Name{Text: "the " + strings.ReplaceAll(o.Target, "_", " ")})
+			}
+		case components.ObjectiveCraft:
+			if rec, ok := data.LookupRecipe(o.Target); ok && hasSpot && rec.Station != "" {
+				e := w.Create()
+				ecs.Add(w, e, components.Position{X: float64(x), Y: float64(y)})
+				ecs.Add(w, e, components.Renderable{Glyph: 'π', TileType: components.TileGalaxy})
+				ecs.Add(w, e, components.Name{Text: "the " + rec.Station})
+				ecs.Add(w, e, components.Workstation{Kind: rec.Station})
+			}
+		}
+	}
+}
+
+// reachMap marks the tiles that can be walked to from one spot, moving four
+// ways like the route planner.
+type reachMap struct {
+	open []bool
+	w, h int
+}
+
+// reachableFrom floods nc's grid from (x, y).
+func reachableFrom(nc *NavCache, x, y int) reachMap {
+	m := reachMap{open: make([]bool, nc.w*nc.h), w: nc.w, h: nc.h}
+	if x < 0 || y < 0 || x >= m.w || y >= m.h {
+		return m
+	}
+	m.open[y*m.w+x] = true
+	queue := []int{y*m.w + x}
+	for len(queue) > 0 {
+		i := queue[0]
+		queue = queue[1:]
+		for _, d := range [4][2]int{{1, 0}, {-1, 0}, {0, 1}, {0, -1}} {
+			nx, ny := i%m.w+d[0], i/m.w+d[1]
+			if nx < 0 || ny < 0 || nx >= m.w || ny >= m.h || m.open[ny*m.w+nx] || nc.Blocked(nx, ny) {
+				continue
+			}
+			m.open[ny*m.w+nx] = true
+			queue = append(queue, ny*m.w+nx)
+		}
+	}
+	return m
+}
+
+// nearest returns the reachable tile closest to (x, y), searching outward
+// ring by ring. It reports false if nothing is reachable.
+func (m reachMap) nearest(x, y int) (int, int, bool) {
+	for r := 0; r < max(m.w, m.h); r++ {
+		for dy := -r; dy <= r; dy++ {
+			for dx := -r; dx <= r; dx++ {
+				if max(iabs(dx), iabs(dy)) != r {
+					continue
+				}
+				nx, ny := x+dx, y+dy
+				if nx >= 0 && ny >= 0 && nx < m.w && ny < m.h && m.open[ny*m.w+nx] {
+					return nx, ny, true
+				}
+			}
+		}
+	}
+	return x, y, false
+}
+
+// grantReward pays one reward to the player. Items that do not fit are left
+// at the player's feet.
+func grantReward(w *ecs.World, player ecs.Entity, r components.Reward) {
+	switch r.Type {
+	case components.RewardItem:
+		item, _ := r.Value.(string)
+		if item == "" {
+			return
+		}
+		if AddItem(w, player, item, 1) == 0 {
+			if pos, ok := ecs.Get[components.Position](w, player); ok {
+				SpawnPile(w, int(pos.X), int(pos.Y), item, 1)
+			}
+		}
+		def, _ := data.Item(item)
+		questEvent(w, player, "", "You receive a %s.", def.Name)
+	case components.RewardFuel:
+		Refuel(w, player, rewardAmount(r.Value))
+	case components.RewardHull:
+		if ps, ok := ecs.Get[components.PlayerStats](w, player); ok {
+			ps.Hull = min(data.HullCapacity, ps.Hull+rewardAmount(r.Value))
+			ecs.Add(w, player, ps)
+		}
+	}
+}
+
+// rewardAmount reads a numeric reward value, which is a float64 once a
+// save has been loaded.
+func rewardAmount(v interface{}) int {
+	switch n := v.(type) {
+	case int:
+		return n
+	case float64:
+		return int(n)
+	}
+	return 0
+}
+
+// syncCharter mirrors the escape quest's contract count into QuestProgress
+// for the HUD.
+func syncCharter(ctx *ecs.WorldContext, log QuestLog) {
+	if ctx.QuestProgress.RoyalCharterComplete {
+		ctx.QuestProgress.ContractsCollected = ctx.QuestProgress.ContractsNeeded
+		return
+	}
+	for _, q := range log.Active {
+		if !q.EscapeReward || q.PlanetID != ctx.PlanetID {
+			continue
+		}
+		for _, o := range q.Objectives {
+			if o.Type == components.ObjectiveCollect {
+				ctx.QuestProgress.ContractsCollected = o.Progress
+				ctx.QuestProgress.ContractsNeeded = o.Count
+			}
+		}
+	}
+}
+
+// SpeakTo has the player talk to the speaker s.
+func SpeakTo(w *ecs.World, player, s ecs.Entity) {
+	sp, ok := ecs.Get[Speaker](w, s)
+	if !ok {
+		return
+	}
+	w.Emit(ecs.Event{Kind: EventTalk, Source: player, Target: s, Subject: sp.ID,
+		Text: fmt.Sprintf("You speak with %s.", nameOf(w, s))})
+}
+
+// speakerAt returns a Speaker standing on (x, y), or 0.
+func speakerAt(w *ecs.World, x, y int) ecs.Entity {
+	var found ecs.Entity
+	ecs.View2Of[Speaker, components.Position](w).Each(func(t ecs.Tuple2[Speaker, components.Position]) {
+		if int(t.B.X) == x && int(t.B.Y) == y && (found == 0 || t.E < found) {
+			found = t.E
+		}
+	})
+	return found
+}
+
+func questEvent(w *ecs.World, player ecs.Entity, id, format string, args ...any) {
+	w.Emit(ecs.Event{Kind: EventQuest, Source: player, Subject: id, Text: fmt.Sprintf(format, args...)})
+}
+
+func questSpot(loc string) (int, int, bool) {
+	var x, y int
+	if _, err := fmt.Sscanf(loc, "%d,%d", &x, &y); err != nil {
+		return 0, 0, false
+	}
+	return x, y, true
+}
+
+func describeObjective(o components.QuestObjective) string {
+	target := strings.ReplaceAll(o.Target, "_", " ")
+	switch o.Type {
+	case components.ObjectiveDepth:
+		return fmt.Sprintf("reach depth %d", o.Count)
+	case components.ObjectiveVisit:
+		return "visit the " + target
+	case components.ObjectiveTalk:
+		return "speak with the " + target
+	}
+	return fmt.Sprintf("%s %d %s", o.Type, o.Count, target)
+}
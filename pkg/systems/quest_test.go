@@ -0,0 +1,193 @@
+package systems
+
+import (
+	"encoding/json"
+	"math/rand"
+	"testing"
+
+	"harvester/pkg/components"
+	"harvester/pkg/data"
+	"harvester/pkg/ecs"
+)
+
+// questsOffered runs the quest system once so that the planet offers its
+// quests.
+func questsOffered(w *ecs.World, _ ecs.Entity) {
+	QuestSystem{}.Update(0, w)
+}
+
+func activeQuest(w *ecs.World, p ecs.Entity, id string) (components.Quest, bool) {
+	log, _ := ecs.Get[QuestLog](w, p)
+	for _, q := range log.Active {
+		if q.ID == id {
+			return q, true
+		}
+	}
+	return components.Quest{}, false
+}
+
+func TestQuestsOfferedOncePerPlanetWithSites(t *testing.T) {
+	w, p := newTestWorld(1, mapSize(200, 80), ship(10, 50), onPlanet(data.BiomeToftForest, 0), questsOffered)
+	QuestSystem{}.Update(0, w)
+	log, _ := ecs.Get[QuestLog](w, p)
+	if len(log.Active) != 4 || len(log.Planets) != 1 {
+		t.Fatalf("expected the four Toft quests once, got %d quests for %v", len(log.Active), log.Planets)
+	}
+	contracts, speakers, forges := 0, 0, 0
+	ecs.View1Of[components.Resource](w).Each(func(_ ecs.Entity, r *components.Resource) {
+		if r.Kind == "trade_contract" {
+			contracts += r.Amount
+		}
+	})
+	ecs.View1Of[Speaker](w).Each(func(ecs.Entity, *Speaker) { speakers++ })
+	ecs.View1Of[components.Workstation](w).Each(func(ecs.Entity, *components.Workstation) { forges++ })
+	if contracts != 5 || speakers != 1 || forges != 1 {
+		t.Fatalf("sites: %d contracts, %d speakers, %d stations", contracts, speakers, forges)
+	}
+}
+
+func TestQuestSitesCanBeReached(t *testing.T) {
+	for _, biome := range []data.BiomeType{data.BiomeToftForest, data.BiomeVolcanic, data.BiomeIce, data.BiomeAncientRuins} {
+		w, p := newTestWorld(1, mapSize(200, 80), playerAt(100, 40), onPlanet(biome, 0))
+		TerrainGen{}.Update(0, w)
+		QuestSystem{}.Update(0, w)
+		start, _ := ecs.Get[components.Position](w, p)
+		sites := 0
+		ecs.View1Of[components.Resource](w).Each(func(e ecs.Entity, _ *components.Resource) {
+			pos, _ := ecs.Get[components.Position](w, e)
+			if !reachable(w, start, pos) {
+				t.Fatalf("%v: a pile at %+v cannot be reached", biome, pos)
+			}
+			sites++
+		})
+		log, _ := ecs.Get[QuestLog](w, p)
+		for _, q := range log.Active {
+			for _, o := range q.Objectives {
+				if x, y, ok := questSpot(o.Location); ok {
+					if !reachable(w, start, components.Position{X: float64(x), Y: float64(y)}) {
+						t.Fatalf("%v: %s at %s cannot be reached", biome, o.Target, o.Location)
+					}
+					sites++
+				}
+			}
+		}
+		if sites == 0 {
+			t.Fatalf("%v: expected quest sites", biome)
+		}
+	}
+}
+
+func TestEscapeQuestCompletesFromEvents(t *testing.T) {
+	w, p := newTestWorld(1, mapSize(200, 80), ship(10, 50), onPlanet(data.BiomeToftForest, 0), questsOffered)
+	w.Emit(ecs.Event{Kind: EventPickup, Source: p, Subject: "trade_contract", Amount: 3})
+	// pickups by anyone else do not count
+	w.Emit(ecs.Event{Kind: EventPickup, Source: 99, Subject: "trade_contract", Amount: 2})
+	QuestSystem{}.Update(0, w)
+	if ctx := ecs.GetWorldContext(w); ctx.QuestProgress.ContractsCollected != 3 || ctx.QuestProgress.RoyalCharterComplete {
+		t.Fatalf("unexpected progress %+v", ctx.QuestProgress)
+	}
+	w.Emit(ecs.Event{Kind: EventPickup, Source: p, Subject: "trade_contract", Amount: 2})
+	var speaker ecs.Entity
+	ecs.View1Of[Speaker](w).Each(func(e ecs.Entity, _ *Speaker) { speaker = e })
+	SpeakTo(w, p, speaker)
+	QuestSystem{}.Update(0, w)
+	ctx := ecs.GetWorldContext(w)
+	if !ctx.QuestProgress.RoyalCharterComplete || ctx.QuestProgress.ContractsCollected != 5 {
+		t.Fatalf("escape quest should be complete, got %+v", ctx.QuestProgress)
+	}
+	if _, ok := activeQuest(w, p, "royal_charter"); ok {
+		t.Fatal("completed quest should leave the active list")
+	}
+	if ps, _ := ecs.Get[components.PlayerStats](w, p); ps.Fuel != 35 {
+		t.Fatalf("expected the fuel reward, tank at %d", ps.Fuel)
+	}
+}
+
+func TestKillDepthVisitAndCraftObjectives(t *testing.T) {
+	w, p := newTestWorld(1, mapSize(200, 80), ship(10, 50), onPlanet(data.BiomeToftForest, 0), questsOffered)
+	for i := 0; i < 3; i++ {
+		w.Emit(ecs.Event{Kind: EventKill, Source: p, Subject: "wild beast"})
+	}
+	w.Emit(ecs.Event{Kind: EventKill, Source: p, Subject: "patrol"})
+	w.Emit(ecs.Event{Kind: EventCraft, Source: p, Subject: "knife", Amount: 1})
+	ecs.Add(w, p, components.Position{X: 41, Y: 21})
+	QuestSystem{}.Update(0, w)
+	for _, id := range []string{"beast_cull", "apprentice"} {
+		if _, ok := activeQuest(w, p, id); ok {
+			t.Errorf("%s should be complete", id)
+		}
+	}
+	if ps, _ := ecs.Get[components.PlayerStats](w, p); ps.Hull != 60 {
+		t.Errorf("expected hull reward, hull %d", ps.Hull)
+	}
+	inv, _ := ecs.Get[components.Inventory](w, p)
+	if inv.Count("herbs") != 1 || inv.Count("leather_armor") != 1 {
+		t.Errorf("expected item rewards, got %+v", inv.Stacks)
+	}
+	q, _ := activeQuest(w, p, "forest_survey")
+	if !q.Objectives[0].Completed || q.Objectives[1].Completed {
+		t.Fatalf("visit done, depth pending: %+v", q.Objectives)
+	}
+	ctx := ecs.GetWorldContext(w)
+	ctx.Depth = 12
+	ecs.SetWorldContext(w, ctx)
+	QuestSystem{}.Update(0, w)
+	if _, ok := activeQuest(w, p, "forest_survey"); ok {
+		t.Fatal("depth objective should finish the survey")
+	}
+}
+
+func TestQuestLogSurvivesSaveLoad(t *testing.T) {
+	w, p := newTestWorld(1, mapSize(200, 80), ship(10, 50), onPlanet(data.BiomeToftForest, 0), questsOffered)
+	w.Emit(ecs.Event{Kind: EventPickup, Source: p, Subject: "trade_contract", Amount: 2})
+	QuestSystem{}.Update(0, w)
+	s, err := ecs.Save(w, json.Marshal)
+	if err != nil {
+		t.Fatal(err)
+	}
+	w2 := ecs.NewWorld(rand.New(rand.NewSource(2)))
+	if _, err := ecs.Load(w2, s, json.Unmarshal); err != nil {
+		t.Fatal(err)
+	}
+	// Player is not persisted; the game keeps it on the live entity
+	ecs.Add(w2, p, components.Player{})
+	q, ok := activeQuest(w2, p, "royal_charter")
+	if !ok || q.Objectives[0].Progress != 2 {
+		t.Fatalf("quest progress lost: %+v", q)
+	}
+	// numeric rewards come back as float64 and must still pay out
+	for i := 0; i < 3; i++ {
+		w2.Emit(ecs.Event{Kind: EventKill, Source: p, Subject: "wild beast"})
+	}
+	QuestSystem{}.Update(0, w2)
+	QuestSystem{}.Update(0, w2)
+	if ps, _ := ecs.Get[components.PlayerStats](w2, p); ps.Hull != 60 {
+		t.Fatalf("expected the hull reward after load, hull %d", ps.Hull)
+	}
+}
+
+func TestHullRewardStopsAtAWholeHull(t *testing.T) {
+	w, p := newTestWorld(1, mapSize(200, 80), ship(10, 50), onPlanet(data.BiomeToftForest, 0), questsOffered)
+	ecs.Add(w, p, components.PlayerStats{Hull: data.HullCapacity - 3})
+	grantReward(w, p, components.Reward{Type: components.RewardHull, Value: 10})
+	if ps, _ := ecs.Get[components.PlayerStats](w, p); ps.Hull != data.HullCapacity {
+		t.Fatalf("a hull reward should stop at a whole hull, hull %d", ps.Hull)
+	}
+}
+
+func TestBumpingSpeakerTalksInsteadOfMoving(t *testing.T) {
+	w, p := newTestWorld(1)
+	npc := w.Create()
+	ecs.Add(w, npc, components.Position{X: 6, Y: 5})
+	ecs.Add(w, npc, components.Name{Text: "the harbour master"})
+	ecs.Add(w, npc, Speaker{ID: "harbour_master"})
+	SetPlayerInput(w, p, "right")
+	SurfaceMovement{}.Update(0.1, w)
+	if pos, _ := ecs.Get[components.Position](w, p); pos.X != 5 {
+		t.Fatalf("talking should not move the player, at %v", pos.X)
+	}
+	evs, _ := w.EventsSince(0)
+	if len(evs) != 1 || evs[0].Kind != EventTalk || evs[0].Subject != "harbour_master" {
+		t.Fatalf("expected a talk event, got %+v", evs)
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"github.com/charmbracelet/lipgloss/v2"
 	"harvester/pkg/components"
 	"harvester/pkg/ecs"
+	"harvester/pkg/timing"
 	"image/color"
 	"math"
 	"math/rand"
@@ -16,8 +17,12 @@ type Drawable struct {
 	Style     lipgloss.Style
 	Alpha     float64
 	BlendMode components.BlendMode
+	Dim       bool // remembered but not currently in view
 }
 
+// rememberedAlpha scales the opacity of tiles outside the player's view.
+const rememberedAlpha = 0.5
+
 type Render struct{ Output []Drawable }
 
 type Theme struct {
@@ -37,6 +42,11 @@ func getThemeForBiome(biome int) Theme {
 		components.TileGalaxyCore: lipgloss.NewStyle().Foreground(lipgloss.Color("219")).Bold(true),
 		components.TileAsteroid:   lipgloss.NewStyle().Foreground(lipgloss.Color("245")),
 		components.TileComet:      lipgloss.NewStyle().Foreground(lipgloss.Color("123")),
+		components.TileBlackHole:  lipgloss.NewStyle().Foreground(lipgloss.Color("238")).Bold(true),
+		components.TileWormhole:   lipgloss.NewStyle().Foreground(lipgloss.Color("51")),
+		components.TileAnomaly:    lipgloss.NewStyle().Foreground(lipgloss.Color("118")),
+		components.TileIce:        lipgloss.NewStyle().Foreground(lipgloss.Color("195")),
+		components.TileStructure:  lipgloss.NewStyle().Foreground(lipgloss.Color("180")),
 	}
 	return Theme{styles: m}
 }
@@ -68,13 +78,89 @@ func applyColorModifier(base lipgloss.Style, mod *components.ColorModifier, dt f
 
 func adjustBrightness(c color.Color, factor float64) color.Color { return c }
 
+// particle is how a kind of weather is drawn: its glyph, colour and blend,
+// and the way it drifts in tiles per tick.
+type particle struct {
+	glyph  rune
+	tile   components.TileType
+	blend  components.BlendMode
+	dx, dy int
+}
+
+var particles = map[components.WeatherKind]particle{
+	components.WeatherRain:      {glyph: ',', tile: components.TileRiver, blend: components.BlendNormal, dy: 1},
+	components.WeatherSnowstorm: {glyph: '*', tile: components.TileIce, blend: components.BlendScreen, dx: 1, dy: 1},
+	components.WeatherAshFall:   {glyph: '˙', tile: components.TileMountain, blend: components.BlendMultiply, dy: 1},
+	components.WeatherDust:      {glyph: '·', tile: components.TileStructure, blend: components.BlendAdditive, dx: 1},
+}
+
+// particleDensity is the share of cells under a front at full intensity that
+// show a particle.
+const particleDensity = 0.35
+
+// appendWeather draws the surface's weather over the tiles in view as
+// translucent particles drifting with the ticks, thicker where the weather
+// is stronger. Entities are drawn after, so they show through.
+func appendWeather(out []Drawable, w *ecs.World, th Theme, vis func(x, y int) Visibility) []Drawable {
+	wi, _ := ecs.Get[components.WorldInfo](w, 1)
+	we := surfaceWeather(w)
+	if len(we.Fronts) == 0 {
+		return out
+	}
+	x0, y0, x1, y1 := wi.Width, wi.Height, 0, 0
+	for _, f := range we.Fronts {
+		x0, y0 = min(x0, int(f.X-f.Radius)), min(y0, int(f.Y-f.Radius))
+		x1, y1 = max(x1, int(f.X+f.Radius)+1), max(y1, int(f.Y+f.Radius)+1)
+	}
+	t := int(timing.Tick())
+	for y := max(y0, 0); y < min(y1, wi.Height); y++ {
+		for x := max(x0, 0); x < min(x1, wi.Width); x++ {
+			kind, in := we.At(float64(x), float64(y))
+			p, ok := particles[kind]
+			if !ok || vis(x, y) != VisVisible {
+				continue
+			}
+			// the same particle moves on a tile every tick
+			h := uint32(x-p.dx*t)*2654435761 ^ uint32(y-p.dy*t)*2246822519 ^ uint32(kind)*3266489917
+			h ^= h >> 15
+			h *= 2246822519
+			h ^= h >> 13
+			if float64(h%1000)/1000 >= in*particleDensity {
+				continue
+			}
+			out = append(out, Drawable{
+				X: x, Y: y,
+				Glyph:     p.glyph,
+				Style:     th.GetStyle(p.tile),
+				Alpha:     0.3 + 0.5*in,
+				BlendMode: p.blend,
+			})
+		}
+	}
+	return out
+}
+
 func (r *Render) Update(dt float64, w *ecs.World) {
 	ctx := ecs.GetWorldContext(w)
 	out := r.Output[:0]
 	th := getThemeForBiome(ctx.BiomeType)
+	vis := VisibilityOf(w)
+	// space scenery stays loaded while the ship is landed but is not drawn
+	offstage := func(e ecs.Entity) bool {
+		if ctx.CurrentLayer == ecs.LayerSpace {
+			return false
+		}
+		_, ok := ecs.Get[SpaceBody](w, e)
+		return ok
+	}
 
-	// Render tiles with full styling, transparency, and alpha support
+	// Render tiles with full styling, transparency, and alpha support.
+	// Unknown tiles are skipped and remembered ones drawn faint.
 	ecs.View2Of[components.Position, components.Tile](w).Each(func(t ecs.Tuple2[components.Position, components.Tile]) {
+		v := vis(int(t.A.X), int(t.A.Y))
+		if v == VisUnknown || offstage(t.E) {
+			return
+		}
 		style := th.GetStyle(t.B.Type)
 		alpha := 1.0 // Default fully opaque for all tiles
 		blendMode := components.BlendNormal
@@ -84,6 +170,11 @@ func (r *Render) Update(dt float64, w *ecs.World) {
 			alpha = trans.Alpha
 			blendMode = trans.BlendMode
 		}
+		dim := v == VisRemembered
+		if dim {
+			style = style.Faint(true)
+			alpha *= rememberedAlpha
+		}
 
 		out = append(out, Drawable{
 			X: int(t.A.X), Y: int(t.A.Y),
@@ -91,17 +182,26 @@ func (r *Render) Update(dt float64, w *ecs.World) {
 			Style:     style,
 			Alpha:     alpha,
 			BlendMode: blendMode,
+			Dim:       dim,
 		})
 	})
 
+	out = appendWeather(out, w, th, vis)
+
 	// Render entities with full styling, transparency, and alpha support
 	ecs.View2Of[components.Position, components.Renderable](w).Each(func(t ecs.Tuple2[components.Position, components.Renderable]) {
 		style := th.GetStyle(t.B.TileType)
 		alpha := 1.0 // Default fully opaque for all entities
 		blendMode := components.BlendNormal
 
+		// Creatures and items only show while in view
+		_, isPlayer := ecs.Get[components.Player](w, t.E)
+		if !isPlayer && (vis(int(t.A.X), int(t.A.Y)) != VisVisible || offstage(t.E)) {
+			return
+		}
+
 		// Player pulse background using PulseSpring
-		if _, isPlayer := ecs.Get[components.Player](w, t.E); isPlayer {
+		if isPlayer {
 			if ps, ok := ecs.Get[components.PulseSpring](w, t.E); ok {
 				c := 255 - int(255*ps.Pos)
 				bg := lipgloss.Color(strconv.Itoa(c))
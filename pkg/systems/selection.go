@@ -2,59 +2,21 @@ package systems
 
 import (
 	"fmt"
-	"harvester/pkg/components"
+
 	"harvester/pkg/data"
 	"harvester/pkg/ecs"
 )
 
+func init() {
+	ecs.RegisterComponent[PlanetCard]()
+}
+
+// PlanetCard is a planet in the space layer that the ship can land on.
 type PlanetCard struct {
 	Planet data.Planet
 	Index  int
 }
 
-type PlanetSelection struct{}
-
-func (s PlanetSelection) Update(dt float64, w *ecs.World) {
-	ctx := ecs.GetWorldContext(w)
-	// Ensure three planet cards exist once
-	created := false
-	ecs.View2Of[PlanetCard, PlanetCard](w).Each(func(t ecs.Tuple2[PlanetCard, PlanetCard]) { created = true })
-	if !created {
-		pg := data.PlanetGenerator{Seed: 1, Biome: data.BiomeToftForest, MaxDepth: 120}
-		toft := pg.GenerateToft()
-		for i := 0; i < 3; i++ {
-			e := w.Create()
-			ecs.Add(w, e, PlanetCard{Planet: *toft, Index: i})
-			// position cards
-			ecs.Add(w, e, components.Position{X: float64(10 + i*20), Y: 5})
-			glyph := rune('1' + i)
-			ecs.Add(w, e, components.Renderable{Glyph: glyph})
-		}
-	}
-	var in *components.Input
-	ecs.View2Of[components.Player, components.Input](w).Each(func(t ecs.Tuple2[components.Player, components.Input]) { in = t.B })
-	if in != nil {
-		choice := -1
-		if in.Left {
-			choice = 0
-		}
-		if in.Down {
-			choice = 1
-		}
-		if in.Right {
-			choice = 2
-		}
-		if choice >= 0 {
-			pg := data.PlanetGenerator{Seed: int64(choice + 1), Biome: data.BiomeToftForest, MaxDepth: 120}
-			p := pg.GenerateToft()
-			ctx.PlanetID = p.ID
-			ctx.Depth = 0
-			ctx.BiomeType = int(p.Biome)
-			ecs.SetWorldContext(w, ctx)
-		}
-	}
-}
-
 func DescribePlanet(p data.Planet) string {
 	return fmt.Sprintf("%s (%d)", p.Name, p.MaxDepth)
 }
@@ -1,6 +1,8 @@
 package systems
 
 import (
+	"math"
+
 	"github.com/charmbracelet/harmonica"
 	"harvester/pkg/components"
 	"harvester/pkg/data"
@@ -18,90 +20,140 @@ func ApplyDirectionalVelocity(w *ecs.World, e ecs.Entity, dx, dy float64) {
 
 type SpaceObjects struct{}
 
-type FuelSystem struct{}
+type SpaceMovement struct{}
 
-func (s FuelSystem) Update(dt float64, w *ecs.World) {
-	ecs.View2Of[components.FuelTank, components.Velocity](w).Each(func(t ecs.Tuple2[components.FuelTank, components.Velocity]) {
-		burn := int((abs(t.B.VX)+abs(t.B.VY))*dt) + 1
-		if t.A.Current > 0 {
-			t.A.Current -= burn
-			if t.A.Current < 0 {
-				t.A.Current = 0
-			}
-			ecs.Add(w, t.E, *t.A)
+// SpaceMovement flies ships: it eases the heading and thrust springs toward
+// the player's input and integrates thrust, planetary gravity and hazards in
+// fixed physicsStep increments, sweeping each step for asteroid and comet
+// collisions. Out in free space the velocity springs damp
+// the ship toward rest; inside a planet's sphere of influence the ship
+// coasts freely so it can hold an orbit, damped only while braking.
+func (s SpaceMovement) Update(dt float64, w *ecs.World) {
+	obstacles := spaceObstacles(w)
+	ecs.View2Of[components.Position, components.Velocity](w).Each(func(t ecs.Tuple2[components.Position, components.Velocity]) {
+		clk, _ := ecs.Get[physicsClock](w, t.E)
+		clk.Acc += dt
+		pos, vel := *t.A, *t.B
+		// the epsilon keeps float error from dropping a step
+		for n := 0; clk.Acc >= physicsStep-1e-9 && n < maxPhysicsSteps; n++ {
+			pos, vel = stepShip(w, t.E, obstacles, pos, vel, physicsStep)
+			clk.Acc -= physicsStep
 		}
+		if clk.Acc >= physicsStep {
+			// too far behind to catch up; drop the backlog
+			clk.Acc = 0
+		}
+		clk.Acc = max(clk.Acc, 0)
+		ecs.Add(w, t.E, pos)
+		ecs.Add(w, t.E, vel)
+		ecs.Add(w, t.E, clk)
 	})
 }
 
-type SpaceMovement struct{}
-
-func (s SpaceMovement) Update(dt float64, w *ecs.World) {
-	const angW, angZ = 6.0, 0.6
+// stepShip advances ship e by one fixed step of dt.
+func stepShip(w *ecs.World, e ecs.Entity, obstacles obstacleIndex, pos components.Position, vel components.Velocity, dt float64) (components.Position, components.Velocity) {
+	const angZ = 0.6
 	const thrW, thrZ = 5.0, 0.7
 	const velW, velZ = 6.0, 0.6
-	spAngle := harmonica.NewSpring(harmonica.FPS(60), angW, angZ)
 	spThrust := harmonica.NewSpring(harmonica.FPS(60), thrW, thrZ)
 	spVel := harmonica.NewSpring(harmonica.FPS(60), velW, velZ)
-	ecs.View2Of[components.Position, components.Velocity](w).Each(func(t ecs.Tuple2[components.Position, components.Velocity]) {
-		// fetch springs and input/orientation
-		spr, _ := ecs.Get[components.SpaceFlightSprings](w, t.E)
-		inp, _ := ecs.Get[components.Input](w, t.E)
-		// update springs
-		spr.Angle.Pos, spr.Angle.Vel = spAngle.Update(spr.Angle.Pos, spr.Angle.Vel, spr.Angle.Target)
-		spr.Thrust.Pos, spr.Thrust.Vel = spThrust.Update(spr.Thrust.Pos, spr.Thrust.Vel, spr.Thrust.Target)
-		if inp.Down {
-			spr.VelX.Target, spr.VelY.Target = 0, 0
-		}
-		spr.VelX.Pos, spr.VelX.Vel = spVel.Update(spr.VelX.Pos, spr.VelX.Vel, spr.VelX.Target)
-		spr.VelY.Pos, spr.VelY.Vel = spVel.Update(spr.VelY.Pos, spr.VelY.Vel, spr.VelY.Target)
-		// physics integration: accel from thrust+angle
-		ax := spr.Thrust.Pos * cos(spr.Angle.Pos)
-		ay := spr.Thrust.Pos * sin(spr.Angle.Pos)
-		vx := t.B.VX + ax*dt
-		vy := t.B.VY + ay*dt
+	// fetch springs and input/orientation
+	spr, _ := ecs.Get[components.SpaceFlightSprings](w, e)
+	inp, _ := ecs.Get[components.Input](w, e)
+	if OutOfFuel(w, e) {
+		// a dry ship drifts: no thrust, no turning, no braking
+		inp = components.Input{}
+		spr.Thrust.Target = 0
+	}
+	// update springs; a better drive stiffens the heading spring
+	spAngle := harmonica.NewSpring(harmonica.FPS(60), data.DriveTurn(UpgradeTier(w, e, data.Drive)), angZ)
+	spr.Angle.Pos, spr.Angle.Vel = spAngle.Update(spr.Angle.Pos, spr.Angle.Vel, spr.Angle.Target)
+	spr.Thrust.Pos, spr.Thrust.Vel = spThrust.Update(spr.Thrust.Pos, spr.Thrust.Vel, spr.Thrust.Target)
+	if inp.Down {
+		spr.VelX.Target, spr.VelY.Target = 0, 0
+	}
+	spr.VelX.Pos, spr.VelX.Vel = spVel.Update(spr.VelX.Pos, spr.VelX.Vel, spr.VelX.Target)
+	spr.VelY.Pos, spr.VelY.Vel = spVel.Update(spr.VelY.Pos, spr.VelY.Vel, spr.VelY.Target)
+	// physics integration: accel from thrust+angle, planetary gravity and
+	// black holes, semi-implicit so orbits hold their energy
+	px, py, body := gravityAt(w, pos.X, pos.Y)
+	gx, gy, drag := hazardPull(w, pos.X, pos.Y, dt)
+	ax := spr.Thrust.Pos*cos(spr.Angle.Pos) + px + gx
+	ay := spr.Thrust.Pos*sin(spr.Angle.Pos) + py + gy
+	vx := vel.VX + ax*dt
+	vy := vel.VY + ay*dt
+	if body == 0 || inp.Down {
 		// apply velocity springs as damping toward targets
 		vx, _ = spVel.Update(vx, 0, spr.VelX.Pos)
 		vy, _ = spVel.Update(vy, 0, spr.VelY.Pos)
-		t.A.X += vx * dt
-		t.A.Y += vy * dt
-		t.B.VX, t.B.VY = vx, vy
-		ecs.Add(w, t.E, *t.A)
-		ecs.Add(w, t.E, *t.B)
-		ecs.Add(w, t.E, spr)
-	})
+	}
+	// nebulae bleed off speed
+	vx *= 1 - drag
+	vy *= 1 - drag
+	prev := pos
+	pos.X += vx * dt
+	pos.Y += vy * dt
+	vel.VX, vel.VY = vx, vy
+	pos, vel = collideShip(w, e, obstacles, prev, pos, vel)
+	pos, vel = applyHazards(w, e, prev, pos, vel, dt)
+	trackOrbit(w, e, body, pos, vel)
+	ecs.Add(w, e, spr)
+	return pos, vel
 }
 
+// EventApproach is emitted when the ship comes in too fast to land.
+const EventApproach = "approach"
+
+// PlanetApproachSystem lands the ship on the planet under it when the
+// player presses '>' slowly enough.
 type PlanetApproachSystem struct{}
 
 func (s PlanetApproachSystem) Update(dt float64, w *ecs.World) {
-	ctx := ecs.GetWorldContext(w)
-	_ = dt
-	// Enter planet when player presses '>' over a planet glyph '1','2','3'
-	pressed := false
-	ecs.View2Of[EnterPlanet, components.Position](w).Each(func(t ecs.Tuple2[EnterPlanet, components.Position]) { pressed = true })
-	if !pressed {
+	player, pos, ok := findPlayer(w)
+	if !ok {
 		return
 	}
-	playerPos := components.Position{}
-	ecs.View2Of[components.Player, components.Position](w).Each(func(t ecs.Tuple2[components.Player, components.Position]) {
-		playerPos = *t.B
-	})
-	enterID := -1
-	ecs.View2Of[components.Position, components.Renderable](w).Each(func(t ecs.Tuple2[components.Position, components.Renderable]) {
-		if t.B.Glyph >= '1' && t.B.Glyph <= '3' {
-			if int(t.A.X) == int(playerPos.X) && int(t.A.Y) == int(playerPos.Y) {
-				enterID = int(t.B.Glyph - '0')
-			}
+	if _, pressed := ecs.Get[EnterPlanet](w, player); !pressed {
+		return
+	}
+	card, found := planetAt(w, int(pos.X), int(pos.Y))
+	if !found {
+		return
+	}
+	// the key press is spent either way; on the surface it would ask to launch
+	ecs.Remove[EnterPlanet](w, player)
+	// planets sit still, so the ship's speed is its speed relative to them
+	if v, _ := ecs.Get[components.Velocity](w, player); math.Hypot(v.VX, v.VY) > landingSpeed {
+		itemEvent(w, ecs.Event{Kind: EventApproach, Source: player},
+			"Too fast to land: %.1f, slow below %.0f.", math.Hypot(v.VX, v.VY), landingSpeed)
+		return
+	}
+	// the surface has its own map: set down in the middle of it and
+	// remember where in space to come back to
+	ecs.Add(w, player, Departure{X: pos.X, Y: pos.Y})
+	if wi, ok := ecs.Get[components.WorldInfo](w, 1); ok {
+		ecs.Add(w, player, components.Position{X: float64(wi.Width / 2), Y: float64(wi.Height / 2)})
+	}
+	ctx := ecs.GetWorldContext(w)
+	ctx.CurrentLayer = ecs.LayerPlanetSurface
+	ctx.PlanetID = card.Planet.ID
+	ctx.BiomeType = int(card.Planet.Biome)
+	ctx.Depth = 0
+	ecs.SetWorldContext(w, ctx)
+}
+
+// planetAt returns the planet card on tile (x, y).
+func planetAt(w *ecs.World, x, y int) (PlanetCard, bool) {
+	var (
+		card  PlanetCard
+		found bool
+	)
+	ecs.View2Of[PlanetCard, components.Position](w).Each(func(t ecs.Tuple2[PlanetCard, components.Position]) {
+		if !found && int(t.B.X) == x && int(t.B.Y) == y {
+			card, found = *t.A, true
 		}
 	})
-	if enterID > 0 {
-		pg := data.PlanetGenerator{Seed: int64(enterID), Biome: data.BiomeToftForest, MaxDepth: 120}
-		p := pg.GenerateToft()
-		ctx.CurrentLayer = ecs.LayerPlanetSurface
-		ctx.PlanetID = p.ID
-		ctx.Depth = 0
-		ecs.SetWorldContext(w, ctx)
-	}
+	return card, found
 }
 
 func abs(f float64) float64 {
@@ -2,15 +2,12 @@ package systems
 
 import (
 	"harvester/pkg/components"
+	"harvester/pkg/data"
 	"harvester/pkg/ecs"
 )
 
 type SurfaceHeartbeat struct{}
 
-type DepthProgression struct{}
-
-type TerrainGen struct{}
-
 type SurfaceMovement struct{}
 
 func (s SurfaceHeartbeat) Update(dt float64, w *ecs.World) {
@@ -22,105 +19,9 @@ func (s SurfaceHeartbeat) Update(dt float64, w *ecs.World) {
 	ecs.Add(w, 1, wi)
 }
 
-func (t TerrainGen) Update(dt float64, w *ecs.World) {
-	ctx := ecs.GetWorldContext(w)
-	wi, ok := ecs.Get[components.WorldInfo](w, 1)
-	if !ok {
-		return
-	}
-	seed := int64(ctx.PlanetID*100000 + ctx.Depth)
-	r := ecs.RandFromSeed(seed)
-	for y := 0; y < wi.Height; y++ {
-		for x := 0; x < wi.Width; x++ {
-			if (x+y+int(r.Int63()%7))%17 == 0 {
-				e := w.Create()
-				ecs.Add(w, e, components.Position{X: float64(x), Y: float64(y)})
-				ecs.Add(w, e, components.Tile{Glyph: '#', Type: components.TileForest})
-			}
-		}
-	}
-	// simple river: vertical line
-	x0 := wi.Width/3 + int(r.Int63()%5)
-	for y := 0; y < wi.Height; y++ {
-		e := w.Create()
-		ecs.Add(w, e, components.Position{X: float64(x0), Y: float64(y)})
-		ecs.Add(w, e, components.Tile{Glyph: '~', Type: components.TileRiver})
-		ecs.Add(w, e, components.RiverTag{})
-
-		// Make river slightly transparent to show terrain underneath
-		ecs.Add(w, e, components.Transparency{
-			Alpha:     0.8, // 80% opacity - slightly see-through
-			BlendMode: components.BlendNormal,
-		})
-	}
-
-	// Add some fog patches for atmosphere
-	fogCount := int(r.Int63() % 5) // 0-4 fog patches
-	for i := 0; i < fogCount; i++ {
-		fx := int(r.Int63() % int64(wi.Width))
-		fy := int(r.Int63() % int64(wi.Height))
-
-		// Create a small fog patch (3x3 area)
-		for dy := -1; dy <= 1; dy++ {
-			for dx := -1; dx <= 1; dx++ {
-				x, y := fx+dx, fy+dy
-				if x >= 0 && x < wi.Width && y >= 0 && y < wi.Height {
-					// Random chance for each fog cell
-					if r.Float64() < 0.6 {
-						e := w.Create()
-						ecs.Add(w, e, components.Position{X: float64(x), Y: float64(y)})
-						ecs.Add(w, e, components.Tile{Glyph: '░', Type: components.TileForest})
-						ecs.Add(w, e, components.Transparency{
-							Alpha:     0.2 + r.Float64()*0.3, // 20-50% opacity
-							BlendMode: components.BlendNormal,
-						})
-					}
-				}
-			}
-		}
-	}
-}
-
-func (d DepthProgression) Update(dt float64, w *ecs.World) {
-	ctx := ecs.GetWorldContext(w)
-	var in *components.Input
-	var playerPos components.Position
-	ecs.View3Of[components.Player, components.Input, components.Position](w).Each(func(t ecs.Tuple3[components.Player, components.Input, components.Position]) {
-		in = t.B
-		playerPos = *t.C
-	})
-	if in == nil {
-		return
-	}
-	we, _ := ecs.Get[components.Weather](w, 1)
-	step := 1
-	if we.Rain {
-		step = 2
-	}
-	river := false
-	ecs.View2Of[components.Position, components.RiverTag](w).Each(func(t ecs.Tuple2[components.Position, components.RiverTag]) {
-		if int(t.A.X) == int(playerPos.X) && int(t.A.Y) == int(playerPos.Y) {
-			river = true
-		}
-	})
-	if river {
-		step++
-	}
-	if in.Down && ctx.Depth < 10000 {
-		ctx.Depth += step
-	}
-	if in.Up && ctx.Depth > 0 {
-		ctx.Depth -= step
-		if ctx.Depth < 0 {
-			ctx.Depth = 0
-		}
-	}
-	ecs.SetWorldContext(w, ctx)
-}
-
 func (s SurfaceMovement) Update(dt float64, w *ecs.World) {
 	ctx := ecs.GetWorldContext(w)
-	if ctx.CurrentLayer != ecs.LayerPlanetSurface {
+	if ctx.CurrentLayer != ecs.LayerPlanetSurface && ctx.CurrentLayer != ecs.LayerPlanetDeep {
 		return
 	}
 	var in *components.Input
@@ -148,11 +49,26 @@ func (s SurfaceMovement) Update(dt float64, w *ecs.World) {
 	if dx == 0 && dy == 0 {
 		return
 	}
-	we, _ := ecs.Get[components.Weather](w, 1)
-	speed := 1.0
-	if we.Rain {
-		speed *= 0.5
+	// Bump-to-talk and bump-to-attack: stepping into a speaker talks to it and
+	// stepping into anything with health swings at it instead
+	tx, ty := int(p.X)+int(dx), int(p.Y)+int(dy)
+	if s := speakerAt(w, tx, ty); s != 0 {
+		ecs.View1Of[components.Player](w).Each(func(e ecs.Entity, _ *components.Player) { SpeakTo(w, e, s) })
+		return
+	}
+	if target := fighterAt(w, tx, ty); target != 0 {
+		ecs.View1Of[components.Player](w).Each(func(e ecs.Entity, _ *components.Player) {
+			if e != target {
+				ecs.Add(w, e, AttackIntent{Target: target})
+			}
+		})
+		return
+	}
+	if Navigation(w).Blocked(tx, ty) {
+		return
 	}
+	kind, strength := WeatherAt(w, int(p.X), int(p.Y))
+	speed := 1 / (1 + data.EffectOf(kind).Drag*strength)
 	onRiver := false
 	ecs.View2Of[components.Position, components.RiverTag](w).Each(func(t ecs.Tuple2[components.Position, components.RiverTag]) {
 		if int(t.A.X) == int(p.X) && int(t.A.Y) == int(p.Y) {
@@ -167,3 +83,17 @@ func (s SurfaceMovement) Update(dt float64, w *ecs.World) {
 	ecs.View1Of[components.Player](w).Each(func(e ecs.Entity, _ *components.Player) { ecs.Add(w, e, *p) })
 	_ = ctx
 }
+
+// fighterAt returns a non-player entity with health standing on (x, y), or 0.
+func fighterAt(w *ecs.World, x, y int) ecs.Entity {
+	var found ecs.Entity
+	ecs.View2Of[components.Position, components.Health](w).Each(func(t ecs.Tuple2[components.Position, components.Health]) {
+		if found != 0 || int(t.A.X) != x || int(t.A.Y) != y {
+			return
+		}
+		if _, isPlayer := ecs.Get[components.Player](w, t.E); !isPlayer {
+			found = t.E
+		}
+	})
+	return found
+}
@@ -0,0 +1,478 @@
+package systems
+
+import (
+	"math/rand"
+
+	"harvester/pkg/components"
+	"harvester/pkg/data"
+	"harvester/pkg/ecs"
+	"harvester/pkg/noise"
+)
+
+// Surface terrain is generated once per level, a planet at a depth, from
+// three noise fields: elevation, moisture and temperature. The features of
+// the depth layer are then laid over it. Levels below the surface are
+// dungeons instead, see dungeon.go. The level is remembered on entity 1, so
+// TerrainGen only rebuilds the map when the player changes level.
+
+func init() {
+	ecs.RegisterComponent[TerrainLevel]()
+	ecs.RegisterComponent[TerrainTile]()
+}
+
+// TerrainLevel is the level the terrain on the map was generated for. It
+// lives on entity 1 beside WorldInfo.
+type TerrainLevel struct {
+	PlanetID, Depth int
+	Width, Height   int
+}
+
+// TerrainTile tags generated terrain so the next level can clear it.
+type TerrainTile struct{}
+
+// TerrainGen generates the terrain of the current level when it changes.
+// The contents of the level left are stashed in the LevelCache and those of
+// the level arrived at restored from it. A player who took the stairs is put
+// on the new level's stairs back.
+type TerrainGen struct {
+	// Frozen keeps revisited levels as they were left instead of catching
+	// up on the time away.
+	Frozen bool
+}
+
+func (tg TerrainGen) Update(dt float64, w *ecs.World) {
+	ctx := ecs.GetWorldContext(w)
+	wi, ok := ecs.Get[components.WorldInfo](w, 1)
+	if !ok || wi.Width <= 0 || wi.Height <= 0 {
+		return
+	}
+	level := TerrainLevel{PlanetID: ctx.PlanetID, Depth: ctx.Depth, Width: wi.Width, Height: wi.Height}
+	prev, had := ecs.Get[TerrainLevel](w, 1)
+	if had && prev == level {
+		return
+	}
+	if had && (prev.PlanetID != level.PlanetID || prev.Depth != level.Depth) {
+		StashLevel(w, prev.PlanetID, prev.Depth)
+	}
+	var old []ecs.Entity
+	ecs.View1Of[TerrainTile](w).Each(func(e ecs.Entity, _ *TerrainTile) { old = append(old, e) })
+	for _, e := range old {
+		w.Destroy(e)
+	}
+	if ctx.CurrentLayer == ecs.LayerPlanetDeep {
+		GenerateDungeon(w, ctx, wi)
+	} else {
+		GenerateTerrain(w, ctx, wi)
+	}
+	ecs.Add(w, 1, level)
+	RestoreLevel(w, level.PlanetID, level.Depth, tg.Frozen)
+	if had && prev.PlanetID == level.PlanetID && prev.Depth != level.Depth {
+		arriveByStairs(w, prev.Depth)
+	}
+}
+
+// terrainCell is what one map cell holds. The zero cell is open ground and
+// gets no entity.
+type terrainCell struct {
+	glyph rune
+	kind  components.TileType
+	water bool // slows movement like a river
+	mist  bool // drawn see-through
+}
+
+// passable reports whether the cell can be walked onto; it mirrors
+// buildSurfaceGrid.
+func (c terrainCell) passable() bool {
+	switch c.kind {
+	case components.TileMountain, components.TileLava, components.TileStructure:
+		return false
+	}
+	return true
+}
+
+var (
+	openGround = terrainCell{}
+	mountain   = terrainCell{glyph: '^', kind: components.TileMountain}
+	forest     = terrainCell{glyph: '#', kind: components.TileForest}
+	water      = terrainCell{glyph: '~', kind: components.TileRiver, water: true}
+	lava       = terrainCell{glyph: '≈', kind: components.TileLava}
+	ice        = terrainCell{glyph: '≡', kind: components.TileIce}
+	mist       = terrainCell{glyph: '░', kind: components.TileForest, mist: true}
+	hut        = terrainCell{glyph: '⌂', kind: components.TileStructure}
+	ruinWall   = terrainCell{glyph: '▓', kind: components.TileStructure}
+	borderWall = terrainCell{glyph: '║', kind: components.TileStructure}
+)
+
+// biomeHeat shifts temperature by biome.
+var biomeHeat = map[data.BiomeType]float64{
+	data.BiomeVolcanic: 0.2,
+	data.BiomeIce:      -0.2,
+}
+
+// terrainGen builds one level's map cell by cell before any entity is made.
+type terrainGen struct {
+	r             *rand.Rand
+	width, height int
+	elevation     noise.Field
+	cells         []terrainCell
+}
+
+func (g *terrainGen) in(x, y int) bool { return x >= 0 && y >= 0 && x < g.width && y < g.height }
+
+func (g *terrainGen) at(x, y int) terrainCell { return g.cells[y*g.width+x] }
+
+func (g *terrainGen) set(x, y int, c terrainCell) {
+	if g.in(x, y) {
+		g.cells[y*g.width+x] = c
+	}
+}
+
+// levelSeed mixes the world seed with a planet and depth.
+func levelSeed(seed int64, planet, depth int) int64 {
+	return sectorSeed(seed, SectorCoord{X: planet, Y: depth})
+}
+
+func newTerrainGen(seed int64, wi components.WorldInfo) *terrainGen {
+	return &terrainGen{
+		r:         rand.New(rand.NewSource(seed)),
+		width:     wi.Width,
+		height:    wi.Height,
+		elevation: noise.New(seed, 24, 4),
+		cells:     make([]terrainCell, wi.Width*wi.Height),
+	}
+}
+
+// GenerateTerrain lays out the current level's terrain and the features of
+// its depth layer, with the stairs down. The same world seed, planet and
+// depth always give the same map. The middle of the map, where ships set
+// down, is left open so nobody lands inside rock, and passes are dug so
+// every sizeable open area can be reached from it.
+func GenerateTerrain(w *ecs.World, ctx ecs.WorldContext, wi components.WorldInfo) {
+	seed := levelSeed(w.Seed(), ctx.PlanetID, ctx.Depth)
+	biome := data.BiomeType(ctx.BiomeType)
+	g := newTerrainGen(seed, wi)
+	moisture := noise.New(seed+1, 32, 3)
+	temperature := noise.New(seed+2, 48, 2)
+	// deeper is warmer
+	heat := biomeHeat[biome] + min(float64(ctx.Depth)/800, 0.1)
+	for y := 0; y < g.height; y++ {
+		for x := 0; x < g.width; x++ {
+			fx, fy := float64(x), float64(y)
+			e := g.elevation.At(fx, fy)
+			// damped so that biome and depth decide the climate, and high
+			// ground is colder
+			t := 0.5 + 0.6*(temperature.At(fx, fy)-0.5) + heat - 0.3*(e-0.5)
+			g.set(x, y, classify(e, moisture.At(fx, fy), t))
+		}
+	}
+
+	for _, f := range DepthLayerOf(ctx).UniqueFeatures {
+		if place, ok := terrainFeatures[f]; ok {
+			place(g)
+		}
+	}
+
+	cx, cy := g.width/2, g.height/2
+	for dy := -1; dy <= 1; dy++ {
+		for dx := -1; dx <= 1; dx++ {
+			g.set(cx+dx, cy+dy, openGround)
+		}
+	}
+	g.connect(minArea, nil)
+	g.spawn(w)
+
+	label, _ := g.regions()
+	if x, y, ok := g.pick(label, label[cy*g.width+cx], cx, cy, stairsAway); ok {
+		bottom := landedPlanet(w, ctx).MaxDepth
+		spawnStairs(w, x, y, nextDepth(ctx, 0, bottom))
+	}
+}
+
+// spawn creates an entity for every cell that is not open ground.
+func (g *terrainGen) spawn(w *ecs.World) {
+	for y := 0; y < g.height; y++ {
+		for x := 0; x < g.width; x++ {
+			c := g.at(x, y)
+			if c == openGround {
+				continue
+			}
+			e := w.Create()
+			ecs.Add(w, e, components.Position{X: float64(x), Y: float64(y)})
+			ecs.Add(w, e, components.Tile{Glyph: c.glyph, Type: c.kind})
+			ecs.Add(w, e, TerrainTile{})
+			switch {
+			case c.water:
+				ecs.Add(w, e, components.RiverTag{})
+				// slightly see-through to show the bed underneath
+				ecs.Add(w, e, components.Transparency{Alpha: 0.8, BlendMode: components.BlendNormal})
+			case c.mist:
+				ecs.Add(w, e, components.Transparency{Alpha: 0.2 + g.r.Float64()*0.3, BlendMode: components.BlendNormal})
+			}
+		}
+	}
+}
+
+// minArea is the smallest open area worth digging through to.
+const minArea = 8
+
+// regions labels the passable cells by the open area they belong to,
+// joined four ways like movement, and returns each cell's label, -1 for
+// impassable cells, and the size of each area.
+func (g *terrainGen) regions() ([]int, []int) {
+	label := make([]int, len(g.cells))
+	for i := range label {
+		label[i] = -1
+	}
+	var sizes, stack []int
+	for i, c := range g.cells {
+		if label[i] >= 0 || !c.passable() {
+			continue
+		}
+		id := len(sizes)
+		sizes = append(sizes, 0)
+		label[i] = id
+		stack = append(stack[:0], i)
+		for len(stack) > 0 {
+			j := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			sizes[id]++
+			for _, k := range g.neighbours(j) {
+				if label[k] < 0 && g.cells[k].passable() {
+					label[k] = id
+					stack = append(stack, k)
+				}
+			}
+		}
+	}
+	return label, sizes
+}
+
+// neighbours returns the cells four ways around cell i.
+func (g *terrainGen) neighbours(i int) []int {
+	x, y := i%g.width, i/g.width
+	out := make([]int, 0, 4)
+	for _, d := range [4][2]int{{1, 0}, {-1, 0}, {0, 1}, {0, -1}} {
+		if g.in(x+d[0], y+d[1]) {
+			out = append(out, (y+d[1])*g.width+x+d[0])
+		}
+	}
+	return out
+}
+
+// largest returns the label of the biggest area.
+func largest(sizes []int) int {
+	best := 0
+	for id, n := range sizes {
+		if n > sizes[best] {
+			best = id
+		}
+	}
+	return best
+}
+
+// connect digs from every open area of at least smallest cells to the largest,
+// so all of them can be walked to. Smaller pockets are filled with fill, or
+// left alone if fill is nil.
+func (g *terrainGen) connect(smallest int, fill *terrainCell) {
+	label, sizes := g.regions()
+	if len(sizes) == 0 {
+		return
+	}
+	main := largest(sizes)
+	done := make([]bool, len(sizes))
+	done[main] = true
+	for i, id := range label {
+		if id < 0 || done[id] {
+			continue
+		}
+		if sizes[id] < smallest {
+			if fill != nil {
+				g.cells[i] = *fill
+			}
+			continue
+		}
+		done[id] = true
+		g.dig(i, label, main)
+	}
+}
+
+// dig clears the shortest way from cell i to the area labelled to, turning
+// whatever blocks it into open ground.
+func (g *terrainGen) dig(i int, label []int, to int) {
+	from := make([]int, len(g.cells))
+	for j := range from {
+		from[j] = -1
+	}
+	from[i] = i
+	queue := []int{i}
+	for len(queue) > 0 {
+		j := queue[0]
+		queue = queue[1:]
+		if label[j] == to {
+			for ; j != i; j = from[j] {
+				if !g.cells[j].passable() {
+					g.cells[j] = openGround
+				}
+			}
+			return
+		}
+		for _, k := range g.neighbours(j) {
+			if from[k] < 0 {
+				from[k] = j
+				queue = append(queue, k)
+			}
+		}
+	}
+}
+
+// pick returns a random open-ground cell in area id, trying for one at
+// least away tiles from (fx, fy). It reports false if the area has no open
+// ground.
+func (g *terrainGen) pick(label []int, id, fx, fy, away int) (int, int, bool) {
+	var spots []int
+	for i, l := range label {
+		if l == id && g.cells[i] == openGround {
+			spots = append(spots, i)
+		}
+	}
+	if len(spots) == 0 {
+		return 0, 0, false
+	}
+	var i int
+	for try := 0; try < 50; try++ {
+		i = spots[g.r.Intn(len(spots))]
+		if chebyshev(i%g.width, i/g.width, fx, fy) >= away {
+			break
+		}
+	}
+	return i % g.width, i / g.width, true
+}
+
+// classify picks a cell's terrain from its elevation, moisture and
+// temperature.
+func classify(e, m, t float64) terrainCell {
+	switch {
+	case e > 0.64:
+		return mountain
+	case t > 0.85:
+		return lava
+	case e < 0.32 && t < 0.25:
+		return ice
+	case e < 0.32:
+		return water
+	case t < 0.2 && m > 0.45:
+		return ice
+	case m > 0.7 && e < 0.42:
+		return mist
+	case m > 0.56 && t < 0.75:
+		return forest
+	}
+	return openGround
+}
+
+// terrainFeatures places the depth layers' UniqueFeatures. Features
+// without a placer are flavour only.
+var terrainFeatures = map[string]func(*terrainGen){
+	"rivers":               func(g *terrainGen) { g.river(water) },
+	"lava_flows":           func(g *terrainGen) { g.river(lava) },
+	"lava_tubes":           func(g *terrainGen) { g.river(lava) },
+	"villages":             (*terrainGen).villages,
+	"highlands":            func(g *terrainGen) { g.ridge(false) },
+	"mountain_passes":      func(g *terrainGen) { g.ridge(true) },
+	"kingdom_borders":      (*terrainGen).border,
+	"abandoned_structures": (*terrainGen).ruins,
+	"sealed_halls":         (*terrainGen).ruins,
+	"data_vaults":          (*terrainGen).ruins,
+	"frozen_lakes":         (*terrainGen).freeze,
+}
+
+// river runs a channel of c from the top edge to the bottom, always
+// stepping to the lowest of the three cells below.
+func (g *terrainGen) river(c terrainCell) {
+	x := g.r.Intn(g.width)
+	for y := 0; y < g.height; y++ {
+		g.set(x, y, c)
+		next, low := x, 2.0
+		for dx := -1; dx <= 1; dx++ {
+			if nx := x + dx; g.in(nx, y+1) {
+				if e := g.elevation.At(float64(nx), float64(y+1)); e < low {
+					next, low = nx, e
+				}
+			}
+		}
+		x = next
+	}
+}
+
+// villages builds two or three clusters of huts in clearings.
+func (g *terrainGen) villages() {
+	for v := 2 + g.r.Intn(2); v > 0; v-- {
+		cx, cy := 4+g.r.Intn(max(1, g.width-8)), 3+g.r.Intn(max(1, g.height-6))
+		for dy := -3; dy <= 3; dy++ {
+			for dx := -5; dx <= 5; dx++ {
+				g.set(cx+dx, cy+dy, openGround)
+			}
+		}
+		for h := 3 + g.r.Intn(3); h > 0; h-- {
+			g.set(cx-4+g.r.Intn(9), cy-2+g.r.Intn(5), hut)
+		}
+	}
+}
+
+// ridge throws a mountain range diagonally across the map. With passes it
+// leaves a gap every twenty columns to get through.
+func (g *terrainGen) ridge(passes bool) {
+	y0 := g.r.Intn(g.height)
+	slope := float64(g.height) / float64(g.width) * (g.r.Float64() - 0.5)
+	gap := g.r.Intn(20)
+	for x := 0; x < g.width; x++ {
+		if passes && (x+gap)%20 < 3 {
+			continue
+		}
+		y := y0 + int(slope*float64(x))
+		for dy := -1; dy <= 1; dy++ {
+			g.set(x, y+dy, mountain)
+		}
+	}
+}
+
+// border walls the map in two down the middle, with a gate every fifteen
+// rows.
+func (g *terrainGen) border() {
+	x := g.width/3 + g.r.Intn(max(1, g.width/3))
+	for y := 0; y < g.height; y++ {
+		if y%15 < 2 {
+			g.set(x, y, openGround)
+			continue
+		}
+		g.set(x, y, borderWall)
+	}
+}
+
+// ruins leaves three to five walled rooms, each with a doorway.
+func (g *terrainGen) ruins() {
+	for n := 3 + g.r.Intn(3); n > 0; n-- {
+		rw, rh := 5+g.r.Intn(5), 3+g.r.Intn(3)
+		x0, y0 := g.r.Intn(max(1, g.width-rw)), g.r.Intn(max(1, g.height-rh))
+		for y := y0; y < y0+rh; y++ {
+			for x := x0; x < x0+rw; x++ {
+				edge := x == x0 || y == y0 || x == x0+rw-1 || y == y0+rh-1
+				if edge {
+					g.set(x, y, ruinWall)
+				} else {
+					g.set(x, y, openGround)
+				}
+			}
+		}
+		g.set(x0+rw/2, y0+rh-1, openGround)
+	}
+}
+
+// freeze turns standing water to ice.
+func (g *terrainGen) freeze() {
+	for i, c := range g.cells {
+		if c == water {
+			g.cells[i] = ice
+		}
+	}
+}
@@ -0,0 +1,137 @@
+package systems
+
+import (
+	"testing"
+
+	"harvester/pkg/components"
+	"harvester/pkg/data"
+	"harvester/pkg/ecs"
+)
+
+// terrainGlyphs counts the generated terrain by glyph.
+func terrainGlyphs(w *ecs.World) map[rune]int {
+	out := map[rune]int{}
+	ecs.View2Of[TerrainTile, components.Tile](w).Each(func(t ecs.Tuple2[TerrainTile, components.Tile]) {
+		out[t.B.Glyph]++
+	})
+	return out
+}
+
+func terrainCount(w *ecs.World) int {
+	n := 0
+	ecs.View1Of[TerrainTile](w).Each(func(ecs.Entity, *TerrainTile) { n++ })
+	return n
+}
+
+func TestTerrainIsGeneratedOncePerLevel(t *testing.T) {
+	w, _ := newTestWorld(1, mapSize(200, 80), playerAt(100, 40), onPlanet(data.BiomeToftForest, 0))
+	TerrainGen{}.Update(0, w)
+	n := terrainCount(w)
+	if n == 0 {
+		t.Fatal("expected terrain")
+	}
+	for i := 0; i < 20; i++ {
+		TerrainGen{}.Update(0, w)
+	}
+	if got := terrainCount(w); got != n {
+		t.Fatalf("terrain should be cached between ticks: %d then %d tiles", n, got)
+	}
+
+	pile := SpawnPile(w, 3, 3, "ore", 1)
+	ctx := ecs.GetWorldContext(w)
+	ctx.Depth = 30
+	ecs.SetWorldContext(w, ctx)
+	TerrainGen{}.Update(0, w)
+	if got := terrainCount(w); got == 0 || got > 200*80 {
+		t.Fatalf("a new level should replace the terrain, got %d tiles", got)
+	}
+	if _, ok := ecs.Get[components.Resource](w, pile); ok {
+		t.Fatal("the pile belongs to the level left and should be stashed with it")
+	}
+}
+
+func TestTerrainIsDeterministic(t *testing.T) {
+	a, _ := newTestWorld(1, mapSize(200, 80), playerAt(100, 40), onPlanet(data.BiomeVolcanic, 20))
+	b, _ := newTestWorld(1, mapSize(200, 80), playerAt(100, 40), onPlanet(data.BiomeVolcanic, 20))
+	TerrainGen{}.Update(0, a)
+	TerrainGen{}.Update(0, b)
+	ga, gb := terrainGlyphs(a), terrainGlyphs(b)
+	for g, n := range ga {
+		if gb[g] != n {
+			t.Fatalf("same level should give the same map: %q %d vs %d", g, n, gb[g])
+		}
+	}
+}
+
+func TestTerrainFollowsBiome(t *testing.T) {
+	hot, _ := newTestWorld(1, mapSize(200, 80), playerAt(100, 40), onPlanet(data.BiomeVolcanic, 0))
+	cold, _ := newTestWorld(1, mapSize(200, 80), playerAt(100, 40), onPlanet(data.BiomeIce, 0))
+	TerrainGen{}.Update(0, hot)
+	TerrainGen{}.Update(0, cold)
+	h, c := terrainGlyphs(hot), terrainGlyphs(cold)
+	if h['≈'] <= c['≈'] || c['≡'] <= h['≡'] {
+		t.Fatalf("volcanic worlds should run to lava and ice worlds to ice: hot %v, cold %v", h, c)
+	}
+}
+
+func TestTerrainPlacesLayerFeatures(t *testing.T) {
+	cases := []struct {
+		biome data.BiomeType
+		depth int
+		glyph rune
+		what  string
+	}{
+		{data.BiomeToftForest, 0, '⌂', "villages on Toft's surface"},
+		{data.BiomeToftForest, 80, '║', "a kingdom border in Toft's deep wilds"},
+		{data.BiomeAncientRuins, 0, '▓', "ruined walls on Mechanicus"},
+	}
+	for _, tc := range cases {
+		w, _ := newTestWorld(1, mapSize(200, 80), playerAt(100, 40), onPlanet(tc.biome, tc.depth))
+		TerrainGen{}.Update(0, w)
+		if terrainGlyphs(w)[tc.glyph] == 0 {
+			t.Errorf("expected %s", tc.what)
+		}
+	}
+
+	// Toft's rivers run top to bottom and slow travel
+	w, _ := newTestWorld(1, mapSize(200, 80), playerAt(100, 40), onPlanet(data.BiomeToftForest, 20))
+	TerrainGen{}.Update(0, w)
+	rows := map[int]bool{}
+	ecs.View2Of[components.RiverTag, components.Position](w).Each(func(t ecs.Tuple2[components.RiverTag, components.Position]) {
+		rows[int(t.B.Y)] = true
+	})
+	if len(rows) != 80 {
+		t.Fatalf("a river should cross every row, got %d", len(rows))
+	}
+}
+
+func TestTerrainLeavesThePlayerRoom(t *testing.T) {
+	for depth := 0; depth < 40; depth += 7 {
+		w, _ := newTestWorld(1, mapSize(200, 80), playerAt(100, 40), onPlanet(data.BiomeAncientRuins, depth))
+		TerrainGen{}.Update(0, w)
+		ecs.View2Of[TerrainTile, components.Position](w).Each(func(t2 ecs.Tuple2[TerrainTile, components.Position]) {
+			if chebyshev(int(t2.B.X), int(t2.B.Y), 100, 40) <= 1 {
+				t.Fatalf("terrain at %+v crowds the player at depth %d", *t2.B, depth)
+			}
+		})
+	}
+}
+
+func TestLandingAndLiftOffKeepSpaceCoordinates(t *testing.T) {
+	w, p := newTestWorld(1, inSpace, ship(100, 100), mapSize(200, 80))
+	addOrbitPlanet(w)
+	ecs.Add(w, p, components.Position{X: 50, Y: 40})
+	ecs.Add(w, p, EnterPlanet{})
+	PlanetApproachSystem{}.Update(0, w)
+	if pos, _ := ecs.Get[components.Position](w, p); pos.X != 100 || pos.Y != 40 {
+		t.Fatalf("the ship should set down in the middle of the surface map, at %+v", pos)
+	}
+	TerrainGen{}.Update(0, w)
+	LiftOff(w, p)
+	if pos, _ := ecs.Get[components.Position](w, p); pos.X != 50 || pos.Y != 40 {
+		t.Fatalf("lifting off should return the ship to where it left space, at %+v", pos)
+	}
+	if _, ok := ecs.Get[TerrainLevel](w, 1); ok {
+		t.Fatal("lifting off should forget the level so the next landing builds one")
+	}
+}
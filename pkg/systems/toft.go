@@ -3,11 +3,11 @@ package systems
 import (
 	"harvester/pkg/components"
 	"harvester/pkg/ecs"
-	"harvester/pkg/timing"
-	"math/rand"
 )
 
-type WeatherTick struct{}
+func init() {
+	ecs.RegisterComponent[PatrolMuster]()
+}
 
 type RiverTile struct{ FlowX, FlowY int }
 
@@ -22,23 +22,39 @@ type Wildlife struct{ Hostile bool }
 
 type WildlifeSpawn struct{}
 
+// maxWildlife caps how many creatures WildlifeSpawn keeps alive at once.
+const maxWildlife = 30
+
+// wildlifeDistance keeps new creatures from appearing right beside the
+// player.
+const wildlifeDistance = 4
+
+// huntingSpeed lets hostile beasts occasionally outpace the player.
+const huntingSpeed = 12
+
 type TradeRoutePatrols struct{}
 
+// A patrol sets out every patrolEvery seconds of game time, at least
+// patrolDistance tiles from the player, until maxPatrols are about.
+const (
+	patrolEvery    = 50.0
+	patrolDistance = 12
+	maxPatrols     = 5
+)
+
+// spawnTries bounds how many random tiles spawnSpot looks at.
+const spawnTries = 20
+
+// PatrolMuster is the game time since the last patrol set out. It lives on
+// entity 1.
+type PatrolMuster struct{ Elapsed float64 }
+
 type Patrol struct{}
 
 type RiverFlow struct{}
 
 type KingdomGuards struct{}
 
-func (s WeatherTick) Update(dt float64, w *ecs.World) {
-	r := rand.New(rand.NewSource(int64(timing.Tick()) + 1))
-	we, _ := ecs.Get[components.Weather](w, 1)
-	if r.Float64() < 0.1 {
-		we.Rain = !we.Rain
-	}
-	ecs.Add(w, 1, we)
-}
-
 func (s RiverFlow) Update(dt float64, w *ecs.World) {
 	_ = dt
 }
@@ -48,35 +64,55 @@ func (s TradeRoutePatrols) Update(dt float64, w *ecs.World) {
 	if !ok {
 		return
 	}
-	if timing.Tick()%50 == 0 {
-		count := 0
-		ecs.View2Of[components.Position, Patrol](w).Each(func(t ecs.Tuple2[components.Position, Patrol]) { count++ })
-		if count < 5 {
-			e := w.Create()
-			x, y := wi.Width/2, wi.Height/2
-			ecs.Add(w, e, components.Position{X: float64(x), Y: float64(y)})
-			ecs.Add(w, e, components.Renderable{Glyph: 'P', TileType: components.TileGalaxy})
-			ecs.Add(w, e, Patrol{})
-		}
+	m, _ := ecs.Get[PatrolMuster](w, 1)
+	m.Elapsed += dt
+	if m.Elapsed < patrolEvery {
+		ecs.Add(w, 1, m)
+		return
+	}
+	ecs.Add(w, 1, PatrolMuster{})
+	count := 0
+	ecs.View2Of[components.Position, Patrol](w).Each(func(t ecs.Tuple2[components.Position, Patrol]) { count++ })
+	if count >= maxPatrols {
+		return
+	}
+	x, y, ok := spawnSpot(w, wi, patrolDistance)
+	if !ok {
+		return
+	}
+	e := w.Create()
+	ecs.Add(w, e, components.Position{X: float64(x), Y: float64(y)})
+	ecs.Add(w, e, components.Renderable{Glyph: 'P', TileType: components.TileGalaxy})
+	ecs.Add(w, e, Patrol{})
+	ecs.Add(w, e, components.Name{Text: "the patrol"})
+	ecs.Add(w, e, components.Health{HP: 12, Max: 12})
+	ecs.Add(w, e, components.Armor{Value: 1})
+	ecs.Add(w, e, components.Melee{Accuracy: 70, MinDamage: 2, MaxDamage: 4, Cooldown: 1})
+	ecs.Add(w, e, Brain{Guard: true, HomeX: x, HomeY: y, Perception: 8, Leash: 10, Think: 0.5})
+	ecs.Add(w, e, Actor{Speed: NormalSpeed})
+	// movement comes from CreatureAI via MoveIntent
+}
+
+// spawnSpot picks a random free, walkable tile at least away tiles from the
+// player, or reports false when a few tries find none.
+func spawnSpot(w *ecs.World, wi components.WorldInfo, away int) (int, int, bool) {
+	if wi.Width <= 0 || wi.Height <= 0 {
+		return 0, 0, false
 	}
-	// wander
-	ecs.View2Of[components.Position, Patrol](w).Each(func(t ecs.Tuple2[components.Position, Patrol]) {
-		r := rand.Intn(4)
-		dx, dy := 0.0, 0.0
-		switch r {
-		case 0:
-			dx = 1
-		case 1:
-			dx = -1
-		case 2:
-			dy = 1
-		case 3:
-			dy = -1
+	r := w.Rand()
+	_, pos, hasPlayer := findPlayer(w)
+	nav := Navigation(w)
+	for try := 0; try < spawnTries; try++ {
+		x, y := r.Intn(wi.Width), r.Intn(wi.Height)
+		if hasPlayer && chebyshev(x, y, int(pos.X), int(pos.Y)) < away {
+			continue
 		}
-		t.A.X += dx
-		t.A.Y += dy
-		ecs.Add(w, t.E, *t.A)
-	})
+		if nav.Blocked(x, y) || occupantAt(w, x, y, 0) != 0 {
+			continue
+		}
+		return x, y, true
+	}
+	return 0, 0, false
 }
 
 func (s WildlifeSpawn) Update(dt float64, w *ecs.World) {
@@ -85,15 +121,29 @@ func (s WildlifeSpawn) Update(dt float64, w *ecs.World) {
 		return
 	}
 	ctx := ecs.GetWorldContext(w)
-	r := rand.New(rand.NewSource(int64(timing.Tick()) + int64(ctx.Depth)*37))
-	if r.Float64() < 0.1 {
-		e := w.Create()
-		x := r.Intn(wi.Width)
-		y := r.Intn(wi.Height)
-		ecs.Add(w, e, components.Position{X: float64(x), Y: float64(y)})
-		ecs.Add(w, e, components.Renderable{Glyph: 'w', TileType: components.TileForest})
-		ecs.Add(w, e, Wildlife{Hostile: ctx.Depth > 20})
+	count := 0
+	ecs.View1Of[Wildlife](w).Each(func(ecs.Entity, *Wildlife) { count++ })
+	if count >= maxWildlife || w.Rand().Float64() >= 0.1 {
+		return
+	}
+	x, y, ok := spawnSpot(w, wi, wildlifeDistance)
+	if !ok {
+		return
+	}
+	hostile := ctx.Depth > 20
+	e := w.Create()
+	ecs.Add(w, e, components.Position{X: float64(x), Y: float64(y)})
+	ecs.Add(w, e, components.Renderable{Glyph: 'w', TileType: components.TileForest})
+	ecs.Add(w, e, Wildlife{Hostile: hostile})
+	ecs.Add(w, e, components.Name{Text: "the wild beast"})
+	ecs.Add(w, e, components.Health{HP: 6, Max: 6})
+	ecs.Add(w, e, components.Melee{Accuracy: 60, MinDamage: 1, MaxDamage: 3, Cooldown: 1})
+	ecs.Add(w, e, Brain{HomeX: x, HomeY: y, Perception: 6, Timid: !hostile, Think: 0.6})
+	speed := NormalSpeed
+	if hostile {
+		speed = huntingSpeed
 	}
+	ecs.Add(w, e, Actor{Speed: speed})
 }
 
 func (s KingdomGuards) Update(dt float64, w *ecs.World) {
@@ -0,0 +1,136 @@
+package systems
+
+import (
+	"harvester/pkg/components"
+	"harvester/pkg/ecs"
+)
+
+// Energy scheduling on planet layers. Every game tick each Actor gains its
+// Speed in energy and may act once it holds ActionCost. At NormalSpeed that
+// is one action per turn, and a turn is TurnSeconds of world time.
+const (
+	ActionCost   = 100
+	NormalSpeed  = 10
+	TurnSeconds  = 1.0
+	ticksPerTurn = ActionCost / NormalSpeed
+	// maxActorPasses bounds how many extra actions fast creatures can fit
+	// into one player turn.
+	maxActorPasses = 3
+)
+
+func init() {
+	ecs.RegisterComponent[Actor]()
+}
+
+// Actor takes turns under TurnScheduler.
+type Actor struct {
+	Speed  int
+	Energy int
+}
+
+// WaitIntent spends the player's turn doing nothing.
+type WaitIntent struct{}
+
+// TurnScheduler runs its systems as discrete turns, and only when the player
+// acts: a move, attack, scan or wait. Time then advances until the player
+// has the energy to act again, and every other Actor banks energy at its own
+// speed. Creatures that still hold a full action afterwards get up to
+// maxActorPasses extra passes of ActorSystems.
+type TurnScheduler struct {
+	Systems      []ecs.System
+	ActorSystems []ecs.System
+}
+
+func (ts TurnScheduler) Update(dt float64, w *ecs.World) {
+	if ecs.GetWorldContext(w).GameOver {
+		return
+	}
+	player, _, ok := findPlayer(w)
+	if !ok || !playerActing(w, player) {
+		return
+	}
+	pa := actorOf(w, player)
+	pa.Energy -= ActionCost
+	ticks := 0
+	if short := ActionCost - pa.Energy; short > 0 {
+		ticks = (short + pa.Speed - 1) / pa.Speed
+	}
+	ecs.Add(w, player, pa)
+	grantEnergy(w, ticks)
+
+	turn := float64(ticks) / ticksPerTurn * TurnSeconds
+	advanceClock(w, turn)
+	for _, sys := range ts.Systems {
+		sys.Update(turn, w)
+	}
+	// one keypress is one action; auto-travel re-arms Input each frame
+	ecs.Add(w, player, components.Input{})
+	ecs.Remove[WaitIntent](w, player)
+
+	for i := 0; i < maxActorPasses && creaturesReady(w); i++ {
+		for _, sys := range ts.ActorSystems {
+			sys.Update(0, w)
+		}
+	}
+}
+
+// playerActing reports whether the player has queued an action.
+func playerActing(w *ecs.World, player ecs.Entity) bool {
+	if in, _ := ecs.Get[components.Input](w, player); in.Left || in.Right || in.Up || in.Down {
+		return true
+	}
+	if _, ok := ecs.Get[WaitIntent](w, player); ok {
+		return true
+	}
+	if _, ok := ecs.Get[ScanRequest](w, player); ok {
+		return true
+	}
+	if a, _ := ecs.Get[components.Action](w, player); a.Harvest {
+		return true
+	}
+	return hasAny[AttackIntent](w, player) || hasAny[UseIntent](w, player) || hasAny[DropIntent](w, player) ||
+		hasAny[ThrowIntent](w, player) || hasAny[EquipIntent](w, player) || hasAny[CraftIntent](w, player)
+}
+
+func hasAny[T any](w *ecs.World, e ecs.Entity) bool {
+	_, ok := ecs.Get[T](w, e)
+	return ok
+}
+
+// actorOf returns e's Actor, defaulting to normal speed with a full action.
+func actorOf(w *ecs.World, e ecs.Entity) Actor {
+	a, ok := ecs.Get[Actor](w, e)
+	if !ok {
+		return Actor{Speed: NormalSpeed, Energy: ActionCost}
+	}
+	if a.Speed <= 0 {
+		a.Speed = NormalSpeed
+	}
+	return a
+}
+
+// grantEnergy advances every Actor by ticks game ticks.
+func grantEnergy(w *ecs.World, ticks int) {
+	if ticks <= 0 {
+		return
+	}
+	ecs.View1Of[Actor](w).Each(func(e ecs.Entity, a *Actor) {
+		speed := a.Speed
+		if speed <= 0 {
+			speed = NormalSpeed
+		}
+		a.Energy += speed * ticks
+		ecs.Add(w, e, *a)
+	})
+}
+
+// creaturesReady reports whether any non-player Actor can still act.
+func creaturesReady(w *ecs.World) bool {
+	ready := false
+	ecs.View2Of[Actor, Brain](w).Each(func(t ecs.Tuple2[Actor, Brain]) {
+		if t.A.Energy >= ActionCost {
+			ready = true
+		}
+	})
+	return ready
+}
@@ -0,0 +1,103 @@
+package systems
+
+import (
+	"harvester/pkg/components"
+	"harvester/pkg/ecs"
+	"testing"
+)
+
+func newTurnScheduler() TurnScheduler {
+	return TurnScheduler{
+		Systems:      []ecs.System{SurfaceMovement{}, CreatureAI{}, IntentMovement{}, Combat{}},
+		ActorSystems: []ecs.System{CreatureAI{}, IntentMovement{}, Combat{}},
+	}
+}
+
+// spawnWanderer places a guard far from anything it would react to, so it
+// wanders one tile per action.
+func spawnWanderer(w *ecs.World, speed int) ecs.Entity {
+	e := spawnFoe(w, 30, 30, 5)
+	ecs.Add(w, e, Brain{Guard: true, HomeX: 30, HomeY: 30, Leash: 50})
+	ecs.Add(w, e, Actor{Speed: speed})
+	return e
+}
+
+func TestWorldWaitsForPlayer(t *testing.T) {
+	w, p := newTestWorld(1)
+	ecs.Add(w, p, Actor{Speed: NormalSpeed, Energy: ActionCost})
+	c := spawnWanderer(w, NormalSpeed)
+	ts := newTurnScheduler()
+	for i := 0; i < 20; i++ {
+		ts.Update(1.0/60, w)
+	}
+	if b, _ := ecs.Get[Brain](w, c); b.Steps != 0 {
+		t.Fatalf("creature acted %d times without the player acting", b.Steps)
+	}
+}
+
+func TestOneKeypressIsOneTurn(t *testing.T) {
+	w, p := newTestWorld(1)
+	ecs.Add(w, p, Actor{Speed: NormalSpeed, Energy: ActionCost})
+	c := spawnWanderer(w, NormalSpeed)
+	ts := newTurnScheduler()
+	SetPlayerInput(w, p, "right")
+	for i := 0; i < 10; i++ {
+		ts.Update(1.0/60, w)
+	}
+	if pos, _ := ecs.Get[components.Position](w, p); pos.X != 6 {
+		t.Fatalf("player should move exactly one tile, at x=%v", pos.X)
+	}
+	if b, _ := ecs.Get[Brain](w, c); b.Steps != 1 {
+		t.Fatalf("creature should act once per player turn, acted %d", b.Steps)
+	}
+}
+
+func TestSpeedScalesActions(t *testing.T) {
+	w, p := newTestWorld(1)
+	ecs.Add(w, p, Actor{Speed: NormalSpeed, Energy: ActionCost})
+	fast := spawnWanderer(w, 2*NormalSpeed)
+	slow := spawnWanderer(w, NormalSpeed/2)
+	ts := newTurnScheduler()
+	for i := 0; i < 4; i++ {
+		SetPlayerInput(w, p, "wait")
+		ts.Update(1.0/60, w)
+	}
+	if b, _ := ecs.Get[Brain](w, fast); b.Steps != 8 {
+		t.Errorf("double-speed creature should act 8 times in 4 turns, acted %d", b.Steps)
+	}
+	if b, _ := ecs.Get[Brain](w, slow); b.Steps != 2 {
+		t.Errorf("half-speed creature should act 2 times in 4 turns, acted %d", b.Steps)
+	}
+}
+
+func TestWildlifeSpawnsFromTheWorldRNGOnOpenGround(t *testing.T) {
+	spawned := func() map[[2]int]bool {
+		w, _ := newTestWorld(1)
+		ecs.Add(w, 1, components.WorldInfo{Width: 20, Height: 20})
+		for y := 0; y < 20; y++ {
+			for x := 0; x < 10; x++ {
+				addTile(w, float64(x), float64(y), components.TileMountain)
+			}
+		}
+		for i := 0; i < 300; i++ {
+			WildlifeSpawn{}.Update(1, w)
+		}
+		at := map[[2]int]bool{}
+		ecs.View2Of[Wildlife, components.Position](w).Each(func(t ecs.Tuple2[Wildlife, components.Position]) {
+			at[[2]int{int(t.B.X), int(t.B.Y)}] = true
+		})
+		return at
+	}
+	a, b := spawned(), spawned()
+	if len(a) == 0 || len(a) != len(b) {
+		t.Fatalf("the same seed should spawn the same wildlife, got %d and %d", len(a), len(b))
+	}
+	for at := range a {
+		if !b[at] {
+			t.Fatalf("the same seed should spawn the same wildlife, %v differs", at)
+		}
+		if at[0] < 10 {
+			t.Fatalf("a creature spawned inside the mountains at %v", at)
+		}
+	}
+}
@@ -0,0 +1,434 @@
+package systems
+
+import (
+	"math"
+	"math/rand"
+
+	"harvester/pkg/components"
+	"harvester/pkg/data"
+	"harvester/pkg/ecs"
+)
+
+// The universe is an endless grid of sectors, each generated on demand from
+// the world seed and its coordinates. Sectors near the ship are streamed in
+// and distant ones streamed out again; a sector that comes back is
+// regenerated as it first was, so harvested galaxies and spent anomalies
+// recover while the ship is away.
+const (
+	// sectorW and sectorH are the size of a sector in tiles.
+	sectorW, sectorH = 64, 32
+	// streamRadius is how many sectors around the ship are kept loaded.
+	streamRadius = 1
+	// unloadRadius is how far a sector must fall behind before it is
+	// dropped; the gap keeps a ship on a border from thrashing.
+	unloadRadius = 2
+	// stationSupplies is how many dockings a station can service.
+	stationSupplies = 2
+)
+
+// EventDock is emitted when the ship docks at a station.
+const EventDock = "dock"
+
+func init() {
+	ecs.RegisterComponent[SectorMember]()
+	ecs.RegisterComponent[Sectors]()
+	ecs.RegisterComponent[Station]()
+	ecs.RegisterComponent[Depleted]()
+}
+
+// SectorCoord addresses a sector of the universe.
+type SectorCoord struct{ X, Y int }
+
+// SectorMember tags an entity as generated by a sector, so it goes when
+// the sector is streamed out.
+type SectorMember struct{ Sector SectorCoord }
+
+// Sectors lists the loaded sectors. It lives on entity 1 beside WorldInfo.
+type Sectors struct{ Loaded []SectorCoord }
+
+func (s Sectors) has(c SectorCoord) bool {
+	for _, l := range s.Loaded {
+		if l == c {
+			return true
+		}
+	}
+	return false
+}
+
+// Station is a space station the ship can dock at with '>' to refuel and
+// patch its hull while Supplies last.
+type Station struct {
+	Name     string
+	Supplies int
+}
+
+// Depleted remembers the stations and galaxy nodes the ship has drawn on in
+// sectors since streamed out, so a sector generated again comes back as the
+// ship left it rather than refilled. It lives on entity 1 beside Sectors.
+type Depleted struct{ Spots []DepletedSpot }
+
+// DepletedSpot is what the station or galaxy node at X, Y in Sector has
+// left: its supplies or its charges.
+type DepletedSpot struct {
+	Sector  SectorCoord
+	X, Y    int
+	Station bool
+	Left    int
+}
+
+// SectorAt returns the sector holding (x, y).
+func SectorAt(x, y float64) SectorCoord {
+	return SectorCoord{X: int(math.Floor(x / sectorW)), Y: int(math.Floor(y / sectorH))}
+}
+
+// SectorStreaming keeps the sectors around the player's ship loaded.
+type SectorStreaming struct{}
+
+func (SectorStreaming) Update(dt float64, w *ecs.World) { StreamSectors(w) }
+
+// StreamSectors generates any missing sector within streamRadius of the
+// player and drops loaded ones beyond unloadRadius.
+func StreamSectors(w *ecs.World) {
+	_, pos, ok := findPlayer(w)
+	if !ok {
+		return
+	}
+	here := SectorAt(pos.X, pos.Y)
+	loaded, _ := ecs.Get[Sectors](w, 1)
+	var keep, drop []SectorCoord
+	for _, c := range loaded.Loaded {
+		if max(iabs(c.X-here.X), iabs(c.Y-here.Y)) > unloadRadius {
+			drop = append(drop, c)
+		} else {
+			keep = append(keep, c)
+		}
+	}
+	if len(drop) > 0 {
+		unloadSectors(w, drop)
+	}
+	loaded.Loaded = keep
+	for dy := -streamRadius; dy <= streamRadius; dy++ {
+		for dx := -streamRadius; dx <= streamRadius; dx++ {
+			c := SectorCoord{X: here.X + dx, Y: here.Y + dy}
+			if !loaded.has(c) {
+				GenerateSector(w, c)
+				loaded.Loaded = append(loaded.Loaded, c)
+			}
+		}
+	}
+	ecs.Add(w, 1, loaded)
+}
+
+func unloadSectors(w *ecs.World, drop []SectorCoord) {
+	gone := Sectors{Loaded: drop}
+	dep, _ := ecs.Get[Depleted](w, 1)
+	var doomed []ecs.Entity
+	ecs.View1Of[SectorMember](w).Each(func(e ecs.Entity, m *SectorMember) {
+		if !gone.has(m.Sector) {
+			return
+		}
+		doomed = append(doomed, e)
+		pos, _ := ecs.Get[components.Position](w, e)
+		spot := DepletedSpot{Sector: m.Sector, X: int(pos.X), Y: int(pos.Y)}
+		if st, ok := ecs.Get[Station](w, e); ok && st.Supplies < stationSupplies {
+			spot.Station, spot.Left = true, st.Supplies
+			dep.Spots = append(dep.Spots, spot)
+		} else if gn, ok := ecs.Get[GalaxyNode](w, e); ok {
+			spot.Left = gn.Charges
+			dep.Spots = append(dep.Spots, spot)
+		}
+	})
+	ecs.Add(w, 1, dep)
+	for _, e := range doomed {
+		w.Destroy(e)
+	}
+}
+
+// restoreDepletion puts back what the ship drew from sector c's stations
+// and galaxy nodes before it was streamed out. The live entities carry it
+// from then on.
+func restoreDepletion(w *ecs.World, c SectorCoord) {
+	dep, _ := ecs.Get[Depleted](w, 1)
+	type key struct {
+		x, y    int
+		station bool
+	}
+	left := map[key]int{}
+	spots := dep.Spots[:0]
+	for _, s := range dep.Spots {
+		if s.Sector == c {
+			left[key{s.X, s.Y, s.Station}] = s.Left
+		} else {
+			spots = append(spots, s)
+		}
+	}
+	if len(left) == 0 {
+		return
+	}
+	ecs.View2Of[SectorMember, components.Position](w).Each(func(t ecs.Tuple2[SectorMember, components.Position]) {
+		if t.A.Sector != c {
+			return
+		}
+		st, station := ecs.Get[Station](w, t.E)
+		n, ok := left[key{int(t.B.X), int(t.B.Y), station}]
+		if !ok {
+			return
+		}
+		if station {
+			st.Supplies = n
+			ecs.Add(w, t.E, st)
+		} else if tile, ok := ecs.Get[components.Tile](w, t.E); ok && (tile.Type == components.TileGalaxyCore || tile.Type == components.TileGalaxy) {
+			ecs.Add(w, t.E, GalaxyNode{Charges: n})
+			if n <= 0 {
+				fadeGalaxyNode(w, t.E)
+			}
+		}
+	})
+	dep.Spots = spots
+	ecs.Add(w, 1, dep)
+}
+
+// sectorSeed mixes the world seed with a sector's coordinates.
+func sectorSeed(seed int64, c SectorCoord) int64 {
+	h := uint64(seed)*0x9E3779B97F4A7C15 ^ uint64(int64(c.X))*0xBF58476D1CE4E5B9 ^ uint64(int64(c.Y))*0x94D049BB133111EB
+	h ^= h >> 31
+	return int64(h & math.MaxInt64)
+}
+
+// sectorGen places one sector's contents, keeping important bodies apart.
+type sectorGen struct {
+	w       *ecs.World
+	r       *rand.Rand
+	c       SectorCoord
+	ox, oy  float64
+	taken   []components.Position
+	planets int
+}
+
+// GenerateSector fills sector c with background stars, asteroids and
+// galaxies, star systems with planets, perhaps a station, hazards and
+// comets. The same world seed and coordinates always give the same sector,
+// less whatever the ship used up there on an earlier visit.
+func GenerateSector(w *ecs.World, c SectorCoord) {
+	g := &sectorGen{
+		w: w, r: rand.New(rand.NewSource(sectorSeed(w.Seed(), c))), c: c,
+		ox: float64(c.X * sectorW), oy: float64(c.Y * sectorH),
+		// the ship starts at the origin; keep it clear
+		taken: []components.Position{{X: 0, Y: 0}},
+	}
+	home := c == SectorCoord{}
+	g.background(home)
+	if home {
+		pg := data.PlanetGenerator{Seed: 1, Biome: data.BiomeToftForest, MaxDepth: 120}
+		g.planet(components.Position{X: 10, Y: 5}, *pg.GenerateToft())
+	}
+	for i := g.r.Intn(2) + 1; i > 0; i-- {
+		g.starSystem()
+	}
+	if home || g.r.Intn(3) == 0 {
+		g.station()
+	}
+	g.hazards()
+	for i := g.r.Intn(3); i > 0; i-- {
+		x, y := g.r.Intn(sectorW), g.r.Intn(sectorH)
+		g.tag(SpawnComet(w, int(g.ox)+x, int(g.oy)+y))
+	}
+	restoreDepletion(w, c)
+}
+
+func (g *sectorGen) tag(e ecs.Entity) ecs.Entity {
+	ecs.Add(g.w, e, SectorMember{Sector: g.c})
+	return e
+}
+
+// spot picks a free spot at least clearance from everything placed so far,
+// or reports false if the sector is too crowded.
+func (g *sectorGen) spot(margin int, clearance float64) (components.Position, bool) {
+	for try := 0; try < 30; try++ {
+		p := components.Position{
+			X: g.ox + float64(margin+g.r.Intn(sectorW-2*margin)),
+			Y: g.oy + float64(margin+g.r.Intn(sectorH-2*margin)),
+		}
+		ok := true
+		for _, t := range g.taken {
+			if math.Hypot(p.X-t.X, p.Y-t.Y) < clearance {
+				ok = false
+				break
+			}
+		}
+		if ok {
+			g.taken = append(g.taken, p)
+			return p, true
+		}
+	}
+	return components.Position{}, false
+}
+
+// background scatters stars, asteroids and galaxy cores. The home sector
+// is charted space, swept clear of asteroids for ships leaving port.
+func (g *sectorGen) background(home bool) {
+	for y := 0; y < sectorH; y++ {
+		for x := 0; x < sectorW; x++ {
+			tt, glyph := components.TileUnknown, ' '
+			switch n := g.r.Intn(100); {
+			case n < 1:
+				tt, glyph = components.TileGalaxyCore, '¤'
+			case n < 3 && home:
+				continue
+			case n < 3:
+				tt, glyph = components.TileAsteroid, '·'
+			case n < 6:
+				tt, glyph = components.TileStar, '+'
+			default:
+				continue
+			}
+			e := g.w.Create()
+			ecs.Add(g.w, e, components.Position{X: g.ox + float64(x), Y: g.oy + float64(y)})
+			ecs.Add(g.w, e, components.Tile{Glyph: glyph, Type: tt})
+			ecs.Add(g.w, e, components.Renderable{Glyph: glyph, TileType: tt, StyleMod: &components.ColorModifier{Special: components.EffectTwinkling}})
+			ecs.Add(g.w, e, SpaceBody{})
+			g.tag(e)
+		}
+	}
+}
+
+// starSystem places a sun with one to three planets around it.
+func (g *sectorGen) starSystem() {
+	sun, ok := g.spot(4, 2*soiRadius)
+	if !ok {
+		return
+	}
+	e := g.w.Create()
+	ecs.Add(g.w, e, sun)
+	ecs.Add(g.w, e, components.Renderable{Glyph: '☼', TileType: components.TileStar,
+		StyleMod: &components.ColorModifier{Special: components.EffectPulsing, PulseRate: 1}})
+	ecs.Add(g.w, e, SpaceBody{})
+	g.tag(e)
+	for i := 1 + g.r.Intn(3); i > 0; i-- {
+		p, ok := g.spot(2, 2*soiRadius)
+		if !ok {
+			return
+		}
+		seed := sectorSeed(g.w.Seed()+int64(g.planets)+1, g.c)
+		// ids stay clear of the hand-made planets below 1000
+		id := 1000 + int(seed%1_000_000_000)
+		g.planet(p, data.RandomPlanet(id, seed))
+	}
+}
+
+// planet places a landable planet; Index numbers it within the sector.
+func (g *sectorGen) planet(at components.Position, p data.Planet) {
+	g.taken = append(g.taken, at)
+	e := g.w.Create()
+	ecs.Add(g.w, e, at)
+	ecs.Add(g.w, e, components.Renderable{Glyph: 'O', TileType: components.TilePlanet})
+	ecs.Add(g.w, e, components.Name{Text: p.Name})
+	ecs.Add(g.w, e, PlanetCard{Planet: p, Index: g.planets})
+	g.planets++
+	ecs.Add(g.w, e, Gravity{Mass: planetMass * p.GravityModifier, SOI: soiRadius})
+	ecs.Add(g.w, e, SpaceBody{})
+	g.tag(e)
+}
+
+func (g *sectorGen) station() {
+	at, ok := g.spot(2, 4)
+	if !ok {
+		return
+	}
+	e := g.w.Create()
+	ecs.Add(g.w, e, at)
+	ecs.Add(g.w, e, components.Renderable{Glyph: 'H', TileType: components.TileGalaxyCore})
+	name := data.RandomPlanet(0, sectorSeed(g.w.Seed()-1, g.c)).Name + " Station"
+	ecs.Add(g.w, e, components.Name{Text: name})
+	ecs.Add(g.w, e, Station{Name: name, Supplies: stationSupplies})
+	ecs.Add(g.w, e, SpaceBody{})
+	g.tag(e)
+}
+
+// hazards scatters the sector's share of black holes, wormholes, nebulae
+// and anomalies.
+func (g *sectorGen) hazards() {
+	if g.r.Intn(4) == 0 {
+		if at, ok := g.spot(2, hazardClearance); ok {
+			g.tag(spawnHazard(g.w, at.X, at.Y, Hazard{Kind: data.BlackHole, Radius: blackHoleReach}))
+		}
+	}
+	if g.r.Intn(5) == 0 {
+		a, okA := g.spot(1, hazardClearance/2)
+		b, okB := g.spot(1, hazardClearance/2)
+		if okA && okB {
+			ea := g.tag(spawnHazard(g.w, a.X, a.Y, Hazard{Kind: data.Wormhole, Radius: wormholeMouth}))
+			eb := g.tag(spawnHazard(g.w, b.X, b.Y, Hazard{Kind: data.Wormhole, Radius: wormholeMouth, Pair: ea}))
+			ecs.Add(g.w, ea, Hazard{Kind: data.Wormhole, Radius: wormholeMouth, Pair: eb})
+		}
+	}
+	for i := g.r.Intn(2); i > 0; i-- {
+		// a nebula is a ragged cloud of cells around a centre
+		centre, ok := g.spot(3, hazardClearance/2)
+		if !ok {
+			break
+		}
+		size := 2 + g.r.Intn(3)
+		for dy := -size; dy <= size; dy++ {
+			for dx := -size * 2; dx <= size*2; dx++ {
+				if math.Hypot(float64(dx)/2, float64(dy)) > float64(size)-g.r.Float64() {
+					continue
+				}
+				g.tag(spawnHazard(g.w, centre.X+float64(dx), centre.Y+float64(dy), Hazard{Kind: data.Nebula, Radius: 0.5}))
+			}
+		}
+	}
+	for i := g.r.Intn(3); i > 0; i-- {
+		if at, ok := g.spot(1, 3); ok {
+			g.tag(spawnHazard(g.w, at.X, at.Y, Hazard{Kind: data.Anomaly, Radius: anomalyReach}))
+		}
+	}
+}
+
+// Docking services the ship when the player presses '>' over a station.
+type Docking struct{}
+
+func (Docking) Update(dt float64, w *ecs.World) {
+	player, pos, ok := findPlayer(w)
+	if !ok {
+		return
+	}
+	if _, pressed := ecs.Get[EnterPlanet](w, player); !pressed {
+		return
+	}
+	station := stationAt(w, int(pos.X), int(pos.Y), 0)
+	if station == 0 {
+		return
+	}
+	ecs.Remove[EnterPlanet](w, player)
+	if v, _ := ecs.Get[components.Velocity](w, player); math.Hypot(v.VX, v.VY) > landingSpeed {
+		itemEvent(w, ecs.Event{Kind: EventApproach, Source: player},
+			"Too fast to dock: %.1f, slow below %.0f.", math.Hypot(v.VX, v.VY), landingSpeed)
+		return
+	}
+	st, _ := ecs.Get[Station](w, station)
+	if st.Supplies <= 0 {
+		itemEvent(w, ecs.Event{Kind: EventDock, Source: player, Target: station}, "%s has nothing left to spare.", st.Name)
+		return
+	}
+	st.Supplies--
+	ecs.Add(w, station, st)
+	fuel := Refuel(w, player, data.FuelCapacity)
+	ps, _ := ecs.Get[components.PlayerStats](w, player)
+	hull := max(0, data.HullCapacity-ps.Hull)
+	ps.Hull += hull
+	ecs.Add(w, player, ps)
+	itemEvent(w, ecs.Event{Kind: EventDock, Source: player, Target: station, Amount: fuel},
+		"Docked at %s: %d fuel taken on, %d hull patched.", st.Name, fuel, hull)
+}
+
+// stationAt returns a station within reach of (x, y), or 0.
+func stationAt(w *ecs.World, x, y, reach int) ecs.Entity {
+	var found ecs.Entity
+	ecs.View2Of[Station, components.Position](w).Each(func(t ecs.Tuple2[Station, components.Position]) {
+		if found == 0 && chebyshev(x, y, int(t.B.X), int(t.B.Y)) <= reach {
+			found = t.E
+		}
+	})
+	return found
+}
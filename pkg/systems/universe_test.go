@@ -0,0 +1,245 @@
+package systems
+
+import (
+	"encoding/json"
+	"math/rand"
+	"testing"
+
+	"harvester/pkg/components"
+	"harvester/pkg/ecs"
+)
+
+// sectorContents lists what sector c holds, in creation order.
+func sectorContents(w *ecs.World, c SectorCoord) []string {
+	var out []string
+	for _, e := range sortedWith[SectorMember](w) {
+		if m, _ := ecs.Get[SectorMember](w, e); m.Sector != c {
+			continue
+		}
+		pos, _ := ecs.Get[components.Position](w, e)
+		r, _ := ecs.Get[components.Renderable](w, e)
+		t, _ := ecs.Get[components.Tile](w, e)
+		card, _ := ecs.Get[PlanetCard](w, e)
+		b, _ := json.Marshal([]any{pos, r.Glyph, t.Glyph, card.Planet.ID, card.Planet.Name})
+		out = append(out, string(b))
+	}
+	return out
+}
+
+func TestSectorsAreSeedDeterministic(t *testing.T) {
+	c := SectorCoord{X: 3, Y: -2}
+	a, b := ecs.NewWorld(nil), ecs.NewWorld(nil)
+	GenerateSector(a, c)
+	GenerateSector(b, c)
+	ca, cb := sectorContents(a, c), sectorContents(b, c)
+	if len(ca) == 0 {
+		t.Fatal("a sector should not be empty")
+	}
+	if len(ca) != len(cb) {
+		t.Fatalf("same seed gave %d and %d bodies", len(ca), len(cb))
+	}
+	for i := range ca {
+		if ca[i] != cb[i] {
+			t.Fatalf("same seed differs at %d: %s vs %s", i, ca[i], cb[i])
+		}
+	}
+
+	GenerateSector(a, SectorCoord{X: 4, Y: -2})
+	if other := sectorContents(a, SectorCoord{X: 4, Y: -2}); len(other) == len(ca) && other[0] == ca[0] {
+		t.Fatal("neighbouring sectors should differ")
+	}
+}
+
+func TestTheRNGPicksTheUniverse(t *testing.T) {
+	c := SectorCoord{X: 3, Y: -2}
+	world := func(seed int64) []string {
+		w := ecs.NewWorld(rand.New(rand.NewSource(seed)))
+		GenerateSector(w, c)
+		return sectorContents(w, c)
+	}
+	a, again, b := world(7), world(7), world(8)
+	if len(a) != len(again) || a[0] != again[0] {
+		t.Fatal("the same RNG seed should give the same universe")
+	}
+	if len(a) == len(b) && a[0] == b[0] {
+		t.Fatal("different RNG seeds should give different universes")
+	}
+}
+
+func TestHomeSectorHoldsToftAndAStation(t *testing.T) {
+	w := ecs.NewWorld(nil)
+	GenerateSector(w, SectorCoord{})
+	card, ok := planetAt(w, 10, 5)
+	if !ok || card.Planet.ID != 1 {
+		t.Fatalf("expected Toft at (10,5), got %+v %v", card, ok)
+	}
+	if len(sortedWith[Station](w)) == 0 {
+		t.Fatal("the home sector should have a station")
+	}
+}
+
+func TestSectorsStreamAroundShip(t *testing.T) {
+	w, p := newTestWorld(1, inSpace, ship(100, 100), mapSize(200, 80))
+	StreamSectors(w)
+	s, _ := ecs.Get[Sectors](w, 1)
+	if len(s.Loaded) != 9 {
+		t.Fatalf("expected the 3x3 block around the ship, got %v", s.Loaded)
+	}
+	far := SectorCoord{X: -1, Y: -1}
+	if len(sectorContents(w, far)) == 0 {
+		t.Fatal("the corner sector should be populated")
+	}
+
+	// one sector over nothing is dropped yet: the unload radius is wider
+	ecs.Add(w, p, components.Position{X: sectorW + 5, Y: 5})
+	StreamSectors(w)
+	if s, _ = ecs.Get[Sectors](w, 1); len(s.Loaded) != 12 || !s.has(far) {
+		t.Fatalf("expected 12 sectors with the old ones kept, got %v", s.Loaded)
+	}
+
+	ecs.Add(w, p, components.Position{X: 3*sectorW + 5, Y: 5})
+	StreamSectors(w)
+	s, _ = ecs.Get[Sectors](w, 1)
+	if s.has(far) || len(sectorContents(w, far)) != 0 {
+		t.Fatal("sectors left behind should be streamed out with their contents")
+	}
+	if !s.has(SectorCoord{X: 4, Y: 1}) {
+		t.Fatalf("sectors ahead should be streamed in, got %v", s.Loaded)
+	}
+}
+
+func TestReturningSectorIsRegenerated(t *testing.T) {
+	w, p := newTestWorld(1, inSpace, ship(100, 100), mapSize(200, 80))
+	StreamSectors(w)
+	before := sectorContents(w, SectorCoord{X: 1, Y: 1})
+	ecs.Add(w, p, components.Position{X: 10 * sectorW, Y: 5})
+	StreamSectors(w)
+	ecs.Add(w, p, components.Position{})
+	StreamSectors(w)
+	after := sectorContents(w, SectorCoord{X: 1, Y: 1})
+	if len(before) != len(after) {
+		t.Fatalf("a returning sector should come back the same: %d vs %d bodies", len(before), len(after))
+	}
+}
+
+func TestReturningSectorKeepsItsDepletion(t *testing.T) {
+	w, p := newTestWorld(1, inSpace, ship(100, 100), mapSize(200, 80))
+	StreamSectors(w)
+	station := sortedWith[Station](w)[0]
+	home, _ := ecs.Get[SectorMember](w, station)
+	st, _ := ecs.Get[Station](w, station)
+	st.Supplies = 0
+	ecs.Add(w, station, st)
+	var node ecs.Entity
+	for _, e := range sortedWith[components.Tile](w) {
+		if tile, _ := ecs.Get[components.Tile](w, e); tile.Type == components.TileGalaxyCore {
+			node = e
+			break
+		}
+	}
+	if node == 0 {
+		t.Fatal("expected a galaxy core near home")
+	}
+	at, _ := ecs.Get[components.Position](w, node)
+	ecs.Add(w, node, GalaxyNode{Charges: 0})
+	fadeGalaxyNode(w, node)
+
+	ecs.Add(w, p, components.Position{X: 10 * sectorW, Y: 5})
+	StreamSectors(w)
+	ecs.Add(w, p, components.Position{})
+	StreamSectors(w)
+
+	for _, e := range sortedWith[Station](w) {
+		if m, _ := ecs.Get[SectorMember](w, e); m != home {
+			continue
+		}
+		if st, _ := ecs.Get[Station](w, e); st.Supplies != 0 {
+			t.Fatalf("a drained station should stay drained, has %d supplies", st.Supplies)
+		}
+	}
+	if galaxyNodeNear(w, int(at.X), int(at.Y), 0) != 0 {
+		t.Fatal("a spent galaxy node should not come back")
+	}
+	if dep, _ := ecs.Get[Depleted](w, 1); len(dep.Spots) != 0 {
+		t.Fatalf("restored spots should be dropped from the record, %+v left", dep.Spots)
+	}
+}
+
+func TestLandingUsesTheCardsPlanet(t *testing.T) {
+	w, p := newTestWorld(1, inSpace, ship(100, 100), mapSize(200, 80))
+	GenerateSector(w, SectorCoord{X: 2, Y: 0})
+	cards := sortedWith[PlanetCard](w)
+	if len(cards) == 0 {
+		t.Fatal("expected a planet in the sector")
+	}
+	card, _ := ecs.Get[PlanetCard](w, cards[0])
+	at, _ := ecs.Get[components.Position](w, cards[0])
+	ecs.Add(w, p, at)
+	ecs.Add(w, p, EnterPlanet{})
+	PlanetApproachSystem{}.Update(0, w)
+	ctx := ecs.GetWorldContext(w)
+	if ctx.CurrentLayer != ecs.LayerPlanetSurface || ctx.PlanetID != card.Planet.ID || ctx.BiomeType != int(card.Planet.Biome) {
+		t.Fatalf("expected to land on %+v, got %+v", card.Planet, ctx)
+	}
+	if card.Planet.ID < 1000 {
+		t.Fatalf("generated planet ids should stay clear of the hand-made ones, got %d", card.Planet.ID)
+	}
+}
+
+func TestDockingRefuelsAndRepairs(t *testing.T) {
+	w, p := newTestWorld(1, inSpace, ship(100, 100), mapSize(200, 80))
+	station := w.Create()
+	ecs.Add(w, station, components.Position{X: 5, Y: 5})
+	ecs.Add(w, station, Station{Name: "Test Station", Supplies: 1})
+	ecs.Add(w, p, components.Position{X: 5.5, Y: 5.5})
+	ecs.Add(w, p, components.PlayerStats{Fuel: 10, Hull: 40, Drive: 1})
+
+	ecs.Add(w, p, EnterPlanet{})
+	Docking{}.Update(0, w)
+	ps, _ := ecs.Get[components.PlayerStats](w, p)
+	if ps.Fuel != 100 || ps.Hull != 100 {
+		t.Fatalf("docking should fill the tank and patch the hull, got %+v", ps)
+	}
+	if _, pressed := ecs.Get[EnterPlanet](w, p); pressed {
+		t.Fatal("docking should spend the key press")
+	}
+
+	ps.Fuel, ps.Hull = 10, 40
+	ecs.Add(w, p, ps)
+	ecs.Add(w, p, EnterPlanet{})
+	Docking{}.Update(0, w)
+	if ps, _ = ecs.Get[components.PlayerStats](w, p); ps.Fuel != 10 {
+		t.Fatalf("a station out of supplies should not refuel, got %+v", ps)
+	}
+}
+
+func TestCometWrapsInItsSector(t *testing.T) {
+	w, _ := newTestWorld(1, inSpace, ship(100, 100), mapSize(200, 80))
+	c := w.Create()
+	ecs.Add(w, c, components.Position{X: 2*sectorW - 1, Y: 40})
+	ecs.Add(w, c, Comet{VX: 4})
+	ecs.Add(w, c, SectorMember{Sector: SectorCoord{X: 1, Y: 1}})
+	CometDrift{}.Update(0.5, w)
+	if pos, _ := ecs.Get[components.Position](w, c); pos.X != sectorW+1 || pos.Y != 40 {
+		t.Fatalf("expected the comet to wrap to its sector's west edge, at %+v", pos)
+	}
+}
+
+func TestSectorsSurviveSaveLoad(t *testing.T) {
+	w, _ := newTestWorld(1, inSpace, ship(100, 100), mapSize(200, 80))
+	StreamSectors(w)
+	s, err := ecs.Save(w, json.Marshal)
+	if err != nil {
+		t.Fatal(err)
+	}
+	w2 := ecs.NewWorld(rand.New(rand.NewSource(2)))
+	if _, err := ecs.Load(w2, s, json.Unmarshal); err != nil {
+		t.Fatal(err)
+	}
+	if loaded, _ := ecs.Get[Sectors](w2, 1); len(loaded.Loaded) != 9 {
+		t.Fatalf("loaded sectors lost: %v", loaded.Loaded)
+	}
+	if card, ok := planetAt(w2, 10, 5); !ok || card.Planet.Name != "Toft" {
+		t.Fatalf("planet cards should persist, got %+v %v", card, ok)
+	}
+}
@@ -0,0 +1,200 @@
+package systems
+
+import (
+	"errors"
+	"fmt"
+
+	"harvester/pkg/components"
+	"harvester/pkg/data"
+	"harvester/pkg/ecs"
+)
+
+// Event kinds for ship upgrades. EventUpgrade carries the new tier in Amount
+// and the upgrade name in Subject; EventWarp marks a blink.
+const (
+	EventUpgrade = "upgrade"
+	EventWarp    = "warp"
+)
+
+// Reasons an upgrade cannot be bought. BuyUpgrade wraps them with details.
+var (
+	ErrUnknownUpgrade = errors.New("unknown upgrade")
+	ErrMaxTier        = errors.New("already at the top tier")
+	ErrCantAfford     = errors.New("not enough resources")
+)
+
+// UpgradeIntent asks Upgrades to buy the next tier of Kind.
+type UpgradeIntent struct{ Kind data.UpgradeKind }
+
+// Upgrades installs requested ship upgrades. Installing does not take a
+// turn, so it runs on every layer.
+type Upgrades struct{}
+
+func (Upgrades) Update(dt float64, w *ecs.World) {
+	for _, e := range sortedWith[UpgradeIntent](w) {
+		in, _ := ecs.Get[UpgradeIntent](w, e)
+		ecs.Remove[UpgradeIntent](w, e)
+		if err := BuyUpgrade(w, e, in.Kind); err != nil {
+			itemEvent(w, ecs.Event{Kind: EventItem, Source: e, Subject: upgradeName(in.Kind)}, "%s", capitalize(err.Error())+".")
+		}
+	}
+}
+
+// UpgradeTier returns the tier of ship upgrade kind installed for e.
+func UpgradeTier(w *ecs.World, e ecs.Entity, kind data.UpgradeKind) int {
+	ps, _ := ecs.Get[components.PlayerStats](w, e)
+	switch kind {
+	case data.Drive:
+		return ps.Drive
+	case data.Sensors:
+		return ps.Sensors
+	case data.Cargo:
+		return ps.Cargo
+	case data.Shield:
+		return ps.Shield
+	case data.Warp:
+		return ps.Warp
+	}
+	return 0
+}
+
+// CanUpgrade reports why e cannot buy the next tier of kind, or nil.
+func CanUpgrade(w *ecs.World, e ecs.Entity, kind data.UpgradeKind) error {
+	_, err := upgradeCost(w, e, kind)
+	return err
+}
+
+// BuyUpgrade pays for and installs the next tier of kind. The cost is checked
+// in full before anything is taken from the hold.
+func BuyUpgrade(w *ecs.World, e ecs.Entity, kind data.UpgradeKind) error {
+	cost, err := upgradeCost(w, e, kind)
+	if err != nil {
+		return err
+	}
+	for _, q := range cost {
+		RemoveItem(w, e, q.Item, q.Count)
+	}
+	tier := UpgradeTier(w, e, kind) + 1
+	ps, _ := ecs.Get[components.PlayerStats](w, e)
+	switch kind {
+	case data.Drive:
+		ps.Drive = tier
+	case data.Sensors:
+		ps.Sensors = tier
+	case data.Cargo:
+		ps.Cargo = tier
+	case data.Shield:
+		ps.Shield = tier
+		sh, _ := ecs.Get[components.Shield](w, e)
+		sh.Max = data.ShieldCapacity(tier)
+		sh.Current = sh.Max
+		ecs.Add(w, e, sh)
+	case data.Warp:
+		ps.Warp = tier
+	}
+	ecs.Add(w, e, ps)
+	itemEvent(w, ecs.Event{Kind: EventUpgrade, Source: e, Subject: upgradeName(kind), Amount: tier},
+		"%s upgraded to tier %s.", upgradeName(kind), RomanTier(tier))
+	return nil
+}
+
+// upgradeCost returns what the next tier of kind costs e.
+func upgradeCost(w *ecs.World, e ecs.Entity, kind data.UpgradeKind) ([]data.ItemQty, error) {
+	def, ok := data.Upgrade(kind)
+	if !ok {
+		return nil, fmt.Errorf("%w: %d", ErrUnknownUpgrade, kind)
+	}
+	cost, ok := def.Cost(UpgradeTier(w, e, kind) + 1)
+	if !ok {
+		return nil, fmt.Errorf("%w: %s %s", ErrMaxTier, def.Name, RomanTier(def.MaxTier()))
+	}
+	inv, _ := ecs.Get[components.Inventory](w, e)
+	if missing := missingItems(inv, cost); len(missing) > 0 {
+		return nil, fmt.Errorf("%w: %s", ErrCantAfford, describeQty(missing))
+	}
+	return cost, nil
+}
+
+// HazardDamage applies environmental damage to e's hull, or to its health
+// when it has no hull, after the Shield upgrade turns part of it away.
+// It returns the damage taken.
+func HazardDamage(w *ecs.World, e ecs.Entity, amount int) int {
+	amount = Shielded(w, e, amount)
+	if amount <= 0 {
+		return 0
+	}
+	if ps, ok := ecs.Get[components.PlayerStats](w, e); ok {
+		ps.Hull = max(0, ps.Hull-amount)
+		ecs.Add(w, e, ps)
+		return amount
+	}
+	InflictDamage(w, 0, e, amount)
+	return amount
+}
+
+// Shielded returns what is left of amount environmental damage once e's
+// Shield upgrade has turned its share away.
+func Shielded(w *ecs.World, e ecs.Entity, amount int) int {
+	if amount <= 0 {
+		return 0
+	}
+	mitigation := data.ShieldMitigation(UpgradeTier(w, e, data.Shield))
+	return max(0, amount-int(float64(amount)*mitigation+0.5))
+}
+
+func upgradeName(kind data.UpgradeKind) string {
+	if def, ok := data.Upgrade(kind); ok {
+		return def.Name
+	}
+	return "unknown"
+}
+
+// RomanTier renders an upgrade tier as a roman numeral, or "-" for none.
+func RomanTier(tier int) string {
+	numerals := []string{"-", "I", "II", "III", "IV", "V"}
+	if tier < 0 || tier >= len(numerals) {
+		return fmt.Sprint(tier)
+	}
+	return numerals[tier]
+}
+
+// warpFuel is what one blink burns.
+const warpFuel = 10
+
+// WarpIntent asks WarpDrive to blink the ship along its heading.
+type WarpIntent struct{}
+
+// WarpDrive resolves warp intents in space.
+type WarpDrive struct{}
+
+func (WarpDrive) Update(dt float64, w *ecs.World) {
+	for _, e := range sortedWith[WarpIntent](w) {
+		ecs.Remove[WarpIntent](w, e)
+		Blink(w, e)
+	}
+}
+
+// Blink jumps e WarpRange tiles along its heading and reports whether it
+// went.
+func Blink(w *ecs.World, e ecs.Entity) bool {
+	tier := UpgradeTier(w, e, data.Warp)
+	if tier == 0 {
+		itemEvent(w, ecs.Event{Kind: EventItem, Source: e}, "No warp drive is installed.")
+		return false
+	}
+	ps, _ := ecs.Get[components.PlayerStats](w, e)
+	if ps.Fuel < warpFuel {
+		itemEvent(w, ecs.Event{Kind: EventItem, Source: e}, "Not enough fuel to warp.")
+		return false
+	}
+	pos, _ := ecs.Get[components.Position](w, e)
+	spr, _ := ecs.Get[components.SpaceFlightSprings](w, e)
+	dist := float64(data.WarpRange(tier))
+	pos.X += cos(spr.Angle.Pos) * dist
+	pos.Y += sin(spr.Angle.Pos) * dist
+	ps.Fuel -= warpFuel
+	ecs.Add(w, e, pos)
+	ecs.Add(w, e, ps)
+	itemEvent(w, ecs.Event{Kind: EventWarp, Source: e}, "The warp drive folds space around you.")
+	return true
+}
@@ -0,0 +1,109 @@
+package systems
+
+import (
+	"errors"
+	"testing"
+
+	"harvester/pkg/components"
+	"harvester/pkg/data"
+	"harvester/pkg/ecs"
+)
+
+func TestBuyUpgradePaysAndRaisesTier(t *testing.T) {
+	w, p := newTestWorld(1)
+	ecs.Add(w, p, components.PlayerStats{Drive: 1})
+	AddItem(w, p, "energy", 12)
+	AddItem(w, p, "ore", 3)
+	if err := BuyUpgrade(w, p, data.Drive); !errors.Is(err, ErrCantAfford) {
+		t.Fatalf("expected to be short of ore, got %v", err)
+	}
+	if inv, _ := ecs.Get[components.Inventory](w, p); inv.Count("energy") != 12 || inv.Count("ore") != 3 {
+		t.Fatal("a failed purchase must not take anything")
+	}
+	AddItem(w, p, "ore", 1)
+	if err := BuyUpgrade(w, p, data.Drive); err != nil {
+		t.Fatal(err)
+	}
+	inv, _ := ecs.Get[components.Inventory](w, p)
+	if UpgradeTier(w, p, data.Drive) != 2 || inv.Count("energy") != 2 || inv.Count("ore") != 0 {
+		t.Fatalf("expected Drive II for 10 energy and 4 ore, tier %d, %+v", UpgradeTier(w, p, data.Drive), inv.Stacks)
+	}
+}
+
+func TestUpgradeStopsAtTopTier(t *testing.T) {
+	w, p := newTestWorld(1)
+	ecs.Add(w, p, components.PlayerStats{Cargo: 3})
+	if err := CanUpgrade(w, p, data.Cargo); !errors.Is(err, ErrMaxTier) {
+		t.Fatalf("Cargo tops out at III, got %v", err)
+	}
+}
+
+func TestShieldUpgradeChargesShieldAndMitigatesHazards(t *testing.T) {
+	w, p := newTestWorld(1)
+	ecs.Add(w, p, components.PlayerStats{Hull: 100})
+	if got := HazardDamage(w, p, 10); got != 10 {
+		t.Fatalf("no shield takes the full hit, got %d", got)
+	}
+	AddItem(w, p, "scrap", 4)
+	AddItem(w, p, "energy", 6)
+	ecs.Add(w, p, UpgradeIntent{Kind: data.Shield})
+	Upgrades{}.Update(0, w)
+	if sh, _ := ecs.Get[components.Shield](w, p); sh.Max != 10 || sh.Current != 10 {
+		t.Fatalf("Shield I should charge a 10 point shield, got %+v", sh)
+	}
+	if got := HazardDamage(w, p, 10); got != 8 {
+		t.Fatalf("Shield I turns away a fifth, took %d", got)
+	}
+	if ps, _ := ecs.Get[components.PlayerStats](w, p); ps.Hull != 82 {
+		t.Fatalf("hazards hit the hull, hull %d", ps.Hull)
+	}
+}
+
+func TestDriveTierRaisesThrustAndCutsBurn(t *testing.T) {
+	thrust := func(tier int) float64 {
+		w, p := newTestWorld(1)
+		ecs.SetWorldContext(w, ecs.WorldContext{CurrentLayer: ecs.LayerSpace})
+		ecs.Add(w, p, components.PlayerStats{Drive: tier, Fuel: 100})
+		ecs.Add(w, p, components.SpaceFlightSprings{})
+		SetPlayerInput(w, p, "up")
+		InputSystem{}.Update(0.05, w)
+		spr, _ := ecs.Get[components.SpaceFlightSprings](w, p)
+		return spr.Thrust.Target
+	}
+	if lo, hi := thrust(1), thrust(3); hi <= lo {
+		t.Fatalf("Drive III should out-thrust Drive I: %v vs %v", hi, lo)
+	}
+	burn := func(tier int) int {
+		w, p := newTestWorld(1)
+		ecs.Add(w, p, components.PlayerStats{Drive: tier, Fuel: 100})
+		ecs.Add(w, p, components.SpaceFlightSprings{Thrust: components.SpringState{Pos: 100}})
+		FuelSystem{}.Update(10, w)
+		ps, _ := ecs.Get[components.PlayerStats](w, p)
+		return 100 - ps.Fuel
+	}
+	if lo, hi := burn(5), burn(1); lo >= hi {
+		t.Fatalf("Drive V should burn less than Drive I: %d vs %d", lo, hi)
+	}
+}
+
+func TestBlinkNeedsWarpAndFuel(t *testing.T) {
+	w, p := newTestWorld(1)
+	ecs.Add(w, 1, components.WorldInfo{Width: 200, Height: 80})
+	ecs.Add(w, p, components.PlayerStats{Fuel: 15})
+	ecs.Add(w, p, components.SpaceFlightSprings{})
+	if Blink(w, p) {
+		t.Fatal("blinking needs a warp drive")
+	}
+	ecs.Add(w, p, components.PlayerStats{Fuel: 15, Warp: 1})
+	if !Blink(w, p) {
+		t.Fatal("expected a blink")
+	}
+	pos, _ := ecs.Get[components.Position](w, p)
+	ps, _ := ecs.Get[components.PlayerStats](w, p)
+	if pos.X != 5+float64(data.WarpRange(1)) || pos.Y != 5 || ps.Fuel != 5 {
+		t.Fatalf("expected an 8 tile hop east for 10 fuel, at %+v with %d fuel", pos, ps.Fuel)
+	}
+	if Blink(w, p) {
+		t.Fatal("blinking needs fuel")
+	}
+}
@@ -0,0 +1,182 @@
+package systems
+
+import (
+	"fmt"
+	"math"
+
+	"harvester/pkg/components"
+	"harvester/pkg/data"
+	"harvester/pkg/ecs"
+	"harvester/pkg/fov"
+	"harvester/pkg/pathfind"
+)
+
+// Sight radii on planet layers. Sensors tiers widen the player's view;
+// weather and depth narrow it.
+const (
+	surfaceSight = 8
+	deepSight    = 5
+	sensorSight  = 2
+	depthPerStep = 40 // depth per point of sight lost
+	maxDepthLoss = 3
+	minSight     = 2
+	scanCooldown = 5.0 // seconds between scans
+)
+
+// EventScan is emitted when the player sweeps the area with sensors.
+const EventScan = "scan"
+
+// Visibility is how much the player knows about a tile.
+type Visibility int
+
+const (
+	VisUnknown Visibility = iota
+	VisRemembered
+	VisVisible
+)
+
+// Sight is what an entity can see this tick. It is rebuilt by Vision every
+// tick and is not persisted.
+type Sight struct {
+	Radius   int
+	visible  map[pathfind.Point]bool
+	scanWait float64
+}
+
+// Sees reports whether (x, y) is currently in view.
+func (s Sight) Sees(x, y int) bool { return s.visible[pathfind.Point{X: x, Y: y}] }
+
+// ScanRequest asks Vision to sweep the area around the player, revealing
+// everything within twice the sight radius regardless of line of sight.
+type ScanRequest struct{}
+
+// Vision computes shadowcast fields of view for the player and every
+// creature with a Brain, and folds what the player sees into FogMemory.
+type Vision struct{}
+
+func (Vision) Update(dt float64, w *ecs.World) {
+	ctx := ecs.GetWorldContext(w)
+	if ctx.CurrentLayer != ecs.LayerPlanetSurface && ctx.CurrentLayer != ecs.LayerPlanetDeep {
+		return
+	}
+	nav := Navigation(w)
+	if nav.w == 0 || nav.h == 0 {
+		return
+	}
+	if player, pos, ok := findPlayer(w); ok {
+		updatePlayerSight(w, ctx, nav, player, int(pos.X), int(pos.Y), dt)
+	}
+	ecs.View2Of[Brain, components.Position](w).Each(func(t ecs.Tuple2[Brain, components.Position]) {
+		s := Sight{Radius: t.A.Perception, visible: map[pathfind.Point]bool{}}
+		fov.Compute(int(t.B.X), int(t.B.Y), s.Radius, nav.Opaque, func(x, y int) {
+			s.visible[pathfind.Point{X: x, Y: y}] = true
+		})
+		ecs.Add(w, t.E, s)
+	})
+}
+
+func updatePlayerSight(w *ecs.World, ctx ecs.WorldContext, nav *NavCache, player ecs.Entity, px, py int, dt float64) {
+	mem, ok := ecs.Get[components.FogMemory](w, player)
+	if !ok || mem.PlanetID != ctx.PlanetID || mem.Layer != int(ctx.CurrentLayer) || mem.Depth != ctx.Depth || mem.Width != nav.w || mem.Height != nav.h {
+		mem = components.NewFogMemory(ctx.PlanetID, int(ctx.CurrentLayer), ctx.Depth, nav.w, nav.h)
+	}
+	prev, _ := ecs.Get[Sight](w, player)
+	s := Sight{Radius: SightRadius(w), visible: map[pathfind.Point]bool{}, scanWait: prev.scanWait - dt}
+	fov.Compute(px, py, s.Radius, nav.Opaque, func(x, y int) {
+		s.visible[pathfind.Point{X: x, Y: y}] = true
+		mem.Mark(x, y)
+	})
+	if _, scan := ecs.Get[ScanRequest](w, player); scan {
+		ecs.Remove[ScanRequest](w, player)
+		if s.scanWait > 0 {
+			w.Emit(ecs.Event{Kind: EventScan, Source: player, Text: "Sensors are still recharging."})
+		} else {
+			r := 2 * s.Radius
+			for y := py - r; y <= py+r; y++ {
+				for x := px - r; x <= px+r; x++ {
+					if (x-px)*(x-px)+(y-py)*(y-py) <= r*r {
+						mem.Mark(x, y)
+					}
+				}
+			}
+			s.scanWait = scanCooldown
+			w.Emit(ecs.Event{Kind: EventScan, Source: player, Amount: r, Text: fmt.Sprintf("Sensor sweep maps the terrain within %d tiles.", r)})
+		}
+	}
+	ecs.Add(w, player, s)
+	ecs.Add(w, player, mem)
+}
+
+// SightRadius is the player's current sight radius from the layer, depth,
+// weather and Sensors tier.
+func SightRadius(w *ecs.World) int {
+	ctx := ecs.GetWorldContext(w)
+	r := surfaceSight
+	if ctx.CurrentLayer == ecs.LayerPlanetDeep {
+		r = deepSight
+	}
+	if loss := ctx.Depth / depthPerStep; loss > maxDepthLoss {
+		r -= maxDepthLoss
+	} else {
+		r -= loss
+	}
+	if player, pos, ok := findPlayer(w); ok {
+		kind, in := WeatherAt(w, int(pos.X), int(pos.Y))
+		r -= int(math.Round(data.EffectOf(kind).Sight * in))
+		ps, _ := ecs.Get[components.PlayerStats](w, player)
+		r += sensorSight * ps.Sensors
+	}
+	if r < minSight {
+		r = minSight
+	}
+	return r
+}
+
+// VisibilityOf returns a lookup for how well the player knows each tile.
+// In space everything is visible unless a nebula dampens the sensors;
+// elsewhere off the planet layers, or before Vision has run, it all is.
+func VisibilityOf(w *ecs.World) func(x, y int) Visibility {
+	all := func(int, int) Visibility { return VisVisible }
+	ctx := ecs.GetWorldContext(w)
+	if ctx.CurrentLayer == ecs.LayerSpace {
+		return spaceVisibility(w)
+	}
+	if ctx.CurrentLayer != ecs.LayerPlanetSurface && ctx.CurrentLayer != ecs.LayerPlanetDeep {
+		return all
+	}
+	player, _, ok := findPlayer(w)
+	if !ok {
+		return all
+	}
+	s, ok := ecs.Get[Sight](w, player)
+	if !ok {
+		return all
+	}
+	mem, _ := ecs.Get[components.FogMemory](w, player)
+	return func(x, y int) Visibility {
+		switch {
+		case s.Sees(x, y):
+			return VisVisible
+		case mem.Has(x, y):
+			return VisRemembered
+		}
+		return VisUnknown
+	}
+}
+
+// spaceVisibility sees everything in clear space. Inside a nebula only what
+// is within NebulaSight is in view and the rest of the map goes faint.
+func spaceVisibility(w *ecs.World) func(x, y int) Visibility {
+	r := NebulaSight(w)
+	if r == 0 {
+		return func(int, int) Visibility { return VisVisible }
+	}
+	_, pos, _ := findPlayer(w)
+	px, py := int(pos.X), int(pos.Y)
+	return func(x, y int) Visibility {
+		if chebyshev(px, py, x, y) <= r {
+			return VisVisible
+		}
+		return VisRemembered
+	}
+}
@@ -0,0 +1,142 @@
+package systems
+
+import (
+	"harvester/pkg/components"
+	"harvester/pkg/ecs"
+	"testing"
+)
+
+// ridge is a wall of mountains three tiles east of the player at (5, 5),
+// with a river beyond it.
+func ridge(w *ecs.World, _ ecs.Entity) {
+	for y := 0; y < 20; y++ {
+		addTile(w, 8, float64(y), components.TileMountain)
+	}
+	addTile(w, 12, 5, components.TileRiver)
+}
+
+func TestVisionSplitsVisibleRememberedUnknown(t *testing.T) {
+	w, p := newTestWorld(1, mapSize(40, 20), ridge)
+	Vision{}.Update(0.1, w)
+	vis := VisibilityOf(w)
+	if vis(6, 5) != VisVisible || vis(8, 5) != VisVisible {
+		t.Fatal("open ground and the wall itself should be visible")
+	}
+	if vis(12, 5) != VisUnknown {
+		t.Fatal("tiles behind the wall should be unknown")
+	}
+
+	ecs.Add(w, p, components.Position{X: 2, Y: 14})
+	Vision{}.Update(0.1, w)
+	if v := VisibilityOf(w)(6, 5); v != VisRemembered {
+		t.Fatalf("tile seen earlier should be remembered, got %v", v)
+	}
+}
+
+func TestTravelOnlyToResourcesSeen(t *testing.T) {
+	w, p := newTestWorld(1)
+	ecs.Add(w, 1, components.WorldInfo{Width: 40, Height: 20})
+	ore := w.Create()
+	ecs.Add(w, ore, components.Position{X: 35, Y: 15})
+	ecs.Add(w, ore, components.Resource{Kind: "ore", Amount: 1})
+	Vision{}.Update(0.1, w)
+	if TravelToResource(w, p) {
+		t.Fatal("auto-travel should not head for ore the player has never seen")
+	}
+	ecs.Add(w, ore, components.Position{X: 7, Y: 5})
+	if !TravelToResource(w, p) {
+		t.Fatal("ore in plain view should be a travel target")
+	}
+}
+
+func TestRenderHidesUnknownAndDimsRemembered(t *testing.T) {
+	w, p := newTestWorld(1, mapSize(40, 20), ridge)
+	foe := spawnFoe(w, 12, 6, 5)
+	ecs.Add(w, foe, components.Renderable{Glyph: 'w'})
+	Vision{}.Update(0.1, w)
+	ecs.Add(w, p, components.Position{X: 2, Y: 18})
+	Vision{}.Update(0.1, w)
+
+	r := &Render{}
+	r.Update(0, w)
+	var sawRiver, sawFoe bool
+	var wall *Drawable
+	for i, d := range r.Output {
+		switch {
+		case d.X == 12 && d.Y == 5:
+			sawRiver = true
+		case d.Glyph == 'w':
+			sawFoe = true
+		case d.X == 8 && d.Y == 5:
+			wall = &r.Output[i]
+		}
+	}
+	if sawRiver || sawFoe {
+		t.Error("unknown tiles and unseen creatures should not be drawn")
+	}
+	if wall == nil || !wall.Dim {
+		t.Error("remembered wall should be drawn dimmed")
+	}
+}
+
+func TestScanRevealsBeyondWalls(t *testing.T) {
+	w, p := newTestWorld(1, mapSize(40, 20), ridge)
+	SetPlayerInput(w, p, "scan")
+	Vision{}.Update(0.1, w)
+	if v := VisibilityOf(w)(12, 5); v != VisRemembered {
+		t.Fatalf("scan should map tiles behind the wall, got %v", v)
+	}
+	SetPlayerInput(w, p, "scan")
+	Vision{}.Update(0.1, w)
+	evs, _ := w.EventsSince(0)
+	if len(evs) != 2 || evs[1].Text != "Sensors are still recharging." {
+		t.Fatalf("second scan should be on cooldown, events %+v", evs)
+	}
+}
+
+func TestSightRadiusModifiers(t *testing.T) {
+	w, p := newTestWorld(1, mapSize(40, 20), ridge)
+	base := SightRadius(w)
+	ecs.Add(w, p, components.PlayerStats{Sensors: 2})
+	if r := SightRadius(w); r != base+2*sensorSight {
+		t.Errorf("sensors: radius %d, want %d", r, base+2*sensorSight)
+	}
+	weatherOver(w, components.WeatherRain, 5, 5)
+	if r := SightRadius(w); r != base+2*sensorSight-2 {
+		t.Errorf("rain: radius %d", r)
+	}
+	ctx := ecs.GetWorldContext(w)
+	ctx.CurrentLayer, ctx.Depth = ecs.LayerPlanetDeep, 1000
+	ecs.SetWorldContext(w, ctx)
+	// no weather underground
+	if r := SightRadius(w); r != deepSight-maxDepthLoss+2*sensorSight {
+		t.Errorf("deep: radius %d", r)
+	}
+}
+
+func TestCreatureCannotSeeThroughWalls(t *testing.T) {
+	w, _ := newTestWorld(1, mapSize(40, 20), ridge)
+	c := spawnCreature(w, 10, 5, true)
+	Vision{}.Update(0.1, w)
+	CreatureAI{}.Update(0.1, w)
+	if b, _ := ecs.Get[Brain](w, c); b.State == AIHunt {
+		t.Fatal("creature behind a wall should not spot the player")
+	}
+}
+
+func TestFogMemorySurvivesSaveLoad(t *testing.T) {
+	w, p := newTestWorld(1, mapSize(40, 20), ridge)
+	Vision{}.Update(0.1, w)
+	s, err := ecs.Save(w, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	w2 := ecs.NewWorld(nil)
+	if _, err := ecs.Load(w2, s, nil); err != nil {
+		t.Fatal(err)
+	}
+	mem, ok := ecs.Get[components.FogMemory](w2, p)
+	if !ok || !mem.Has(6, 5) || mem.Has(12, 5) {
+		t.Fatal("remembered tiles should round-trip through a save")
+	}
+}
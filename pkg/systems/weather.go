@@ -0,0 +1,214 @@
+package systems
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+
+	"harvester/pkg/components"
+	"harvester/pkg/data"
+	"harvester/pkg/ecs"
+)
+
+// Weather blows across the surface in fronts: patches of rain, snow, ash or
+// dust, brewed by the planet's biome, that drift downwind for a while and
+// then die down. Under a front the player walks slower and sees less, and
+// fires spread or go out.
+
+func init() {
+	ecs.RegisterComponent[Burning]()
+}
+
+// EventFire is emitted when the player sees a fire start.
+const EventFire = "fire"
+
+const (
+	maxFronts = 4
+	// frontSpeed is the range of how many tiles a front drifts per second.
+	frontSpeedMin, frontSpeedMax = 0.3, 1.2
+	// fireTurns is how long a tree burns before it is gone.
+	fireTurns = 6
+	// fireSpread is the chance per turn a fire catches each tree beside it
+	// in still air.
+	fireSpread = 0.15
+)
+
+var fire = components.Tile{Glyph: '♨', Type: components.TileLava}
+
+// Burning marks a tree on fire with the turns it has left to burn.
+type Burning struct{ Turns int }
+
+// prevailingWind is the direction fronts blow across a planet, the same on
+// every visit.
+func prevailingWind(seed int64, planet int) (float64, float64) {
+	a := rand.New(rand.NewSource(levelSeed(seed, planet, 0))).Float64() * 2 * math.Pi
+	return math.Cos(a), math.Sin(a)
+}
+
+// WeatherTick moves the surface's weather fronts with the wind, lets them
+// blow out, and brews new ones upwind from the biome's patterns.
+type WeatherTick struct{}
+
+func (WeatherTick) Update(dt float64, w *ecs.World) {
+	ctx := ecs.GetWorldContext(w)
+	wi, ok := ecs.Get[components.WorldInfo](w, 1)
+	if ctx.CurrentLayer != ecs.LayerPlanetSurface || !ok {
+		return
+	}
+	we, _ := ecs.Get[components.Weather](w, 1)
+	changed := false
+	if we.PlanetID != ctx.PlanetID {
+		we = components.Weather{PlanetID: ctx.PlanetID, Version: we.Version}
+		changed = true
+	}
+	fronts := we.Fronts[:0]
+	for _, f := range we.Fronts {
+		f.X += f.VX * dt
+		f.Y += f.VY * dt
+		f.Remaining -= dt
+		gone := f.X+f.Radius < 0 || f.Y+f.Radius < 0 || f.X-f.Radius > float64(wi.Width) || f.Y-f.Radius > float64(wi.Height)
+		if f.Remaining > 0 && !gone {
+			fronts = append(fronts, f)
+		}
+		changed = true
+	}
+	we.Fronts = fronts
+	r := w.Rand()
+	wx, wy := prevailingWind(w.Seed(), ctx.PlanetID)
+	for _, p := range data.Weather(data.BiomeType(ctx.BiomeType)) {
+		if len(we.Fronts) >= maxFronts || r.Float64() >= 1-math.Exp(-p.Chance*dt) {
+			continue
+		}
+		we.Fronts = append(we.Fronts, newFront(r, p, wi, wx, wy))
+		changed = true
+	}
+	if changed {
+		we.Version++
+	}
+	ecs.Add(w, 1, we)
+}
+
+// newFront rolls a front of pattern p blowing in over the upwind edge of
+// the map.
+func newFront(r *rand.Rand, p data.WeatherPattern, wi components.WorldInfo, wx, wy float64) components.WeatherFront {
+	roll := func(span [2]float64) float64 { return span[0] + r.Float64()*(span[1]-span[0]) }
+	cx, cy := float64(wi.Width)/2, float64(wi.Height)/2
+	// how far the edge is from the middle going upwind, and where along it
+	reach := math.Min(cx/math.Max(math.Abs(wx), 1e-9), cy/math.Max(math.Abs(wy), 1e-9))
+	across := (r.Float64()*2 - 1) * math.Hypot(cx, cy)
+	speed := frontSpeedMin + r.Float64()*(frontSpeedMax-frontSpeedMin)
+	return components.WeatherFront{
+		Kind:      p.Kind,
+		X:         cx - wx*reach - wy*across,
+		Y:         cy - wy*reach + wx*across,
+		VX:        wx * speed,
+		VY:        wy * speed,
+		Radius:    roll(p.Radius),
+		Intensity: roll(p.Intensity),
+		Remaining: roll(p.Seconds),
+	}
+}
+
+// WeatherAt returns the weather over tile (x, y) and its intensity. There is
+// none off the surface.
+func WeatherAt(w *ecs.World, x, y int) (components.WeatherKind, float64) {
+	if ecs.GetWorldContext(w).CurrentLayer != ecs.LayerPlanetSurface {
+		return components.WeatherClear, 0
+	}
+	we, _ := ecs.Get[components.Weather](w, 1)
+	return we.At(float64(x), float64(y))
+}
+
+// surfaceWeather is the weather the navigation grid is built for: the
+// surface's, or none below it.
+func surfaceWeather(w *ecs.World) components.Weather {
+	if ecs.GetWorldContext(w).CurrentLayer != ecs.LayerPlanetSurface {
+		return components.Weather{}
+	}
+	we, _ := ecs.Get[components.Weather](w, 1)
+	return we
+}
+
+// FireSpread burns forest: ash fronts set trees alight, fires catch the
+// trees beside them, faster in ash and dust and not at all in rain or snow,
+// which put them out instead. A burnt tree leaves open ground.
+type FireSpread struct{}
+
+func (FireSpread) Update(dt float64, w *ecs.World) {
+	ctx := ecs.GetWorldContext(w)
+	if ctx.CurrentLayer != ecs.LayerPlanetSurface {
+		return
+	}
+	trees := map[[2]int]ecs.Entity{}
+	ecs.View2Of[components.Tile, components.Position](w).Each(func(t ecs.Tuple2[components.Tile, components.Position]) {
+		if t.A.Glyph == forest.glyph && t.A.Type == forest.kind {
+			trees[[2]int{int(t.B.X), int(t.B.Y)}] = t.E
+		}
+	})
+	occupied := map[[2]int]bool{}
+	ecs.View2Of[components.Health, components.Position](w).Each(func(t ecs.Tuple2[components.Health, components.Position]) {
+		occupied[[2]int{int(t.B.X), int(t.B.Y)}] = true
+	})
+	r := w.Rand()
+	changed := false
+	ignite := func(at [2]int) bool {
+		e, ok := trees[at]
+		if !ok || occupied[at] {
+			return false
+		}
+		delete(trees, at)
+		ecs.Add(w, e, Burning{Turns: fireTurns})
+		ecs.Add(w, e, fire)
+		changed = true
+		return true
+	}
+
+	type flame struct {
+		e  ecs.Entity
+		at [2]int
+		b  Burning
+	}
+	var flames []flame
+	ecs.View2Of[Burning, components.Position](w).Each(func(t ecs.Tuple2[Burning, components.Position]) {
+		flames = append(flames, flame{t.E, [2]int{int(t.B.X), int(t.B.Y)}, *t.A})
+	})
+	for _, f := range flames {
+		kind, in := WeatherAt(w, f.at[0], f.at[1])
+		fx := data.EffectOf(kind).Fire * in
+		switch {
+		case fx < 0 && r.Float64() < -fx:
+			ecs.Remove[Burning](w, f.e)
+			ecs.Add(w, f.e, components.Tile{Glyph: forest.glyph, Type: forest.kind})
+			changed = true
+			continue
+		case f.b.Turns <= 1:
+			w.Destroy(f.e)
+			changed = true
+			continue
+		}
+		f.b.Turns--
+		ecs.Add(w, f.e, f.b)
+		for _, d := range [4][2]int{{1, 0}, {-1, 0}, {0, 1}, {0, -1}} {
+			if r.Float64() < fireSpread*(1+fx) {
+				ignite([2]int{f.at[0] + d[0], f.at[1] + d[1]})
+			}
+		}
+	}
+
+	we, _ := ecs.Get[components.Weather](w, 1)
+	player, _, _ := findPlayer(w)
+	sight, _ := ecs.Get[Sight](w, player)
+	for _, fr := range we.Fronts {
+		if r.Float64() >= data.EffectOf(fr.Kind).Ignite*fr.Intensity {
+			continue
+		}
+		a, d := r.Float64()*2*math.Pi, r.Float64()*fr.Radius
+		x, y := int(math.Round(fr.X+d*math.Cos(a))), int(math.Round(fr.Y+d*math.Sin(a)))
+		if ignite([2]int{x, y}) && sight.Sees(x, y) {
+			w.Emit(ecs.Event{Kind: EventFire, Source: player, Text: fmt.Sprintf("Embers in the %s set a tree alight.", fr.Kind)})
+		}
+	}
+	if changed {
+		InvalidateNavigation(w)
+	}
+}
@@ -0,0 +1,194 @@
+package systems
+
+import (
+	"math"
+	"testing"
+
+	"harvester/pkg/components"
+	"harvester/pkg/data"
+	"harvester/pkg/ecs"
+)
+
+// weatherOver settles a front of kind at full strength over (x, y).
+func weatherOver(w *ecs.World, kind components.WeatherKind, x, y float64) {
+	we, _ := ecs.Get[components.Weather](w, 1)
+	we.PlanetID = ecs.GetWorldContext(w).PlanetID
+	we.Fronts = append(we.Fronts, components.WeatherFront{Kind: kind, X: x, Y: y, Radius: 20, Intensity: 1, Remaining: 1000})
+	we.Version++
+	ecs.Add(w, 1, we)
+}
+
+func addTree(w *ecs.World, x, y float64) ecs.Entity {
+	e := addTile(w, x, y, components.TileForest)
+	ecs.Add(w, e, components.Tile{Glyph: forest.glyph, Type: forest.kind})
+	return e
+}
+
+func TestFrontsDriftAndBlowOver(t *testing.T) {
+	w, _ := newTestWorld(1, mapSize(200, 80), playerAt(100, 40), onPlanet(data.BiomeToftForest, 0))
+	we := components.Weather{PlanetID: 1, Fronts: []components.WeatherFront{
+		{Kind: components.WeatherRain, X: 50, Y: 40, VX: 1, Radius: 12.5, Intensity: 1, Remaining: 3},
+	}}
+	ecs.Add(w, 1, we)
+	front := func() (components.WeatherFront, bool) {
+		we, _ := ecs.Get[components.Weather](w, 1)
+		for _, f := range we.Fronts {
+			if f.Radius == 12.5 {
+				return f, true
+			}
+		}
+		return components.WeatherFront{}, false
+	}
+	WeatherTick{}.Update(1, w)
+	if f, ok := front(); !ok || f.X != 51 {
+		t.Fatalf("the front should drift a tile with the wind, got %+v", f)
+	}
+	WeatherTick{}.Update(1, w)
+	if _, in := WeatherAt(w, 52, 40); in <= 0 || in >= 1 {
+		t.Fatalf("a front should die down near its end, intensity %v", in)
+	}
+	WeatherTick{}.Update(1, w)
+	if _, ok := front(); ok {
+		t.Fatal("the front should have blown over")
+	}
+}
+
+func TestBiomesBrewTheirOwnWeather(t *testing.T) {
+	for _, biome := range []data.BiomeType{data.BiomeToftForest, data.BiomeVolcanic, data.BiomeIce, data.BiomeAncientRuins} {
+		w, _ := newTestWorld(1, mapSize(200, 80), playerAt(100, 40), onPlanet(biome, 0))
+		ctx := ecs.GetWorldContext(w)
+		wx, wy := prevailingWind(w.Seed(), ctx.PlanetID)
+		seen := map[components.WeatherKind]bool{}
+		for turn := 0; turn < 5000; turn++ {
+			WeatherTick{}.Update(1, w)
+			we, _ := ecs.Get[components.Weather](w, 1)
+			if len(we.Fronts) > maxFronts {
+				t.Fatalf("%v: %d fronts at once", biome, len(we.Fronts))
+			}
+			for _, f := range we.Fronts {
+				seen[f.Kind] = true
+				if math.Abs(f.VX*wy-f.VY*wx) > 1e-9 || f.VX*wx+f.VY*wy <= 0 {
+					t.Fatalf("%v: fronts should blow with the planet's wind, got %+v", biome, f)
+				}
+			}
+		}
+		want := map[components.WeatherKind]bool{}
+		for _, p := range data.Weather(biome) {
+			want[p.Kind] = true
+		}
+		if len(seen) != len(want) {
+			t.Fatalf("%v: expected weather %v, saw %v", biome, want, seen)
+		}
+		for k := range seen {
+			if !want[k] {
+				t.Fatalf("%v: %v does not belong here", biome, k)
+			}
+		}
+	}
+}
+
+func TestSnowSlowsTheWalk(t *testing.T) {
+	w, p := newTestWorld(1)
+	ecs.Add(w, 1, components.WorldInfo{Width: 20, Height: 20})
+	weatherOver(w, components.WeatherSnowstorm, 5, 5)
+	ecs.Add(w, p, components.Input{Right: true})
+	SurfaceMovement{}.Update(1, w)
+	pos, _ := ecs.Get[components.Position](w, p)
+	if want := 5 + 1/(1+data.EffectOf(components.WeatherSnowstorm).Drag); pos.X != want {
+		t.Fatalf("a full snowstorm should slow the step to %v, got %v", want, pos.X)
+	}
+}
+
+func TestFiresBurnOutUnlessRainPutsThemOut(t *testing.T) {
+	w, _ := newTestWorld(1)
+	ecs.Add(w, 1, components.WorldInfo{Width: 20, Height: 20})
+	tree := addTree(w, 15, 15)
+	ecs.Add(w, tree, Burning{Turns: fireTurns})
+	ecs.Add(w, tree, fire)
+	if !Navigation(w).Blocked(15, 15) {
+		t.Fatal("a burning tree should block the way")
+	}
+	weatherOver(w, components.WeatherRain, 15, 15)
+	FireSpread{}.Update(1, w)
+	if tile, _ := ecs.Get[components.Tile](w, tree); tile.Glyph != forest.glyph {
+		t.Fatalf("a downpour should put the fire out, tile %+v", tile)
+	}
+	if Navigation(w).Blocked(15, 15) {
+		t.Fatal("a tree put out should no longer block the way")
+	}
+
+	ecs.Add(w, 1, components.Weather{})
+	ecs.Add(w, tree, Burning{Turns: fireTurns})
+	ecs.Add(w, tree, fire)
+	for turn := 0; turn < fireTurns; turn++ {
+		FireSpread{}.Update(1, w)
+	}
+	if _, ok := ecs.Get[components.Tile](w, tree); ok {
+		t.Fatal("a fire left alone should burn the tree away")
+	}
+}
+
+func TestAshSetsTheForestAlight(t *testing.T) {
+	burnt := func(kind components.WeatherKind) int {
+		w, _ := newTestWorld(1)
+		ecs.Add(w, 1, components.WorldInfo{Width: 40, Height: 40})
+		trees := 0
+		for y := 10; y < 30; y++ {
+			for x := 10; x < 30; x++ {
+				addTree(w, float64(x), float64(y))
+				trees++
+			}
+		}
+		weatherOver(w, kind, 20, 20)
+		for turn := 0; turn < 300; turn++ {
+			FireSpread{}.Update(1, w)
+		}
+		standing := 0
+		ecs.View1Of[components.Tile](w).Each(func(_ ecs.Entity, t *components.Tile) {
+			if t.Glyph == forest.glyph {
+				standing++
+			}
+		})
+		return trees - standing
+	}
+	if n := burnt(components.WeatherAshFall); n == 0 {
+		t.Fatal("falling ash should set the forest alight")
+	}
+	if n := burnt(components.WeatherRain); n != 0 {
+		t.Fatalf("rain should start no fires, %d trees burnt", n)
+	}
+}
+
+func TestWeatherIsDrawnOverTheTiles(t *testing.T) {
+	w, p := newTestWorld(1)
+	ecs.Add(w, 1, components.WorldInfo{Width: 40, Height: 40})
+	ecs.Add(w, p, components.Renderable{Glyph: '@'})
+	weatherOver(w, components.WeatherRain, 20, 20)
+	r := &Render{}
+	r.Update(0, w)
+	drops, player := 0, -1
+	for i, d := range r.Output {
+		switch d.Glyph {
+		case ',':
+			if d.Alpha >= 1 || player >= 0 {
+				t.Fatalf("rain should be see-through and under the player: %+v", d)
+			}
+			drops++
+		case '@':
+			player = i
+		}
+	}
+	if drops == 0 || drops > 40*40/2 {
+		t.Fatalf("expected a scattering of raindrops, got %d", drops)
+	}
+
+	ctx := ecs.GetWorldContext(w)
+	ctx.CurrentLayer = ecs.LayerPlanetDeep
+	ecs.SetWorldContext(w, ctx)
+	r.Update(0, w)
+	for _, d := range r.Output {
+		if d.Glyph == ',' {
+			t.Fatal("it should not rain underground")
+		}
+	}
+}
@@ -17,7 +17,7 @@ func TestHarvest(t *testing.T) {
 	ecs.Add(c.World, c.Player, components.Action{Harvest: true})
 	c.Tick(1, 1)
 	inv, _ := ecs.Get[components.Inventory](c.World, c.Player)
-	require.Equal(t, 1, inv.Items["ore"])
+	require.Equal(t, 1, inv.Count("ore"))
 }
 
 func TestSaveLoad(t *testing.T) {
@@ -33,7 +33,8 @@ func TestSaveLoad(t *testing.T) {
 	// mutate
 	c.InjectKey("left")
 	c.Tick(3, 1)
-	require.NoError(t, ecs.Load(c.World, b, nil))
+	_, err = ecs.Load(c.World, b, nil)
+	require.NoError(t, err)
 	s2, _ := c.Snapshot()
 	var snap2 map[string]any
 	_ = json.Unmarshal(s2, &snap2)
@@ -0,0 +1,82 @@
+package testharness
+
+import (
+	"encoding/json"
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"harvester/pkg/components"
+	"harvester/pkg/ecs"
+)
+
+func buildWorld(order []int) *ecs.World {
+	w := ecs.NewWorld(nil)
+	es := []ecs.Entity{w.Create(), w.Create(), w.Create()}
+	for _, i := range order {
+		e := es[i]
+		ecs.Add(w, e, components.Position{X: float64(i) + 0.5, Y: -float64(i)})
+		inv := components.Inventory{Stacks: []components.ItemStack{{Item: "ore", Count: i}, {Item: "gas", Count: i * 2}}}
+		ecs.Add(w, e, inv)
+	}
+	return w
+}
+
+func TestCanonicalSnapshot_IndependentOfInsertionOrder(t *testing.T) {
+	a, b := buildWorld([]int{0, 1, 2}), buildWorld([]int{2, 0, 1})
+	sa, err := ecs.Save(a, nil)
+	require.NoError(t, err)
+	sb, err := ecs.Save(b, nil)
+	require.NoError(t, err)
+	ba, err := ecs.EncodeSnapshot(sa, ecs.SaveOptions{Compress: true})
+	require.NoError(t, err)
+	bb, err := ecs.EncodeSnapshot(sb, ecs.SaveOptions{Compress: true})
+	require.NoError(t, err)
+	require.Equal(t, ba, bb)
+
+	ha, err := a.Hash()
+	require.NoError(t, err)
+	hb, err := b.Hash()
+	require.NoError(t, err)
+	require.Equal(t, ha, hb)
+	require.Len(t, ha, 64)
+
+	ecs.Add(b, 1, components.Position{X: 9})
+	hc, err := b.Hash()
+	require.NoError(t, err)
+	require.NotEqual(t, ha, hc)
+}
+
+func TestCanonicalJSON_NormalizesRawPayloads(t *testing.T) {
+	s1 := &ecs.Snapshot{Version: 1, Next: 1, Components: map[string]map[ecs.Entity]json.RawMessage{
+		"components.Position": {1: json.RawMessage(`{"Y": 2.0, "X": 1e0}`)},
+	}}
+	s2 := &ecs.Snapshot{Version: 1, Next: 1, Components: map[string]map[ecs.Entity]json.RawMessage{
+		"components.Position": {1: json.RawMessage(`{"X":1,"Y":2}`)},
+	}}
+	b1, err := ecs.CanonicalJSON(s1)
+	require.NoError(t, err)
+	b2, err := ecs.CanonicalJSON(s2)
+	require.NoError(t, err)
+	require.Equal(t, string(b2), string(b1))
+
+	// canonical output stays a regular snapshot
+	var back ecs.Snapshot
+	require.NoError(t, json.Unmarshal(b1, &back))
+	w := ecs.NewWorld(nil)
+	_, err = ecs.Load(w, &back, nil)
+	require.NoError(t, err)
+	p, ok := ecs.Get[components.Position](w, 1)
+	require.True(t, ok)
+	require.Equal(t, components.Position{X: 1, Y: 2}, p)
+}
+
+func TestSave_ReportsEncoderErrors(t *testing.T) {
+	w := ecs.NewWorld(nil)
+	e := w.Create()
+	ecs.Add(w, e, components.Position{X: math.NaN()})
+	_, err := ecs.Save(w, nil)
+	require.Error(t, err)
+	_, err = w.Hash()
+	require.Error(t, err)
+}
@@ -22,7 +22,8 @@ func TestConcurrentSaveLoad(t *testing.T) {
 				s, err := ecs.Save(w, nil)
 				require.NoError(t, err)
 				w2 := ecs.NewWorld(nil)
-				require.NoError(t, ecs.Load(w2, s, nil))
+				_, err = ecs.Load(w2, s, nil)
+				require.NoError(t, err)
 			}
 		}()
 	}
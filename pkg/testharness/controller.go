@@ -80,7 +80,8 @@ type Snapshot struct {
 		Width  int `json:"w"`
 		Height int `json:"h"`
 	} `json:"camera"`
-	Tick int64 `json:"tick"`
+	Tick int64  `json:"tick"`
+	Hash string `json:"hash"`
 }
 
 func (c *Controller) Snapshot() ([]byte, error) {
@@ -94,5 +95,10 @@ func (c *Controller) Snapshot() ([]byte, error) {
 	s.Camera.X, s.Camera.Y = cam.X, cam.Y
 	s.Camera.Width, s.Camera.Height = cam.Width, cam.Height
 	s.Tick = wi.Tick
+	h, err := c.World.Hash()
+	if err != nil {
+		return nil, err
+	}
+	s.Hash = h
 	return json.MarshalIndent(s, "", "  ")
 }
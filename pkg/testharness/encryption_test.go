@@ -19,7 +19,8 @@ func TestEncodeDecode_WithPassword(t *testing.T) {
 	s2, err := ecs.DecodeSnapshot(b, ecs.SaveOptions{Compress: true, Password: "pw"})
 	require.NoError(t, err)
 	w2 := ecs.NewWorld(nil)
-	require.NoError(t, ecs.Load(w2, s2, nil))
+	_, err = ecs.Load(w2, s2, nil)
+	require.NoError(t, err)
 	p2, ok := ecs.Get[components.Position](w2, p)
 	require.True(t, ok)
 	require.Equal(t, 1.0, p2.X)
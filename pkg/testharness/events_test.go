@@ -0,0 +1,37 @@
+package testharness
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"harvester/pkg/ecs"
+)
+
+func TestEventsSinceCursor(t *testing.T) {
+	w := ecs.NewWorld(nil)
+	evs, cur := w.EventsSince(0)
+	require.Empty(t, evs)
+
+	w.Emit(ecs.Event{Kind: "a"})
+	w.Emit(ecs.Event{Kind: "b"})
+	evs, cur = w.EventsSince(cur)
+	require.Len(t, evs, 2)
+	require.Equal(t, "a", evs[0].Kind)
+
+	w.Emit(ecs.Event{Kind: "c"})
+	evs, _ = w.EventsSince(cur)
+	require.Len(t, evs, 1)
+	require.Equal(t, "c", evs[0].Kind)
+}
+
+func TestEventsLogIsBounded(t *testing.T) {
+	w := ecs.NewWorld(nil)
+	for i := 0; i < 1000; i++ {
+		w.Emit(ecs.Event{Kind: fmt.Sprint(i)})
+	}
+	evs, cur := w.EventsSince(0)
+	require.Less(t, len(evs), 1000)
+	require.Equal(t, uint64(1000), cur)
+	require.Equal(t, "999", evs[len(evs)-1].Kind)
+}
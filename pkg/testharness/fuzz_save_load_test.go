@@ -36,7 +36,7 @@ func FuzzSaveLoadEquivalence(f *testing.F) {
 			t.Fatalf("save1: %v", err)
 		}
 		w2 := ecs.NewWorld(nil)
-		if err := ecs.Load(w2, s1, nil); err != nil {
+		if _, err := ecs.Load(w2, s1, nil); err != nil {
 			t.Fatalf("load: %v", err)
 		}
 		s2, err := ecs.Save(w2, nil)
@@ -0,0 +1,82 @@
+package testharness
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"harvester/pkg/components"
+	"harvester/pkg/ecs"
+)
+
+func corruptSnapshot(t *testing.T) *ecs.Snapshot {
+	w := ecs.NewWorld(nil)
+	for i := 0; i < 3; i++ {
+		e := w.Create()
+		ecs.Add(w, e, components.Position{X: float64(i)})
+		ecs.Add(w, e, components.Health{HP: 5, Max: 5})
+	}
+	s, err := ecs.Save(w, nil)
+	require.NoError(t, err)
+	s.Components["components.Position"][2] = json.RawMessage(`{"X":"not a number"}`)
+	s.Components["components.Retired"] = map[ecs.Entity]json.RawMessage{1: json.RawMessage(`{}`)}
+	return s
+}
+
+func statsFor(r *ecs.LoadReport, name string) ecs.ComponentLoadStats {
+	for _, c := range r.Components {
+		if c.Component == name {
+			return c
+		}
+	}
+	return ecs.ComponentLoadStats{}
+}
+
+func TestLoadReport_CountsFailures(t *testing.T) {
+	w := ecs.NewWorld(nil)
+	report, err := ecs.Load(w, corruptSnapshot(t), nil)
+	require.NoError(t, err)
+	require.False(t, report.Clean())
+
+	pos := statsFor(report, "components.Position")
+	require.Equal(t, 2, pos.Loaded)
+	require.Equal(t, 1, pos.Failed)
+	require.Len(t, pos.Errors, 1)
+	require.Equal(t, ecs.Entity(2), pos.Errors[0].Entity)
+	require.Equal(t, 3, statsFor(report, "components.Health").Loaded)
+	require.Equal(t, []string{"components.Retired"}, report.Unknown)
+	require.Len(t, report.Warnings(), 2)
+
+	_, ok := ecs.Get[components.Position](w, 2)
+	require.False(t, ok, "bad row should be dropped")
+	_, ok = ecs.Get[components.Position](w, 3)
+	require.True(t, ok)
+}
+
+func TestLoadReport_StrictLeavesWorldUntouched(t *testing.T) {
+	w := ecs.NewWorld(nil)
+	e := w.Create()
+	ecs.Add(w, e, components.Position{X: 42})
+
+	report, err := ecs.LoadWithOptions(w, corruptSnapshot(t), ecs.LoadOptions{Strict: true})
+	require.True(t, errors.Is(err, ecs.ErrPartialLoad))
+	require.Equal(t, 1, report.Failed())
+
+	p, ok := ecs.Get[components.Position](w, e)
+	require.True(t, ok)
+	require.Equal(t, 42.0, p.X)
+	_, ok = ecs.Get[components.Health](w, e)
+	require.False(t, ok, "strict failure must not apply any component")
+	require.Equal(t, ecs.Entity(2), w.Create(), "allocator must not be restored")
+}
+
+func TestLoadReport_CleanSnapshot(t *testing.T) {
+	c := NewController(Options{Width: 20, Height: 10})
+	s, err := ecs.Save(c.World, nil)
+	require.NoError(t, err)
+	report, err := ecs.LoadWithOptions(ecs.NewWorld(nil), s, ecs.LoadOptions{Strict: true})
+	require.NoError(t, err)
+	require.True(t, report.Clean())
+	require.Empty(t, report.Warnings())
+}
@@ -40,7 +40,7 @@ func TestSaveLoad_EngineComponents(t *testing.T) {
 	if err != nil {
 		t.Fatal(err)
 	}
-	if err := ecs.Load(w, s2, nil); err != nil {
+	if _, err := ecs.Load(w, s2, nil); err != nil {
 		t.Fatal(err)
 	}
 }
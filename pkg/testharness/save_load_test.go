@@ -13,7 +13,8 @@ func roundtrip(t *testing.T, w *ecs.World) *ecs.World {
 	s, err := ecs.Save(w, nil)
 	require.NoError(t, err)
 	w2 := ecs.NewWorld(nil)
-	require.NoError(t, ecs.Load(w2, s, nil))
+	_, err = ecs.Load(w2, s, nil)
+	require.NoError(t, err)
 	return w2
 }
 
@@ -58,15 +59,31 @@ func TestSaveLoad_Camera_PlayerStats_WorldInfo(t *testing.T) {
 func TestSaveLoad_Inventory(t *testing.T) {
 	w := ecs.NewWorld(nil)
 	p := w.Create()
-	inv := components.Inventory{}
-	inv.Ensure()
-	inv.Items["ore"] = 3
+	inv := components.Inventory{
+		Stacks:   []components.ItemStack{{Item: "ore", Count: 3}, {Item: "knife", Count: 1}},
+		Equipped: map[string]string{"weapon": "knife"},
+	}
 	ecs.Add(w, p, inv)
 	w2 := roundtrip(t, w)
 	inv2, ok := ecs.Get[components.Inventory](w2, p)
 	require.True(t, ok)
-	require.NotNil(t, inv2.Items)
-	require.Equal(t, 3, inv2.Items["ore"])
+	require.Equal(t, 3, inv2.Count("ore"))
+	require.Equal(t, "knife", inv2.Equipped["weapon"])
+}
+
+func TestLoad_MigratesStringKeyedInventory(t *testing.T) {
+	s := &ecs.Snapshot{Version: 1, Next: 3, Components: map[string]map[ecs.Entity]json.RawMessage{
+		"components.Inventory": {2: json.RawMessage(`{"Items":{"ore":3,"herbs":2,"gone":0}}`)},
+	}}
+	w := ecs.NewWorld(nil)
+	report, err := ecs.Load(w, s, nil)
+	require.NoError(t, err)
+	require.True(t, report.Clean())
+	require.Equal(t, ecs.CurrentSnapshotVersion(), s.Version)
+	inv, ok := ecs.Get[components.Inventory](w, 2)
+	require.True(t, ok)
+	require.Equal(t, []components.ItemStack{{Item: "herbs", Count: 2}, {Item: "ore", Count: 3}}, inv.Stacks)
+	require.NotNil(t, inv.Equipped)
 }
 
 func TestSaveLoad_Tile_Renderable_Health_Resource(t *testing.T) {
@@ -100,8 +117,25 @@ func TestController_SaveLoad_EndToEnd(t *testing.T) {
 	require.NoError(t, err)
 	c.InjectKey("left")
 	c.Tick(3, 1)
-	require.NoError(t, ecs.Load(c.World, snap, nil))
+	_, err = ecs.Load(c.World, snap, nil)
+	require.NoError(t, err)
 	s2, _ := c.Snapshot()
 	_ = json.Unmarshal(s2, &b)
 	require.Equal(t, a["player"], b["player"])
 }
+
+func TestSaveLoad_RNGCarriesOn(t *testing.T) {
+	w := ecs.NewWorld(nil)
+	w.Rand().Intn(100)
+	w.Rand().Float64()
+	w2 := roundtrip(t, w)
+	next := w.Rand().Int63()
+	require.Equal(t, next, w2.Rand().Int63(), "a loaded game should roll what would have come next")
+
+	s, err := ecs.Save(w, nil)
+	require.NoError(t, err)
+	w.Rand().Int63()
+	_, err = ecs.Load(w, s, nil)
+	require.NoError(t, err)
+	require.NotEqual(t, next, w.Rand().Int63(), "reloading should not roll again from where the save was made")
+}
@@ -0,0 +1,44 @@
+package testharness
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"harvester/pkg/components"
+	"harvester/pkg/ecs"
+)
+
+func TestValidateSnapshot_CleanWorld(t *testing.T) {
+	c := NewController(Options{Width: 20, Height: 10})
+	s, err := ecs.Save(c.World, nil)
+	require.NoError(t, err)
+	require.Empty(t, ecs.ValidateSnapshot(s))
+}
+
+func TestValidateSnapshot_BadReferences(t *testing.T) {
+	w := ecs.NewWorld(nil)
+	a := w.Create()
+	b := w.Create()
+	ecs.Add(w, a, components.Position{X: 1})
+	w.Destroy(b)
+	s, err := ecs.Save(w, nil)
+	require.NoError(t, err)
+
+	pos := s.Components["components.Position"]
+	pos[b] = json.RawMessage(`{"X":2,"Y":0}`)
+	pos[99] = json.RawMessage(`{"X":3,"Y":0}`)
+	s.Components["components.Mystery"] = map[ecs.Entity]json.RawMessage{a: json.RawMessage(`{}`)}
+	s.Free = append(s.Free, b)
+
+	issues := ecs.ValidateSnapshot(s)
+	var problems []string
+	for _, is := range issues {
+		problems = append(problems, is.String())
+	}
+	require.Len(t, issues, 4, problems)
+	require.Contains(t, problems, "components.Position[2]: component attached to a freed entity")
+	require.Contains(t, problems, "components.Position[99]: entity outside allocated range 1..2")
+	require.Contains(t, problems, "components.Mystery: unknown component type; it will be ignored on load")
+	require.Contains(t, problems, "entity 2: listed twice in free list")
+}